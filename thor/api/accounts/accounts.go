@@ -85,6 +85,9 @@ func (a *Accounts) handleGetCode(w http.ResponseWriter, req *http.Request) error
 		return err
 	}
 
+	if revision.IsBest() {
+		utils.SetShortMaxAge(w)
+	}
 	return utils.WriteJSON(w, &api.GetCodeResult{Code: hexutil.Encode(code)})
 }
 
@@ -139,6 +142,9 @@ func (a *Accounts) handleGetAccount(w http.ResponseWriter, req *http.Request) er
 	if err != nil {
 		return err
 	}
+	if revision.IsBest() {
+		utils.SetShortMaxAge(w)
+	}
 	return utils.WriteJSON(w, acc)
 }
 
@@ -168,6 +174,9 @@ func (a *Accounts) handleGetStorage(w http.ResponseWriter, req *http.Request) er
 	if err != nil {
 		return err
 	}
+	if revision.IsBest() {
+		utils.SetShortMaxAge(w)
+	}
 	return utils.WriteJSON(w, &api.GetStorageResult{Value: storage.String()})
 }
 
@@ -211,6 +220,9 @@ func (a *Accounts) handleCallContract(w http.ResponseWriter, req *http.Request)
 	if err != nil {
 		return err
 	}
+	if revision.IsBest() || revision.IsNext() {
+		utils.SetShortMaxAge(w)
+	}
 	return utils.WriteJSON(w, results[0])
 }
 
@@ -240,6 +252,9 @@ func (a *Accounts) handleCallBatchCode(w http.ResponseWriter, req *http.Request)
 	if err != nil {
 		return err
 	}
+	if revision.IsBest() || revision.IsNext() {
+		utils.SetShortMaxAge(w)
+	}
 	return utils.WriteJSON(w, results)
 }
 
@@ -12,19 +12,34 @@ import (
 
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
+	"github.com/vechain/thor/v2/api/admin/apikeys"
 	"github.com/vechain/thor/v2/api/admin/apilogs"
 	"github.com/vechain/thor/v2/api/admin/loglevel"
+	"github.com/vechain/thor/v2/api/admin/webhooks"
+	"github.com/vechain/thor/v2/api/middleware"
 
 	healthAPI "github.com/vechain/thor/v2/api/admin/health"
 )
 
-func NewHTTPHandler(logLevel *slog.LevelVar, health *healthAPI.Health, apiLogsToggle *atomic.Bool) http.HandlerFunc {
+func NewHTTPHandler(
+	logLevel *slog.LevelVar,
+	health *healthAPI.Health,
+	apiLogsToggle *atomic.Bool,
+	hooks *webhooks.Webhooks,
+	apiKeys *middleware.APIKeys,
+) http.HandlerFunc {
 	router := mux.NewRouter()
 	subRouter := router.PathPrefix("/admin").Subrouter()
 
 	loglevel.New(logLevel).Mount(subRouter, "/loglevel")
 	healthAPI.NewAPI(health).Mount(subRouter, "/health")
 	apilogs.New(apiLogsToggle).Mount(subRouter, "/apilogs")
+	if hooks != nil {
+		webhooks.NewAPI(hooks).Mount(subRouter, "/webhooks")
+	}
+	if apiKeys != nil {
+		apikeys.New(apiKeys).Mount(subRouter, "/apikeys")
+	}
 
 	handler := handlers.CompressHandler(router)
 	return handler.ServeHTTP
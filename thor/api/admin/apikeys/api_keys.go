@@ -0,0 +1,81 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package apikeys
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/api/middleware"
+	"github.com/vechain/thor/v2/api/utils"
+	"github.com/vechain/thor/v2/log"
+)
+
+type APIKeys struct {
+	keys *middleware.APIKeys
+}
+
+func New(keys *middleware.APIKeys) *APIKeys {
+	return &APIKeys{
+		keys: keys,
+	}
+}
+
+func (a *APIKeys) handleList(w http.ResponseWriter, _ *http.Request) error {
+	return utils.WriteJSON(w, a.keys.List())
+}
+
+func (a *APIKeys) handleAdd(w http.ResponseWriter, r *http.Request) error {
+	var req api.APIKey
+	if err := utils.ParseJSON(r.Body, &req); err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "body"))
+	}
+	if err := a.keys.Add(&req); err != nil {
+		return utils.BadRequest(err)
+	}
+
+	log.Info("api key added", "pkg", "apikeys", "name", req.Name)
+
+	added := req
+	added.Key = ""
+	return utils.WriteJSON(w, &added)
+}
+
+func (a *APIKeys) handleRemove(w http.ResponseWriter, r *http.Request) error {
+	name := mux.Vars(r)["name"]
+	if err := a.keys.Remove(name); err != nil {
+		if err == middleware.ErrAPIKeyNotFound {
+			return utils.HTTPError(err, http.StatusNotFound)
+		}
+		return err
+	}
+
+	log.Info("api key removed", "pkg", "apikeys", "name", name)
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (a *APIKeys) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+
+	sub.Path("").
+		Methods(http.MethodGet).
+		Name("get-api-keys").
+		HandlerFunc(utils.WrapHandlerFunc(a.handleList))
+
+	sub.Path("").
+		Methods(http.MethodPost).
+		Name("post-api-keys").
+		HandlerFunc(utils.WrapHandlerFunc(a.handleAdd))
+
+	sub.Path("/{name}").
+		Methods(http.MethodDelete).
+		Name("delete-api-key").
+		HandlerFunc(utils.WrapHandlerFunc(a.handleRemove))
+}
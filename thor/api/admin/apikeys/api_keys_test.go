@@ -0,0 +1,67 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package apikeys
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/api/middleware"
+	"github.com/vechain/thor/v2/thorclient/httpclient"
+)
+
+func TestAPIKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	keys, err := middleware.NewAPIKeys(path)
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	New(keys).Mount(router, "/admin/apikeys")
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+	c := httpclient.New(ts.URL)
+
+	body, code, err := c.RawHTTPPost("/admin/apikeys", &api.APIKey{Name: "a"})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, code, string(body))
+
+	body, code, err = c.RawHTTPPost("/admin/apikeys", &api.APIKey{Name: "a", Key: "secret", Rate: 10})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, code, string(body))
+	var added api.APIKey
+	require.NoError(t, json.Unmarshal(body, &added))
+	assert.Equal(t, "a", added.Name)
+	assert.Empty(t, added.Key)
+
+	body, code, err = c.RawHTTPGet("/admin/apikeys")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, code)
+	var list []*api.APIKey
+	require.NoError(t, json.Unmarshal(body, &list))
+	require.Len(t, list, 1)
+	assert.Equal(t, float64(10), list[0].Rate)
+	assert.Empty(t, list[0].Key)
+
+	// the added key is saved with its secret, and loaded on restart
+	reloaded, err := middleware.NewAPIKeys(path)
+	require.NoError(t, err)
+	require.Len(t, reloaded.List(), 1)
+
+	_, code, err = c.RawHTTPDelete("/admin/apikeys/a")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, code)
+
+	_, code, err = c.RawHTTPDelete("/admin/apikeys/a")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, code)
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/kv"
+	"github.com/vechain/thor/v2/tx"
+)
+
+const (
+	headerWebhookID = "x-thor-webhook-id"
+	headerSignature = "x-thor-signature"
+
+	deliveryTimeout = 10 * time.Second
+)
+
+var (
+	// minBackoff and maxBackoff bound the exponential backoff between failed deliveries.
+	minBackoff = time.Second
+	maxBackoff = 5 * time.Minute
+
+	httpClient = &http.Client{Timeout: deliveryTimeout}
+)
+
+// worker delivers events of a single webhook, block by block, starting from its cursor.
+// The cursor is only advanced after the block's events were acknowledged, which gives
+// at-least-once delivery.
+type worker struct {
+	*hook
+	repo  *chain.Repository
+	store kv.Store
+
+	mu        sync.Mutex
+	cursor    uint32
+	lastError string
+
+	ctx    context.Context
+	cancel func()
+	done   chan struct{}
+}
+
+func (wk *worker) stop() {
+	wk.cancel()
+	<-wk.done
+}
+
+func (wk *worker) status() *api.Webhook {
+	wk.mu.Lock()
+	defer wk.mu.Unlock()
+
+	return &api.Webhook{
+		ID:            wk.ID,
+		URL:           wk.URL,
+		CriteriaSet:   wk.CriteriaSet,
+		Confirmations: wk.Confirmations,
+		Cursor:        wk.cursor,
+		LastError:     wk.lastError,
+	}
+}
+
+func (wk *worker) loop() error {
+	ticker := wk.repo.NewTicker()
+	backoff := time.Duration(0)
+
+	for {
+		wk.mu.Lock()
+		cursor := wk.cursor
+		wk.mu.Unlock()
+
+		best := wk.repo.BestBlockSummary().Header.Number()
+		if uint64(cursor)+uint64(wk.Confirmations) > uint64(best) {
+			select {
+			case <-wk.ctx.Done():
+				return wk.ctx.Err()
+			case <-ticker.C():
+			}
+			continue
+		}
+
+		err := wk.process(cursor)
+		if err == nil {
+			backoff = 0
+			wk.mu.Lock()
+			wk.cursor = cursor + 1
+			wk.lastError = ""
+			wk.mu.Unlock()
+			continue
+		}
+		if wk.ctx.Err() != nil {
+			return wk.ctx.Err()
+		}
+
+		backoff = min(max(backoff*2, minBackoff), maxBackoff)
+		logger.Debug("webhook delivery failed", "id", wk.ID, "block", cursor, "retry", backoff, "err", err)
+
+		wk.mu.Lock()
+		wk.lastError = err.Error()
+		wk.mu.Unlock()
+
+		select {
+		case <-wk.ctx.Done():
+			return wk.ctx.Err()
+		case <-time.After(backoff):
+		}
+	}
+}
+
+// process delivers the matched events of the block at the given number and advances the persisted cursor.
+func (wk *worker) process(num uint32) error {
+	id, err := wk.repo.NewBestChain().GetBlockID(num)
+	if err != nil {
+		return fmt.Errorf("get block id: %w", err)
+	}
+	blk, err := wk.repo.GetBlock(id)
+	if err != nil {
+		return fmt.Errorf("get block: %w", err)
+	}
+	receipts, err := wk.repo.GetBlockReceipts(id)
+	if err != nil {
+		return fmt.Errorf("get receipts: %w", err)
+	}
+
+	var (
+		header = blk.Header()
+		txs    = blk.Transactions()
+		events []*api.EventMessage
+	)
+	for i, receipt := range receipts {
+		for j, output := range receipt.Outputs {
+			for _, event := range output.Events {
+				if !wk.match(event) {
+					continue
+				}
+				msg, err := api.ConvertSubscriptionEvent(header, txs[i], uint32(j), event, false)
+				if err != nil {
+					return err
+				}
+				events = append(events, msg)
+			}
+		}
+	}
+
+	if len(events) > 0 {
+		if err := wk.deliver(&api.WebhookPayload{
+			WebhookID:      wk.ID,
+			BlockID:        id,
+			BlockNumber:    num,
+			BlockTimestamp: header.Timestamp(),
+			Events:         events,
+		}); err != nil {
+			return err
+		}
+	}
+	return wk.store.Put(cursorKey(wk.ID), binary.BigEndian.AppendUint32(nil, num+1))
+}
+
+func (wk *worker) match(event *tx.Event) bool {
+	return len(wk.CriteriaSet) == 0 || api.MatchEventCriteriaSet(wk.CriteriaSet, event)
+}
+
+func (wk *worker) deliver(payload *api.WebhookPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(wk.ctx, http.MethodPost, wk.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(headerWebhookID, wk.ID)
+	req.Header.Set(headerSignature, "sha256="+sign(wk.Secret, body))
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", res.StatusCode)
+	}
+	return nil
+}
+
+// sign returns the hex encoded HMAC-SHA256 of the body.
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
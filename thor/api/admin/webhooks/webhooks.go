@@ -0,0 +1,240 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package webhooks delivers matching contract events to registered HTTP endpoints.
+// Webhooks and their delivery cursors are persisted, so deliveries resume from where
+// they stopped after a restart.
+package webhooks
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"sync"
+
+	"github.com/pborman/uuid"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/co"
+	"github.com/vechain/thor/v2/kv"
+	"github.com/vechain/thor/v2/log"
+	"github.com/vechain/thor/v2/muxdb"
+)
+
+var logger = log.WithContext("pkg", "webhooks")
+
+const (
+	storeName    = "webhooks"
+	hookPrefix   = "h"
+	cursorPrefix = "c"
+)
+
+var errNotFound = errors.New("webhook not found")
+
+// hook is the persisted webhook config.
+type hook struct {
+	ID            string               `json:"id"`
+	URL           string               `json:"url"`
+	CriteriaSet   []*api.EventCriteria `json:"criteriaSet"`
+	Confirmations uint32               `json:"confirmations"`
+	Secret        string               `json:"secret"`
+}
+
+// Webhooks manages registered webhooks and runs one delivery worker per webhook.
+type Webhooks struct {
+	repo  *chain.Repository
+	store kv.Store
+
+	mu      sync.Mutex
+	workers map[string]*worker
+	goes    co.Goes
+}
+
+// New creates the webhooks manager and starts workers for persisted webhooks.
+func New(repo *chain.Repository, db *muxdb.MuxDB) (*Webhooks, error) {
+	w := &Webhooks{
+		repo:    repo,
+		store:   db.NewStore(storeName),
+		workers: make(map[string]*worker),
+	}
+
+	iter := w.store.Iterate(kv.Range(*util.BytesPrefix([]byte(hookPrefix))))
+	defer iter.Release()
+
+	for iter.Next() {
+		var h hook
+		if err := json.Unmarshal(iter.Value(), &h); err != nil {
+			return nil, fmt.Errorf("decode webhook: %w", err)
+		}
+		cursor, err := w.loadCursor(h.ID)
+		if err != nil {
+			return nil, fmt.Errorf("load cursor of webhook %s: %w", h.ID, err)
+		}
+		w.start(&h, cursor)
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Register validates and persists a new webhook, then starts delivering to it.
+func (w *Webhooks) Register(req *api.WebhookRequest) (*api.Webhook, error) {
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		return nil, fmt.Errorf("url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, errors.New("url: scheme must be http or https")
+	}
+	if req.Secret == "" {
+		return nil, errors.New("secret: must not be empty")
+	}
+	for i, c := range req.CriteriaSet {
+		if c == nil {
+			return nil, fmt.Errorf("criteriaSet[%d]: null not allowed", i)
+		}
+	}
+
+	cursor := w.repo.BestBlockSummary().Header.Number() + 1
+	if req.FromBlock != nil {
+		cursor = *req.FromBlock
+	}
+
+	h := &hook{
+		ID:            uuid.NewRandom().String(),
+		URL:           req.URL,
+		CriteriaSet:   req.CriteriaSet,
+		Confirmations: req.Confirmations,
+		Secret:        req.Secret,
+	}
+
+	data, err := json.Marshal(h)
+	if err != nil {
+		return nil, err
+	}
+	bulk := w.store.Bulk()
+	if err := bulk.Put(hookKey(h.ID), data); err != nil {
+		return nil, err
+	}
+	if err := bulk.Put(cursorKey(h.ID), binary.BigEndian.AppendUint32(nil, cursor)); err != nil {
+		return nil, err
+	}
+	if err := bulk.Write(); err != nil {
+		return nil, err
+	}
+
+	logger.Info("webhook registered", "id", h.ID, "url", h.URL, "from", cursor)
+	return w.start(h, cursor).status(), nil
+}
+
+// Remove stops the webhook and deletes it with its cursor.
+func (w *Webhooks) Remove(id string) error {
+	w.mu.Lock()
+	wk, ok := w.workers[id]
+	delete(w.workers, id)
+	w.mu.Unlock()
+
+	if !ok {
+		return errNotFound
+	}
+	wk.stop()
+
+	if err := w.store.Delete(hookKey(id)); err != nil {
+		return err
+	}
+	if err := w.store.Delete(cursorKey(id)); err != nil {
+		return err
+	}
+	logger.Info("webhook removed", "id", id)
+	return nil
+}
+
+// Get returns the status of the given webhook.
+func (w *Webhooks) Get(id string) (*api.Webhook, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	wk, ok := w.workers[id]
+	if !ok {
+		return nil, errNotFound
+	}
+	return wk.status(), nil
+}
+
+// List returns the status of all registered webhooks, sorted by ID.
+func (w *Webhooks) List() []*api.Webhook {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	list := make([]*api.Webhook, 0, len(w.workers))
+	for _, wk := range w.workers {
+		list = append(list, wk.status())
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+// Close stops all delivery workers.
+func (w *Webhooks) Close() {
+	w.mu.Lock()
+	for _, wk := range w.workers {
+		wk.cancel()
+	}
+	w.mu.Unlock()
+	w.goes.Wait()
+}
+
+func (w *Webhooks) start(h *hook, cursor uint32) *worker {
+	ctx, cancel := context.WithCancel(context.Background())
+	wk := &worker{
+		hook:   h,
+		repo:   w.repo,
+		store:  w.store,
+		cursor: cursor,
+		ctx:    ctx,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	w.mu.Lock()
+	w.workers[h.ID] = wk
+	w.mu.Unlock()
+
+	w.goes.Go(func() {
+		defer close(wk.done)
+		if err := wk.loop(); err != nil && !errors.Is(err, context.Canceled) {
+			logger.Warn("webhook worker stopped", "id", h.ID, "err", err)
+		}
+	})
+	return wk
+}
+
+func (w *Webhooks) loadCursor(id string) (uint32, error) {
+	data, err := w.store.Get(cursorKey(id))
+	if err != nil {
+		if w.store.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	if len(data) != 4 {
+		return 0, errors.New("invalid cursor length")
+	}
+	return binary.BigEndian.Uint32(data), nil
+}
+
+func hookKey(id string) []byte {
+	return []byte(hookPrefix + id)
+}
+
+func cursorKey(id string) []byte {
+	return []byte(cursorPrefix + id)
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package webhooks
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/api/utils"
+)
+
+type API struct {
+	webhooks *Webhooks
+}
+
+func NewAPI(webhooks *Webhooks) *API {
+	return &API{
+		webhooks: webhooks,
+	}
+}
+
+func (a *API) handleList(w http.ResponseWriter, _ *http.Request) error {
+	return utils.WriteJSON(w, a.webhooks.List())
+}
+
+func (a *API) handleRegister(w http.ResponseWriter, r *http.Request) error {
+	var req api.WebhookRequest
+	if err := utils.ParseJSON(r.Body, &req); err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "body"))
+	}
+	hook, err := a.webhooks.Register(&req)
+	if err != nil {
+		return utils.BadRequest(err)
+	}
+	return utils.WriteJSON(w, hook)
+}
+
+func (a *API) handleGet(w http.ResponseWriter, r *http.Request) error {
+	hook, err := a.webhooks.Get(mux.Vars(r)["id"])
+	if err != nil {
+		if err == errNotFound {
+			return utils.HTTPError(err, http.StatusNotFound)
+		}
+		return err
+	}
+	return utils.WriteJSON(w, hook)
+}
+
+func (a *API) handleRemove(w http.ResponseWriter, r *http.Request) error {
+	if err := a.webhooks.Remove(mux.Vars(r)["id"]); err != nil {
+		if err == errNotFound {
+			return utils.HTTPError(err, http.StatusNotFound)
+		}
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (a *API) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+
+	sub.Path("").
+		Methods(http.MethodGet).
+		Name("get-webhooks").
+		HandlerFunc(utils.WrapHandlerFunc(a.handleList))
+
+	sub.Path("").
+		Methods(http.MethodPost).
+		Name("post-webhooks").
+		HandlerFunc(utils.WrapHandlerFunc(a.handleRegister))
+
+	sub.Path("/{id}").
+		Methods(http.MethodGet).
+		Name("get-webhook").
+		HandlerFunc(utils.WrapHandlerFunc(a.handleGet))
+
+	sub.Path("/{id}").
+		Methods(http.MethodDelete).
+		Name("delete-webhook").
+		HandlerFunc(utils.WrapHandlerFunc(a.handleRemove))
+}
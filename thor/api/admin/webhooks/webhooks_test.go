@@ -0,0 +1,290 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/test/eventcontract"
+	"github.com/vechain/thor/v2/test/testchain"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/thorclient/httpclient"
+	"github.com/vechain/thor/v2/tx"
+)
+
+const secret = "s3cr3t"
+
+func init() {
+	minBackoff = 10 * time.Millisecond
+	maxBackoff = 50 * time.Millisecond
+}
+
+// receiver is a local webhook target which fails the first failures requests.
+// Invalid requests are reported on errs, to be checked by the test goroutine.
+type receiver struct {
+	mu       sync.Mutex
+	failures int
+	payloads []*api.WebhookPayload
+	errs     chan error
+}
+
+func newReceiver(failures int) *receiver {
+	return &receiver{failures: failures, errs: make(chan error, 16)}
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		rc.fail(w, err)
+		return
+	}
+	if sig := r.Header.Get(headerSignature); sig != "sha256="+sign(secret, body) {
+		rc.fail(w, fmt.Errorf("invalid signature %q", sig))
+		return
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if rc.failures > 0 {
+		rc.failures--
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	var payload api.WebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		rc.fail(w, err)
+		return
+	}
+	if id := r.Header.Get(headerWebhookID); id != payload.WebhookID {
+		rc.fail(w, fmt.Errorf("webhook id header %q, payload %q", id, payload.WebhookID))
+		return
+	}
+	rc.payloads = append(rc.payloads, &payload)
+}
+
+func (rc *receiver) fail(w http.ResponseWriter, err error) {
+	select {
+	case rc.errs <- err:
+	default:
+	}
+	w.WriteHeader(http.StatusBadRequest)
+}
+
+// received returns the delivered payloads, and fails the test if an invalid request was received.
+func (rc *receiver) received(t *testing.T) []*api.WebhookPayload {
+	select {
+	case err := <-rc.errs:
+		require.NoError(t, err)
+	default:
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return append([]*api.WebhookPayload(nil), rc.payloads...)
+}
+
+func TestWebhooks_Delivery(t *testing.T) {
+	thorChain := initChain(t)
+	rc := newReceiver(2)
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	hooks, err := New(thorChain.Repo(), thorChain.Database())
+	require.NoError(t, err)
+	defer hooks.Close()
+
+	from := uint32(0)
+	deployed := thor.Keccak256([]byte("Deployed(string)"))
+	hook, err := hooks.Register(&api.WebhookRequest{
+		URL:         srv.URL,
+		Secret:      secret,
+		FromBlock:   &from,
+		CriteriaSet: []*api.EventCriteria{{TopicSet: api.TopicSet{Topic0: &deployed}}},
+	})
+	require.NoError(t, err)
+
+	best := thorChain.Repo().BestBlockSummary().Header.Number()
+	require.Eventually(t, func() bool {
+		status, err := hooks.Get(hook.ID)
+		require.NoError(t, err)
+		return status.Cursor == best+1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	payloads := rc.received(t)
+	require.Len(t, payloads, 1)
+	assert.Equal(t, best, payloads[0].BlockNumber)
+	assert.Equal(t, thorChain.Repo().BestBlockSummary().Header.ID(), payloads[0].BlockID)
+	require.Len(t, payloads[0].Events, 1)
+	assert.Equal(t, deployed, payloads[0].Events[0].Topics[0])
+
+	status, err := hooks.Get(hook.ID)
+	require.NoError(t, err)
+	assert.Empty(t, status.LastError)
+}
+
+func TestWebhooks_Confirmations(t *testing.T) {
+	thorChain := initChain(t)
+	rc := newReceiver(0)
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	hooks, err := New(thorChain.Repo(), thorChain.Database())
+	require.NoError(t, err)
+	defer hooks.Close()
+
+	best := thorChain.Repo().BestBlockSummary().Header.Number()
+	from := best
+	hook, err := hooks.Register(&api.WebhookRequest{
+		URL:           srv.URL,
+		Secret:        secret,
+		Confirmations: 1,
+		FromBlock:     &from,
+	})
+	require.NoError(t, err)
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, rc.received(t))
+
+	require.NoError(t, thorChain.MintBlock(genesis.DevAccounts()[0]))
+	require.Eventually(t, func() bool {
+		status, err := hooks.Get(hook.ID)
+		require.NoError(t, err)
+		return status.Cursor == best+1
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Len(t, rc.received(t), 1)
+}
+
+func TestWebhooks_Persistence(t *testing.T) {
+	thorChain := initChain(t)
+
+	hooks, err := New(thorChain.Repo(), thorChain.Database())
+	require.NoError(t, err)
+
+	// an unreachable target keeps the cursor in place
+	from := uint32(1)
+	hook, err := hooks.Register(&api.WebhookRequest{
+		URL:         "http://127.0.0.1:1",
+		Secret:      secret,
+		FromBlock:   &from,
+		CriteriaSet: []*api.EventCriteria{{}},
+	})
+	require.NoError(t, err)
+	hooks.Close()
+
+	hooks, err = New(thorChain.Repo(), thorChain.Database())
+	require.NoError(t, err)
+	defer hooks.Close()
+
+	list := hooks.List()
+	require.Len(t, list, 1)
+	assert.Equal(t, hook.ID, list[0].ID)
+	assert.Equal(t, hook.URL, list[0].URL)
+	assert.Equal(t, uint32(1), list[0].Cursor)
+
+	require.NoError(t, hooks.Remove(hook.ID))
+	assert.Empty(t, hooks.List())
+	assert.Equal(t, errNotFound, hooks.Remove(hook.ID))
+
+	_, err = hooks.store.Get(cursorKey(hook.ID))
+	assert.True(t, hooks.store.IsNotFound(err))
+}
+
+func TestWebhooks_Register_Invalid(t *testing.T) {
+	thorChain := initChain(t)
+
+	hooks, err := New(thorChain.Repo(), thorChain.Database())
+	require.NoError(t, err)
+	defer hooks.Close()
+
+	for _, req := range []*api.WebhookRequest{
+		{URL: "ftp://localhost", Secret: secret},
+		{URL: "http://localhost"},
+		{URL: "http://localhost", Secret: secret, CriteriaSet: []*api.EventCriteria{nil}},
+	} {
+		_, err := hooks.Register(req)
+		assert.Error(t, err)
+	}
+	assert.Empty(t, hooks.List())
+}
+
+func TestWebhooksAPI(t *testing.T) {
+	thorChain := initChain(t)
+
+	hooks, err := New(thorChain.Repo(), thorChain.Database())
+	require.NoError(t, err)
+	defer hooks.Close()
+
+	router := mux.NewRouter()
+	NewAPI(hooks).Mount(router, "/admin/webhooks")
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+	c := httpclient.New(ts.URL)
+
+	body, code, err := c.RawHTTPPost("/admin/webhooks", &api.WebhookRequest{URL: "http://localhost"})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, code, string(body))
+
+	body, code, err = c.RawHTTPPost("/admin/webhooks", &api.WebhookRequest{URL: "http://127.0.0.1:1", Secret: secret})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, code, string(body))
+	var hook api.Webhook
+	require.NoError(t, json.Unmarshal(body, &hook))
+	assert.NotEmpty(t, hook.ID)
+
+	body, code, err = c.RawHTTPGet("/admin/webhooks")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, code)
+	var list []*api.Webhook
+	require.NoError(t, json.Unmarshal(body, &list))
+	require.Len(t, list, 1)
+	assert.Equal(t, hook.ID, list[0].ID)
+
+	_, code, err = c.RawHTTPGet("/admin/webhooks/" + hook.ID)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, code)
+
+	_, code, err = c.RawHTTPDelete("/admin/webhooks/" + hook.ID)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, code)
+
+	_, code, err = c.RawHTTPGet("/admin/webhooks/" + hook.ID)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func initChain(t *testing.T) *testchain.Chain {
+	forks := testchain.DefaultForkConfig
+	forks.GALACTICA = 1
+	thorChain, err := testchain.NewWithFork(&forks)
+	require.NoError(t, err)
+
+	txDeploy := tx.NewBuilder(tx.TypeDynamicFee).
+		ChainTag(thorChain.Repo().ChainTag()).
+		MaxFeePerGas(big.NewInt(thor.InitialBaseFee)).
+		Expiration(100).
+		Gas(1_000_000).
+		Nonce(1).
+		Clause(tx.NewClause(nil).WithData(common.Hex2Bytes(eventcontract.HexBytecode))).
+		BlockRef(tx.NewBlockRef(0)).
+		Build()
+	txDeploy = tx.MustSign(txDeploy, genesis.DevAccounts()[0].PrivateKey)
+	require.NoError(t, thorChain.MintTransactions(genesis.DevAccounts()[0], txDeploy))
+
+	return thorChain
+}
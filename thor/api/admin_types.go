@@ -7,6 +7,8 @@ package api
 
 import (
 	"time"
+
+	"github.com/vechain/thor/v2/thor"
 )
 
 type LogStatus struct {
@@ -27,3 +29,48 @@ type LogLevelRequest struct {
 type LogLevelResponse struct {
 	CurrentLevel string `json:"currentLevel"`
 }
+
+// WebhookRequest registers a webhook that receives matching contract events.
+type WebhookRequest struct {
+	URL           string           `json:"url"`
+	CriteriaSet   []*EventCriteria `json:"criteriaSet"`
+	Confirmations uint32           `json:"confirmations"`
+	Secret        string           `json:"secret"`
+	FromBlock     *uint32          `json:"fromBlock,omitempty"`
+}
+
+// Webhook describes a registered webhook and its delivery status.
+type Webhook struct {
+	ID            string           `json:"id"`
+	URL           string           `json:"url"`
+	CriteriaSet   []*EventCriteria `json:"criteriaSet"`
+	Confirmations uint32           `json:"confirmations"`
+	Cursor        uint32           `json:"cursor"`
+	LastError     string           `json:"lastError,omitempty"`
+}
+
+// WebhookPayload is the body POSTed to a webhook target, one per block with matched events.
+type WebhookPayload struct {
+	WebhookID      string          `json:"webhookID"`
+	BlockID        thor.Bytes32    `json:"blockID"`
+	BlockNumber    uint32          `json:"blockNumber"`
+	BlockTimestamp uint64          `json:"blockTimestamp"`
+	Events         []*EventMessage `json:"events"`
+}
+
+// APIKey is an API key with its rate limits. Rate is in requests per second, a zero
+// rate means unlimited. Quotas apply additional limits to routes under a path prefix.
+type APIKey struct {
+	Name   string         `json:"name"`
+	Key    string         `json:"key,omitempty"`
+	Rate   float64        `json:"rate"`
+	Burst  int            `json:"burst"`
+	Quotas []*APIKeyQuota `json:"quotas,omitempty"`
+}
+
+// APIKeyQuota limits the requests of an API key to the routes under Prefix.
+type APIKeyQuota struct {
+	Prefix string  `json:"prefix"`
+	Rate   float64 `json:"rate"`
+	Burst  int     `json:"burst"`
+}
@@ -16,7 +16,6 @@ import (
 	"github.com/vechain/thor/v2/api"
 	"github.com/vechain/thor/v2/api/utils"
 	"github.com/vechain/thor/v2/bft"
-	"github.com/vechain/thor/v2/block"
 	"github.com/vechain/thor/v2/chain"
 	"github.com/vechain/thor/v2/thor"
 )
@@ -59,6 +58,18 @@ func (b *Blocks) handleGetBlock(w http.ResponseWriter, req *http.Request) error
 		return err
 	}
 
+	isTrunk, err := b.isTrunk(summary.Header.ID(), summary.Header.Number())
+	if err != nil {
+		return err
+	}
+	isFinalized := isTrunk && utils.IsFinalized(summary.Header.Number(), b.bft)
+
+	if isFinalized && revision.IsFixed() {
+		utils.SetImmutable(w)
+	} else if revision.IsBest() {
+		utils.SetShortMaxAge(w)
+	}
+
 	if raw {
 		rlpEncoded, err := rlp.EncodeToBytes(summary.Header)
 		if err != nil {
@@ -69,19 +80,6 @@ func (b *Blocks) handleGetBlock(w http.ResponseWriter, req *http.Request) error
 		})
 	}
 
-	isTrunk, err := b.isTrunk(summary.Header.ID(), summary.Header.Number())
-	if err != nil {
-		return err
-	}
-
-	var isFinalized bool
-	if isTrunk {
-		finalized := b.bft.Finalized()
-		if block.Number(finalized) >= summary.Header.Number() {
-			isFinalized = true
-		}
-	}
-
 	jSummary := api.BuildJSONBlockSummary(summary, isTrunk, isFinalized)
 	if expanded {
 		txs, err := b.repo.GetBlockTransactions(summary.Header.ID())
@@ -60,6 +60,7 @@ func TestBlock(t *testing.T) {
 		"testGetBlockWithRevisionNumberTooHigh": testGetBlockWithRevisionNumberTooHigh,
 		"testMutuallyExclusiveQueries":          testMutuallyExclusiveQueries,
 		"testGetRawBlock":                       testGetRawBlock,
+		"testCacheControl":                      testCacheControl,
 	} {
 		t.Run(name, tt)
 	}
@@ -225,6 +226,23 @@ func testGetBlockWithRevisionNumberTooHigh(t *testing.T) {
 	assert.Equal(t, "revision: block number out of max uint32", strings.TrimSpace(string(res)))
 }
 
+func testCacheControl(t *testing.T) {
+	for path, expected := range map[string]string{
+		"/blocks/0": "public, max-age=31536000, immutable",
+		"/blocks/" + genesisBlock.Header().ID().String(): "public, max-age=31536000, immutable",
+		"/blocks/0?raw=true":                             "public, max-age=31536000, immutable",
+		"/blocks/finalized":                              "",
+		"/blocks/" + blk.Header().ID().String():          "",
+		"/blocks/best":                                   "public, max-age=1",
+	} {
+		res, err := http.Get(ts.URL + path)
+		require.NoError(t, err)
+		res.Body.Close()
+		assert.Equal(t, http.StatusOK, res.StatusCode, path)
+		assert.Equal(t, expected, res.Header.Get("Cache-Control"), path)
+	}
+}
+
 func initBlockServer(t *testing.T) {
 	forks := thor.ForkConfig{
 		BLOCKLIST: 0,
@@ -0,0 +1,222 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package certificates verifies the certificates signed by wallets to log in their users. Besides the signature,
+// a certificate must be fresh and of an allowed domain. It may be signed by the key of the signer, by the master of
+// a signer contract, or by a key the signer contract accepts as specified by ERC-1271, checked on the best block.
+package certificates
+
+import (
+	"bytes"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/api/utils"
+	"github.com/vechain/thor/v2/certificate"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/runtime"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+	"github.com/vechain/thor/v2/xenv"
+)
+
+// isValidSignature is the selector of isValidSignature(bytes32,bytes), and the value it returns for valid signatures.
+var isValidSignature = []byte{0x16, 0x26, 0xba, 0x7e}
+
+type Config struct {
+	Domains []string      // the allowed domains, a leading "*." matches the sub domains, any domain if empty
+	MaxAge  time.Duration // the max difference between the timestamp and now, no limit if zero
+}
+
+type Certificates struct {
+	repo         *chain.Repository
+	stater       *state.Stater
+	forkConfig   *thor.ForkConfig
+	callGasLimit uint64
+	config       Config
+	now          func() time.Time
+}
+
+func New(
+	repo *chain.Repository,
+	stater *state.Stater,
+	forkConfig *thor.ForkConfig,
+	callGasLimit uint64,
+	config Config,
+) *Certificates {
+	domains := make([]string, 0, len(config.Domains))
+	for _, d := range config.Domains {
+		if d = strings.ToLower(strings.TrimSpace(d)); d != "" {
+			domains = append(domains, d)
+		}
+	}
+	config.Domains = domains
+	return &Certificates{
+		repo:         repo,
+		stater:       stater,
+		forkConfig:   forkConfig,
+		callGasLimit: callGasLimit,
+		config:       config,
+		now:          time.Now,
+	}
+}
+
+// Verify checks the domain, the timestamp and the signature of the certificate.
+func (c *Certificates) Verify(cert *certificate.Certificate) (*api.CertificateVerification, error) {
+	result := &api.CertificateVerification{Signer: cert.Signer}
+	invalid := func(reason string) (*api.CertificateVerification, error) {
+		result.Reason = reason
+		return result, nil
+	}
+
+	if !c.allowDomain(cert.Domain) {
+		return invalid("domain not allowed")
+	}
+	if maxAge := c.config.MaxAge; maxAge > 0 {
+		age := c.now().Sub(time.Unix(int64(cert.Timestamp), 0))
+		if age > maxAge {
+			return invalid("certificate expired")
+		}
+		if age < -maxAge {
+			return invalid("timestamp in the future")
+		}
+	}
+	key, err := cert.Recover()
+	if err != nil {
+		return invalid(err.Error())
+	}
+	result.Key = &key
+
+	if key == cert.Signer {
+		result.Valid, result.SignedBy = true, api.CertSignedByKey
+		return result, nil
+	}
+
+	best := c.repo.BestBlockSummary()
+	st := c.stater.NewState(best.Root())
+	master, err := st.GetMaster(cert.Signer)
+	if err != nil {
+		return nil, err
+	}
+	if master == key {
+		result.Valid, result.SignedBy = true, api.CertSignedByMaster
+		return result, nil
+	}
+	code, err := st.GetCode(cert.Signer)
+	if err != nil {
+		return nil, err
+	}
+	if len(code) == 0 {
+		return invalid("signature of another key")
+	}
+	valid, err := c.callIsValidSignature(cert, best, st)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return invalid("signature rejected by the signer contract")
+	}
+	result.Valid, result.SignedBy = true, api.CertSignedByContract
+	return result, nil
+}
+
+func (c *Certificates) allowDomain(domain string) bool {
+	if len(c.config.Domains) == 0 {
+		return true
+	}
+	domain = strings.ToLower(domain)
+	for _, allowed := range c.config.Domains {
+		if domain == allowed {
+			return true
+		}
+		if suffix, ok := strings.CutPrefix(allowed, "*"); ok && strings.HasPrefix(suffix, ".") &&
+			strings.HasSuffix(domain, suffix) && len(domain) > len(suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// callIsValidSignature calls isValidSignature(bytes32,bytes) of the signer contract on the best block.
+func (c *Certificates) callIsValidSignature(
+	cert *certificate.Certificate,
+	best *chain.BlockSummary,
+	st *state.State,
+) (bool, error) {
+	hash, err := cert.SigningHash()
+	if err != nil {
+		return false, err
+	}
+	// the signature is padded to 32 bytes words, after its offset and length
+	data := make([]byte, 0, 4+32*3+(len(cert.Signature)+31)/32*32)
+	data = append(data, isValidSignature...)
+	data = append(data, hash.Bytes()...)
+	data = append(data, word(0x40)...)
+	data = append(data, word(uint64(len(cert.Signature)))...)
+	data = append(data, cert.Signature...)
+	data = append(data, make([]byte, (32-len(cert.Signature)%32)%32)...)
+
+	header := best.Header
+	signer, _ := header.Signer()
+	rt := runtime.New(c.repo.NewChain(header.ParentID()), st,
+		&xenv.BlockContext{
+			Beneficiary: header.Beneficiary(),
+			Signer:      signer,
+			Number:      header.Number(),
+			Time:        header.Timestamp(),
+			GasLimit:    header.GasLimit(),
+			TotalScore:  header.TotalScore(),
+			BaseFee:     header.BaseFee(),
+		},
+		c.forkConfig)
+	exec, _ := rt.PrepareClause(tx.NewClause(&cert.Signer).WithData(data), 0, c.callGasLimit,
+		&xenv.TransactionContext{
+			GasPrice:    new(big.Int),
+			ProvedWork:  new(big.Int),
+			ClauseCount: 1,
+		})
+	out, _, err := exec()
+	if err != nil {
+		return false, err
+	}
+	if out.VMErr != nil || len(out.Data) < 32 {
+		return false, nil
+	}
+	return bytes.Equal(out.Data[:4], isValidSignature), nil
+}
+
+func word(v uint64) []byte {
+	return new(big.Int).SetUint64(v).FillBytes(make([]byte, 32))
+}
+
+func (c *Certificates) handleVerify(w http.ResponseWriter, req *http.Request) error {
+	var cert certificate.Certificate
+	if err := utils.ParseJSON(req.Body, &cert); err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "body"))
+	}
+	if cert.Signer.IsZero() {
+		return utils.BadRequest(errors.New("signer: required"))
+	}
+	result, err := c.Verify(&cert)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, result)
+}
+
+func (c *Certificates) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+
+	sub.Path("/verify").
+		Methods(http.MethodPost).
+		Name("POST /certificates/verify").
+		HandlerFunc(utils.WrapHandlerFunc(c.handleVerify))
+}
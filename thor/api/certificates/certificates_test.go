@@ -0,0 +1,186 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package certificates
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/certificate"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/test/testchain"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+var (
+	// the init code of 11 bytes copies the runtime code after it, which
+	// returns the magic value of isValidSignature for any call
+	acceptCode = common.FromHex("0x602980600b6000396000f3" +
+		"7f1626ba7e00000000000000000000000000000000000000000000000000000000" + "600052" + "60206000f3")
+	// or returns zero for any call
+	rejectCode = common.FromHex("0x600580600b6000396000f3" + "60206000f3")
+
+	now      = time.Unix(1700000000, 0)
+	deployer = genesis.DevAccounts()[0]
+	user     = genesis.DevAccounts()[1]
+	other    = genesis.DevAccounts()[2]
+)
+
+// newCertificates deploys the accepting and the rejecting contracts, whose master is the deployer.
+func newCertificates(t *testing.T, config Config) (*Certificates, thor.Address, thor.Address) {
+	thorChain, err := testchain.NewDefault()
+	require.NoError(t, err)
+
+	trx := tx.MustSign(tx.NewBuilder(tx.TypeLegacy).
+		ChainTag(thorChain.Repo().ChainTag()).
+		Expiration(100).
+		Gas(1000000).
+		Clause(tx.NewClause(nil).WithData(acceptCode)).
+		Clause(tx.NewClause(nil).WithData(rejectCode)).
+		Build(), deployer.PrivateKey)
+	require.NoError(t, thorChain.MintBlock(deployer, trx))
+
+	c := New(thorChain.Repo(), thorChain.Stater(), thorChain.GetForkConfig(), 1000000, config)
+	c.now = func() time.Time { return now }
+	return c, thor.CreateContractAddress(trx.ID(), 0, 0), thor.CreateContractAddress(trx.ID(), 1, 0)
+}
+
+func newCert(signer thor.Address, key *ecdsa.PrivateKey) *certificate.Certificate {
+	cert := &certificate.Certificate{
+		Purpose:   "identification",
+		Payload:   certificate.Payload{Type: "text", Content: "log in"},
+		Domain:    "app.example.com",
+		Timestamp: uint64(now.Unix()),
+		Signer:    signer,
+	}
+	hash, _ := cert.SigningHash()
+	cert.Signature, _ = crypto.Sign(hash.Bytes(), key)
+	return cert
+}
+
+func TestVerify(t *testing.T) {
+	c, accept, reject := newCertificates(t, Config{})
+
+	for _, tc := range []struct {
+		name     string
+		cert     *certificate.Certificate
+		signedBy string
+		reason   string
+	}{
+		{"key", newCert(user.Address, user.PrivateKey), api.CertSignedByKey, ""},
+		{"other key", newCert(user.Address, other.PrivateKey), "", "signature of another key"},
+		{"master", newCert(accept, deployer.PrivateKey), api.CertSignedByMaster, ""},
+		{"master of rejecting contract", newCert(reject, deployer.PrivateKey), api.CertSignedByMaster, ""},
+		{"accepted by contract", newCert(accept, user.PrivateKey), api.CertSignedByContract, ""},
+		{"rejected by contract", newCert(reject, user.PrivateKey), "", "signature rejected by the signer contract"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := c.Verify(tc.cert)
+			require.NoError(t, err)
+			assert.Equal(t, tc.reason == "", result.Valid)
+			assert.Equal(t, tc.signedBy, result.SignedBy)
+			assert.Equal(t, tc.reason, result.Reason)
+			assert.Equal(t, tc.cert.Signer, result.Signer)
+		})
+	}
+
+	cert := newCert(user.Address, user.PrivateKey)
+	cert.Signature = cert.Signature[:64]
+	result, err := c.Verify(cert)
+	require.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.Nil(t, result.Key)
+	assert.Equal(t, "invalid signature length", result.Reason)
+}
+
+func TestVerifyFreshnessAndDomain(t *testing.T) {
+	c, _, _ := newCertificates(t, Config{
+		Domains: []string{"login.example.com", " *.Example.org "},
+		MaxAge:  5 * time.Minute,
+	})
+
+	for _, tc := range []struct {
+		domain    string
+		timestamp time.Time
+		reason    string
+	}{
+		{"login.example.com", now, ""},
+		{"LOGIN.example.com", now.Add(-5 * time.Minute), ""},
+		{"app.example.org", now.Add(5 * time.Minute), ""},
+		{"a.b.example.org", now, ""},
+		{"example.org", now, "domain not allowed"},
+		{"app.example.com", now, "domain not allowed"},
+		{"evil-example.org", now, "domain not allowed"},
+		{"login.example.com", now.Add(-5*time.Minute - time.Second), "certificate expired"},
+		{"login.example.com", now.Add(5*time.Minute + time.Second), "timestamp in the future"},
+	} {
+		cert := newCert(user.Address, user.PrivateKey)
+		cert.Domain = tc.domain
+		cert.Timestamp = uint64(tc.timestamp.Unix())
+		hash, _ := cert.SigningHash()
+		cert.Signature, _ = crypto.Sign(hash.Bytes(), user.PrivateKey)
+
+		result, err := c.Verify(cert)
+		require.NoError(t, err)
+		assert.Equal(t, tc.reason, result.Reason, tc.domain)
+		assert.Equal(t, tc.reason == "", result.Valid, tc.domain)
+	}
+}
+
+func TestHandleVerify(t *testing.T) {
+	c, _, _ := newCertificates(t, Config{})
+	router := mux.NewRouter()
+	c.Mount(router, "/certificates")
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	post := func(body []byte) (*http.Response, []byte) {
+		res, err := http.Post(ts.URL+"/certificates/verify", "application/json", bytes.NewReader(body))
+		require.NoError(t, err)
+		defer res.Body.Close()
+		buf := new(bytes.Buffer)
+		_, err = buf.ReadFrom(res.Body)
+		require.NoError(t, err)
+		return res, buf.Bytes()
+	}
+
+	body, _ := json.Marshal(newCert(user.Address, user.PrivateKey))
+	res, data := post(body)
+	require.Equal(t, http.StatusOK, res.StatusCode, string(data))
+	var result api.CertificateVerification
+	require.NoError(t, json.Unmarshal(data, &result))
+	assert.True(t, result.Valid)
+	assert.Equal(t, user.Address, *result.Key)
+	assert.Equal(t, api.CertSignedByKey, result.SignedBy)
+
+	body, _ = json.Marshal(newCert(user.Address, other.PrivateKey))
+	res, data = post(body)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.NoError(t, json.Unmarshal(data, &result))
+	assert.False(t, result.Valid)
+	assert.Equal(t, other.Address, *result.Key)
+
+	for _, body := range []string{
+		`{`,
+		`{"purpose":"identification","payload":{"type":"text","content":""},"domain":"a","timestamp":1}`,
+		`{"purpose":"identification","signer":"0x7567d83b7b8d80addcb281a71d54fc7b3364ffed","extra":1}`,
+	} {
+		res, _ := post([]byte(body))
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, body)
+	}
+}
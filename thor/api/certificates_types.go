@@ -0,0 +1,26 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package api
+
+import (
+	"github.com/vechain/thor/v2/thor"
+)
+
+// Ways a certificate is signed on behalf of its signer.
+const (
+	CertSignedByKey      = "key"      // by the key of the signer
+	CertSignedByMaster   = "master"   // by the key of the master of the signer contract
+	CertSignedByContract = "contract" // by a key the signer contract accepts, as specified by ERC-1271
+)
+
+// CertificateVerification is the result of the verification of a certificate.
+type CertificateVerification struct {
+	Valid    bool          `json:"valid"`
+	Signer   thor.Address  `json:"signer"`
+	Key      *thor.Address `json:"key"`
+	SignedBy string        `json:"signedBy,omitempty"`
+	Reason   string        `json:"reason,omitempty"`
+}
@@ -0,0 +1,331 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package delegator co-signs transactions as their gas payer, as specified by VIP-191 and VIP-201. The transactions
+// are sponsored according to a policy, which limits the contracts and methods called, the gas and the energy spent
+// per origin and day, and they are simulated on the best block before signing. The energy spent is persisted, the
+// max fee of a transaction is charged once it's signed.
+package delegator
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/builtin"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/consensus/upgrade/galactica"
+	"github.com/vechain/thor/v2/kv"
+	"github.com/vechain/thor/v2/log"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/runtime"
+	"github.com/vechain/thor/v2/signer"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+	"github.com/vechain/thor/v2/xenv"
+)
+
+var logger = log.WithContext("pkg", "delegator")
+
+const (
+	storeName    = "delegator"
+	spentPrefix  = "s" // day + origin -> energy spent
+	signedPrefix = "t" // day + delegator signing hash -> empty, the transactions charged
+	secondsOfDay = 24 * 60 * 60
+)
+
+// rejectedError is the error of a transaction not sponsored.
+type rejectedError struct {
+	msg string
+}
+
+func (e *rejectedError) Error() string {
+	return e.msg
+}
+
+func reject(format string, args ...any) error {
+	return &rejectedError{fmt.Sprintf(format, args...)}
+}
+
+// IsRejected returns whether the error is of a transaction not sponsored by the policy.
+func IsRejected(err error) bool {
+	_, ok := err.(*rejectedError)
+	return ok
+}
+
+// Delegator signs transactions as their gas payer.
+type Delegator struct {
+	repo       *chain.Repository
+	stater     *state.Stater
+	forkConfig *thor.ForkConfig
+	signer     signer.Signer
+	rules      *rules
+	store      kv.Store
+	now        func() time.Time
+
+	lock   sync.Mutex
+	pruned uint32 // the day the spends of the previous days were pruned
+}
+
+// New creates the delegator of the gas payer, which sponsors transactions according to the policy.
+func New(
+	repo *chain.Repository,
+	stater *state.Stater,
+	forkConfig *thor.ForkConfig,
+	gasPayer signer.Signer,
+	policy *Policy,
+	db *muxdb.MuxDB,
+) (*Delegator, error) {
+	rules, err := policy.compile()
+	if err != nil {
+		return nil, errors.Wrap(err, "delegator policy")
+	}
+	return &Delegator{
+		repo:       repo,
+		stater:     stater,
+		forkConfig: forkConfig,
+		signer:     gasPayer,
+		rules:      rules,
+		store:      db.NewStore(storeName),
+		now:        time.Now,
+	}, nil
+}
+
+// GasPayer returns the address of the gas payer.
+func (d *Delegator) GasPayer() thor.Address {
+	return d.signer.Address()
+}
+
+// Sign checks the transaction signed by its origin against the policy, simulates it and charges its max fee to the
+// budget of the origin, then returns the signature of the gas payer. The claimed origin, if not zero, must be the
+// signer of the transaction. A transaction signed again the same day is not charged twice.
+func (d *Delegator) Sign(trx *tx.Transaction, claimed thor.Address) ([]byte, error) {
+	if err := d.rules.check(trx); err != nil {
+		return nil, err
+	}
+	origin, err := signedOrigin(trx)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed.IsZero() && claimed != origin {
+		return nil, reject("signature of the origin mismatch")
+	}
+
+	best := d.repo.BestBlockSummary()
+	if best.Header.Number()+1 < d.forkConfig.VIP191 {
+		return nil, reject("delegation not activated")
+	}
+	if trx.ChainTag() != d.repo.ChainTag() {
+		return nil, reject("chain tag mismatch")
+	}
+	if trx.IsExpired(best.Header.Number() + 1) {
+		return nil, reject("transaction expired")
+	}
+
+	fee, err := d.simulate(trx, origin, best)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := trx.DelegatorSigningHash(origin)
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	day := d.today()
+	if err := d.prune(day); err != nil {
+		return nil, err
+	}
+	charged, err := d.store.Has(signedKey(day, hash))
+	if err != nil {
+		return nil, err
+	}
+	spent, err := d.spent(day, origin)
+	if err != nil {
+		return nil, err
+	}
+	if !charged {
+		spent.Add(spent, fee)
+		if spent.Cmp(d.rules.dailyBudget) > 0 {
+			return nil, reject("daily budget of the origin exceeded")
+		}
+	}
+
+	sig, err := d.signer.SignDelegation(trx, origin)
+	if err != nil {
+		return nil, errors.Wrap(err, "sign")
+	}
+	if !charged {
+		bulk := d.store.Bulk()
+		if err := bulk.Put(spentKey(day, origin), spent.Bytes()); err != nil {
+			return nil, err
+		}
+		if err := bulk.Put(signedKey(day, hash), nil); err != nil {
+			return nil, err
+		}
+		if err := bulk.Write(); err != nil {
+			return nil, err
+		}
+		logger.Debug("transaction sponsored", "origin", origin, "hash", hash, "fee", fee, "spent", spent)
+	}
+	return sig, nil
+}
+
+// signedOrigin recovers the origin from its signature, which is the only one the transaction carries, or the first
+// of the two when it's co-signed already. The budget of an origin is only spent by the transactions it signed.
+func signedOrigin(trx *tx.Transaction) (thor.Address, error) {
+	sig := trx.Signature()
+	if len(sig) != 65 && len(sig) != 130 {
+		return thor.Address{}, reject("signature of the origin required")
+	}
+	// the signature of a delegated transaction has room for the one of the gas payer, which Origin doesn't check
+	origin, err := trx.WithSignature(append(sig[:65:65], make([]byte, 65)...)).Origin()
+	if err != nil {
+		return thor.Address{}, reject("invalid signature of the origin")
+	}
+	return origin, nil
+}
+
+// Budget returns the budget of the origin today.
+func (d *Delegator) Budget(origin thor.Address) (*api.DelegationBudget, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	spent, err := d.spent(d.today(), origin)
+	if err != nil {
+		return nil, err
+	}
+	remaining := new(big.Int).Sub(d.rules.dailyBudget, spent)
+	if remaining.Sign() < 0 {
+		remaining.SetUint64(0)
+	}
+	return &api.DelegationBudget{
+		GasPayer:  d.GasPayer(),
+		Origin:    origin,
+		Budget:    (*math.HexOrDecimal256)(new(big.Int).Set(d.rules.dailyBudget)),
+		Spent:     (*math.HexOrDecimal256)(spent),
+		Remaining: (*math.HexOrDecimal256)(remaining),
+	}, nil
+}
+
+// simulate executes the clauses of the transaction in the block next to the best one, paid by the gas payer, and
+// returns the max fee of the transaction.
+func (d *Delegator) simulate(trx *tx.Transaction, origin thor.Address, best *chain.BlockSummary) (*big.Int, error) {
+	intrinsicGas, err := trx.IntrinsicGas()
+	if err != nil {
+		return nil, reject("intrinsic gas: %v", err)
+	}
+	if trx.Gas() < intrinsicGas {
+		return nil, reject("intrinsic gas exceeds provided gas")
+	}
+
+	var (
+		header    = best.Header
+		st        = d.stater.NewState(best.Root())
+		blockTime = header.Timestamp() + thor.BlockInterval
+		baseFee   = galactica.CalcBaseFee(header, d.forkConfig)
+		gasPayer  = d.GasPayer()
+	)
+	if trx.Type() != tx.TypeLegacy && baseFee == nil {
+		return nil, reject("dynamic fee transaction before galactica")
+	}
+	legacyTxBaseGasPrice, err := builtin.Params.Native(st).Get(thor.KeyLegacyTxBaseGasPrice)
+	if err != nil {
+		return nil, err
+	}
+	gasPrice := trx.EffectiveGasPrice(baseFee, legacyTxBaseGasPrice)
+	if baseFee != nil && gasPrice.Cmp(baseFee) < 0 {
+		return nil, reject("gas price is less than the base fee")
+	}
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(trx.Gas()), gasPrice)
+
+	// the gas is bought by the gas payer before the execution
+	if ok, err := builtin.Energy.Native(st, blockTime).Sub(gasPayer, fee); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, reject("insufficient energy of the gas payer")
+	}
+
+	rt := runtime.New(d.repo.NewChain(header.ID()), st,
+		&xenv.BlockContext{
+			Number:     header.Number() + 1,
+			Time:       blockTime,
+			GasLimit:   header.GasLimit(),
+			TotalScore: header.TotalScore() + 1,
+			BaseFee:    baseFee,
+		},
+		d.forkConfig)
+	txCtx := &xenv.TransactionContext{
+		ID:          trx.DelegatorSigningHash(origin),
+		Origin:      origin,
+		GasPayer:    gasPayer,
+		GasPrice:    gasPrice,
+		ProvedWork:  new(big.Int),
+		BlockRef:    trx.BlockRef(),
+		Expiration:  trx.Expiration(),
+		ClauseCount: uint32(len(trx.Clauses())),
+	}
+
+	gas := trx.Gas() - intrinsicGas
+	for i, clause := range trx.Clauses() {
+		exec, _ := rt.PrepareClause(clause, uint32(i), gas, txCtx)
+		out, _, err := exec()
+		if err != nil {
+			return nil, err
+		}
+		if out.VMErr != nil {
+			return nil, reject("simulation: clause %v reverted: %v", i, out.VMErr)
+		}
+		gas = out.LeftOverGas
+	}
+	return fee, nil
+}
+
+func (d *Delegator) today() uint32 {
+	return uint32(d.now().Unix() / secondsOfDay)
+}
+
+func (d *Delegator) spent(day uint32, origin thor.Address) (*big.Int, error) {
+	data, err := d.store.Get(spentKey(day, origin))
+	if err != nil {
+		if d.store.IsNotFound(err) {
+			return new(big.Int), nil
+		}
+		return nil, err
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+// prune deletes the spends of the days before the given day, once a day.
+func (d *Delegator) prune(day uint32) error {
+	if d.pruned == day {
+		return nil
+	}
+	for _, prefix := range []string{spentPrefix, signedPrefix} {
+		r := kv.Range{
+			Start: []byte(prefix),
+			Limit: binary.BigEndian.AppendUint32([]byte(prefix), day),
+		}
+		if err := d.store.DeleteRange(context.Background(), r); err != nil {
+			return err
+		}
+	}
+	d.pruned = day
+	return nil
+}
+
+func spentKey(day uint32, origin thor.Address) []byte {
+	return append(binary.BigEndian.AppendUint32([]byte(spentPrefix), day), origin.Bytes()...)
+}
+
+func signedKey(day uint32, hash thor.Bytes32) []byte {
+	return append(binary.BigEndian.AppendUint32([]byte(signedPrefix), day), hash.Bytes()...)
+}
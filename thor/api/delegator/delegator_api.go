@@ -0,0 +1,72 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package delegator
+
+import (
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/api/utils"
+	"github.com/vechain/thor/v2/thor"
+)
+
+type API struct {
+	delegator *Delegator
+}
+
+func NewAPI(delegator *Delegator) *API {
+	return &API{
+		delegator: delegator,
+	}
+}
+
+func (a *API) handleSign(w http.ResponseWriter, r *http.Request) error {
+	var req api.DelegationRequest
+	if err := utils.ParseJSON(r.Body, &req); err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "body"))
+	}
+	trx, err := req.Decode()
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "raw"))
+	}
+	sig, err := a.delegator.Sign(trx, req.Origin)
+	if err != nil {
+		if IsRejected(err) {
+			return utils.Forbidden(err)
+		}
+		return err
+	}
+	return utils.WriteJSON(w, &api.DelegationResponse{Signature: hexutil.Encode(sig)})
+}
+
+func (a *API) handleGetBudget(w http.ResponseWriter, r *http.Request) error {
+	origin, err := thor.ParseAddress(mux.Vars(r)["origin"])
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "origin"))
+	}
+	budget, err := a.delegator.Budget(origin)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, budget)
+}
+
+func (a *API) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+
+	sub.Path("/sign").
+		Methods(http.MethodPost).
+		Name("POST /delegator/sign").
+		HandlerFunc(utils.WrapHandlerFunc(a.handleSign))
+
+	sub.Path("/budget/{origin}").
+		Methods(http.MethodGet).
+		Name("GET /delegator/budget/{origin}").
+		HandlerFunc(utils.WrapHandlerFunc(a.handleGetBudget))
+}
@@ -0,0 +1,287 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package delegator
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/builtin"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/signer"
+	"github.com/vechain/thor/v2/test/testchain"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+var (
+	// 1000 VTHO
+	dailyBudget = new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))
+
+	gasPayer = genesis.DevAccounts()[0]
+	origin   = genesis.DevAccounts()[1]
+	receiver = genesis.DevAccounts()[2]
+)
+
+func newDelegator(t *testing.T, budget *big.Int) (*testchain.Chain, *Delegator) {
+	thorChain, err := testchain.NewDefault()
+	require.NoError(t, err)
+
+	d, err := New(thorChain.Repo(), thorChain.Stater(), thorChain.GetForkConfig(), signer.NewLocal(gasPayer.PrivateKey), &Policy{
+		MaxGas:      100000,
+		DailyBudget: (*math.HexOrDecimal256)(budget),
+		Targets: []*Target{
+			{Address: builtin.Energy.Address, Methods: []string{"transfer(address,uint256)"}},
+			{Address: builtin.Params.Address},
+		},
+	}, thorChain.Database())
+	require.NoError(t, err)
+	return thorChain, d
+}
+
+func transferClause(amount *big.Int) *tx.Clause {
+	method, _ := builtin.Energy.ABI.MethodByName("transfer")
+	data, _ := method.EncodeInput(receiver.Address, amount)
+	return tx.NewClause(&builtin.Energy.Address).WithData(data)
+}
+
+func newTx(thorChain *testchain.Chain, nonce uint64, clauses ...*tx.Clause) *tx.Transaction {
+	builder := tx.NewBuilder(tx.TypeLegacy).
+		ChainTag(thorChain.Repo().ChainTag()).
+		Expiration(100).
+		Gas(80000).
+		Nonce(nonce).
+		Features(tx.DelegationFeature)
+	for _, c := range clauses {
+		builder.Clause(c)
+	}
+	return builder.Build()
+}
+
+// signedBy returns the transaction with the signature of the origin, without the one of the gas payer.
+func signedBy(t *testing.T, trx *tx.Transaction, account genesis.DevAccount) *tx.Transaction {
+	sig, err := crypto.Sign(trx.SigningHash().Bytes(), account.PrivateKey)
+	require.NoError(t, err)
+	return trx.WithSignature(sig)
+}
+
+func TestSign(t *testing.T) {
+	thorChain, d := newDelegator(t, dailyBudget)
+
+	trx := signedBy(t, newTx(thorChain, 1, transferClause(big.NewInt(1))), origin)
+	sig, err := d.Sign(trx, origin.Address)
+	require.NoError(t, err)
+
+	trx = trx.WithSignature(append(trx.Signature(), sig...))
+	delegator, err := trx.Delegator()
+	require.NoError(t, err)
+	assert.Equal(t, gasPayer.Address, *delegator)
+
+	// the co-signed transaction is accepted by the chain
+	require.NoError(t, thorChain.MintBlock(gasPayer, trx))
+	receipt, err := thorChain.Repo().NewBestChain().GetTransactionReceipt(trx.ID())
+	require.NoError(t, err)
+	assert.False(t, receipt.Reverted)
+	assert.Equal(t, gasPayer.Address, receipt.GasPayer)
+
+	budget, err := d.Budget(origin.Address)
+	require.NoError(t, err)
+	// the max fee is charged, 80000 gas at the base gas price
+	spent := new(big.Int).Mul(big.NewInt(80000), thor.InitialBaseGasPrice)
+	assert.Equal(t, spent, (*big.Int)(budget.Spent))
+	assert.Equal(t, new(big.Int).Sub(dailyBudget, spent), (*big.Int)(budget.Remaining))
+}
+
+func TestSignRejected(t *testing.T) {
+	thorChain, d := newDelegator(t, dailyBudget)
+
+	method, _ := builtin.Energy.ABI.MethodByName("approve")
+	approve, _ := method.EncodeInput(receiver.Address, big.NewInt(1))
+	otherTag := tx.NewBuilder(tx.TypeLegacy).ChainTag(thorChain.Repo().ChainTag() + 1).Gas(80000).
+		Features(tx.DelegationFeature).Clause(transferClause(big.NewInt(1))).Build()
+
+	for _, tc := range []struct {
+		name string
+		tx   *tx.Transaction
+		err  string
+	}{
+		{"not delegated", tx.NewBuilder(tx.TypeLegacy).Gas(80000).Clause(transferClause(big.NewInt(1))).Build(), "transaction not delegated"},
+		{"target", newTx(thorChain, 1, tx.NewClause(&receiver.Address)), "clause 0: target " + receiver.Address.String() + " not allowed"},
+		{"creation", newTx(thorChain, 1, tx.NewClause(nil)), "clause 0: contract creation not allowed"},
+		{"method", newTx(thorChain, 1, tx.NewClause(&builtin.Energy.Address).WithData(approve)), "clause 0: method 0x095ea7b3 of " + builtin.Energy.Address.String() + " not allowed"},
+		{"no method", newTx(thorChain, 1, tx.NewClause(&builtin.Energy.Address)), "clause 0: method call required"},
+		{"chain tag", otherTag, "chain tag mismatch"},
+		{"reverted", newTx(thorChain, 1, transferClause(new(big.Int).Lsh(big.NewInt(1), 128))), "simulation: clause 0 reverted: execution reverted"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := d.Sign(signedBy(t, tc.tx, origin), origin.Address)
+			require.Error(t, err)
+			assert.True(t, IsRejected(err))
+			assert.Equal(t, tc.err, err.Error())
+		})
+	}
+
+	tooMuchGas := tx.NewBuilder(tx.TypeLegacy).ChainTag(thorChain.Repo().ChainTag()).Expiration(100).Gas(100001).
+		Features(tx.DelegationFeature).Clause(transferClause(big.NewInt(1))).Build()
+	_, err := d.Sign(signedBy(t, tooMuchGas, origin), origin.Address)
+	assert.EqualError(t, err, "gas 100001 exceeds the limit of 100000")
+
+	// signed by another origin
+	trx := newTx(thorChain, 1, transferClause(big.NewInt(1)))
+	_, err = d.Sign(signedBy(t, trx, receiver), origin.Address)
+	assert.EqualError(t, err, "signature of the origin mismatch")
+
+	// not signed, the budget of an origin can't be spent by others
+	_, err = d.Sign(trx, origin.Address)
+	assert.EqualError(t, err, "signature of the origin required")
+	_, err = d.Sign(trx.WithSignature(make([]byte, 65)), origin.Address)
+	assert.EqualError(t, err, "invalid signature of the origin")
+
+	budget, err := d.Budget(origin.Address)
+	require.NoError(t, err)
+	assert.Zero(t, (*big.Int)(budget.Spent).Sign())
+}
+
+func TestSignBudget(t *testing.T) {
+	// the budget of a transaction and a half
+	fee := new(big.Int).Mul(big.NewInt(80000), thor.InitialBaseGasPrice)
+	thorChain, d := newDelegator(t, new(big.Int).Div(new(big.Int).Mul(fee, big.NewInt(3)), big.NewInt(2)))
+	now := time.Now()
+	d.now = func() time.Time { return now }
+
+	trx := signedBy(t, newTx(thorChain, 1, transferClause(big.NewInt(1))), origin)
+	_, err := d.Sign(trx, origin.Address)
+	require.NoError(t, err)
+	// signed again, not charged twice, the origin is recovered if not claimed
+	_, err = d.Sign(trx, thor.Address{})
+	require.NoError(t, err)
+
+	_, err = d.Sign(signedBy(t, newTx(thorChain, 2, transferClause(big.NewInt(1))), origin), origin.Address)
+	assert.EqualError(t, err, "daily budget of the origin exceeded")
+
+	// other origins have their own budget
+	_, err = d.Sign(signedBy(t, newTx(thorChain, 2, transferClause(big.NewInt(1))), receiver), receiver.Address)
+	assert.NoError(t, err)
+
+	// the budget is reset the next day, and the spends of the previous days are pruned
+	now = now.Add(24 * time.Hour)
+	_, err = d.Sign(signedBy(t, newTx(thorChain, 2, transferClause(big.NewInt(1))), origin), origin.Address)
+	assert.NoError(t, err)
+	yesterday := d.today() - 1
+	has, err := d.store.Has(spentKey(yesterday, receiver.Address))
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestPolicy(t *testing.T) {
+	budget := (*math.HexOrDecimal256)(big.NewInt(1))
+	for _, tc := range []struct {
+		policy Policy
+		err    string
+	}{
+		{Policy{DailyBudget: budget, Targets: []*Target{{Address: receiver.Address}}}, "maxGas: must be positive"},
+		{Policy{MaxGas: 1, Targets: []*Target{{Address: receiver.Address}}}, "dailyBudget: must be positive"},
+		{Policy{MaxGas: 1, DailyBudget: budget}, "targets: at least one target required"},
+		{Policy{MaxGas: 1, DailyBudget: budget, Targets: []*Target{{}}}, "targets[0]: invalid address"},
+		{Policy{MaxGas: 1, DailyBudget: budget, Targets: []*Target{{Address: receiver.Address}, {Address: receiver.Address}}}, "targets[1]: duplicated address " + receiver.Address.String()},
+		{Policy{MaxGas: 1, DailyBudget: budget, Targets: []*Target{{Address: receiver.Address, Methods: []string{"0x1234"}}}}, `targets[0]: invalid method "0x1234", want a 4 bytes selector or a signature`},
+	} {
+		_, err := tc.policy.compile()
+		assert.EqualError(t, err, tc.err)
+	}
+
+	sel, err := parseSelector("transfer(address, uint256)")
+	require.NoError(t, err)
+	assert.Equal(t, [4]byte{0xa9, 0x05, 0x9c, 0xbb}, sel)
+	sel, err = parseSelector("0xa9059cbb")
+	require.NoError(t, err)
+	assert.Equal(t, [4]byte{0xa9, 0x05, 0x9c, 0xbb}, sel)
+
+	path := filepath.Join(t.TempDir(), "policy.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"maxGas": 100000,
+		"dailyBudget": "0xde0b6b3a7640000",
+		"targets": [{"address": "`+receiver.Address.String()+`", "methods": ["0xa9059cbb"]}]
+	}`), 0o600))
+	policy, err := LoadPolicy(path)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100000), policy.MaxGas)
+	assert.Equal(t, big.NewInt(1e18), (*big.Int)(policy.DailyBudget))
+	assert.Equal(t, []*Target{{Address: receiver.Address, Methods: []string{"0xa9059cbb"}}}, policy.Targets)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"maxGas": 1, "unknown": true}`), 0o600))
+	_, err = LoadPolicy(path)
+	assert.ErrorContains(t, err, "unknown field")
+}
+
+func TestAPI(t *testing.T) {
+	thorChain, d := newDelegator(t, dailyBudget)
+	router := mux.NewRouter()
+	NewAPI(d).Mount(router, "/delegator")
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	post := func(trx *tx.Transaction) (int, []byte) {
+		raw, err := trx.MarshalBinary()
+		require.NoError(t, err)
+		body, err := json.Marshal(&api.DelegationRequest{RawTx: api.RawTx{Raw: hexutil.Encode(raw)}, Origin: origin.Address})
+		require.NoError(t, err)
+		res, err := http.Post(srv.URL+"/delegator/sign", "application/json", bytes.NewReader(body))
+		require.NoError(t, err)
+		defer res.Body.Close()
+		var buf bytes.Buffer
+		_, err = buf.ReadFrom(res.Body)
+		require.NoError(t, err)
+		return res.StatusCode, buf.Bytes()
+	}
+
+	trx := signedBy(t, newTx(thorChain, 1, transferClause(big.NewInt(1))), origin)
+	code, body := post(trx)
+	require.Equal(t, http.StatusOK, code, string(body))
+	var res api.DelegationResponse
+	require.NoError(t, json.Unmarshal(body, &res))
+	sig, err := hexutil.Decode(res.Signature)
+	require.NoError(t, err)
+	pub, err := crypto.SigToPub(trx.DelegatorSigningHash(origin.Address).Bytes(), sig)
+	require.NoError(t, err)
+	assert.Equal(t, gasPayer.Address, thor.Address(crypto.PubkeyToAddress(*pub)))
+
+	code, body = post(signedBy(t, newTx(thorChain, 1, tx.NewClause(&receiver.Address)), origin))
+	assert.Equal(t, http.StatusForbidden, code)
+	assert.Contains(t, string(body), "not allowed")
+
+	code, body = post(newTx(thorChain, 2, transferClause(big.NewInt(1))))
+	assert.Equal(t, http.StatusForbidden, code)
+	assert.Contains(t, string(body), "signature of the origin required")
+
+	res2, err := http.Post(srv.URL+"/delegator/sign", "application/json", bytes.NewReader([]byte(`{"raw":"0xzz"}`)))
+	require.NoError(t, err)
+	res2.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, res2.StatusCode)
+
+	res3, err := http.Get(srv.URL + "/delegator/budget/" + origin.Address.String())
+	require.NoError(t, err)
+	defer res3.Body.Close()
+	var budget api.DelegationBudget
+	require.NoError(t, json.NewDecoder(res3.Body).Decode(&budget))
+	assert.Equal(t, gasPayer.Address, budget.GasPayer)
+	assert.Equal(t, dailyBudget, (*big.Int)(budget.Budget))
+	assert.Positive(t, (*big.Int)(budget.Spent).Sign())
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package delegator
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+// Policy is the sponsorship policy of the gas payer.
+type Policy struct {
+	// MaxGas is the max gas of a sponsored transaction.
+	MaxGas uint64 `json:"maxGas"`
+	// DailyBudget is the energy, in wei, the gas payer spends per origin and day.
+	DailyBudget *math.HexOrDecimal256 `json:"dailyBudget"`
+	// Targets are the contracts the clauses of a sponsored transaction may call.
+	Targets []*Target `json:"targets"`
+}
+
+// Target is a contract allowed to be called, by the methods of the selectors or signatures, e.g. 0xa9059cbb or
+// transfer(address,uint256). Any method is allowed if not set.
+type Target struct {
+	Address thor.Address `json:"address"`
+	Methods []string     `json:"methods,omitempty"`
+}
+
+// LoadPolicy reads the policy from a JSON file.
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "read delegator policy")
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var p Policy
+	if err := dec.Decode(&p); err != nil {
+		return nil, errors.Wrap(err, "decode delegator policy")
+	}
+	return &p, nil
+}
+
+// rules is the policy, compiled for the checks.
+type rules struct {
+	maxGas      uint64
+	dailyBudget *big.Int
+	// the allowed selectors of the targets, nil if any method is allowed
+	targets map[thor.Address]map[[4]byte]bool
+}
+
+func (p *Policy) compile() (*rules, error) {
+	if p.MaxGas == 0 {
+		return nil, errors.New("maxGas: must be positive")
+	}
+	budget := (*big.Int)(p.DailyBudget)
+	if budget == nil || budget.Sign() <= 0 {
+		return nil, errors.New("dailyBudget: must be positive")
+	}
+	if len(p.Targets) == 0 {
+		return nil, errors.New("targets: at least one target required")
+	}
+
+	r := &rules{
+		maxGas:      p.MaxGas,
+		dailyBudget: new(big.Int).Set(budget),
+		targets:     make(map[thor.Address]map[[4]byte]bool),
+	}
+	for i, t := range p.Targets {
+		if t == nil || t.Address.IsZero() {
+			return nil, errors.Errorf("targets[%v]: invalid address", i)
+		}
+		if _, ok := r.targets[t.Address]; ok {
+			return nil, errors.Errorf("targets[%v]: duplicated address %v", i, t.Address)
+		}
+		var selectors map[[4]byte]bool
+		for _, m := range t.Methods {
+			sel, err := parseSelector(m)
+			if err != nil {
+				return nil, errors.Errorf("targets[%v]: %v", i, err)
+			}
+			if selectors == nil {
+				selectors = make(map[[4]byte]bool)
+			}
+			selectors[sel] = true
+		}
+		r.targets[t.Address] = selectors
+	}
+	return r, nil
+}
+
+// parseSelector parses a method selector in hex, or computes it from the method signature.
+func parseSelector(method string) (sel [4]byte, err error) {
+	method = strings.TrimSpace(method)
+	if strings.Contains(method, "(") {
+		copy(sel[:], crypto.Keccak256([]byte(strings.ReplaceAll(method, " ", ""))))
+		return sel, nil
+	}
+	b, err := hexutil.Decode(method)
+	if err != nil || len(b) != 4 {
+		return sel, errors.Errorf("invalid method %q, want a 4 bytes selector or a signature", method)
+	}
+	copy(sel[:], b)
+	return sel, nil
+}
+
+// check checks the transaction against the rules, regardless of the state.
+func (r *rules) check(trx *tx.Transaction) error {
+	if !trx.Features().IsDelegated() {
+		return reject("transaction not delegated")
+	}
+	if trx.Gas() > r.maxGas {
+		return reject("gas %v exceeds the limit of %v", trx.Gas(), r.maxGas)
+	}
+	clauses := trx.Clauses()
+	if len(clauses) == 0 {
+		return reject("no clauses")
+	}
+	for i, c := range clauses {
+		to := c.To()
+		if to == nil {
+			return reject("clause %v: contract creation not allowed", i)
+		}
+		selectors, ok := r.targets[*to]
+		if !ok {
+			return reject("clause %v: target %v not allowed", i, to)
+		}
+		if selectors == nil {
+			continue
+		}
+		var sel [4]byte
+		if data := c.Data(); len(data) >= 4 {
+			copy(sel[:], data)
+		} else {
+			return reject("clause %v: method call required", i)
+		}
+		if !selectors[sel] {
+			return reject("clause %v: method 0x%x of %v not allowed", i, sel, to)
+		}
+	}
+	return nil
+}
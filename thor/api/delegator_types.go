@@ -0,0 +1,32 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package api
+
+import (
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// DelegationRequest asks the gas payer to co-sign a transaction of the origin, as specified by VIP-201.
+// The transaction must be signed by the origin, the origin field is only checked against its signature.
+type DelegationRequest struct {
+	RawTx
+	Origin thor.Address `json:"origin"`
+}
+
+// DelegationResponse is the signature of the gas payer, to be appended to the one of the origin.
+type DelegationResponse struct {
+	Signature string `json:"signature"`
+}
+
+// DelegationBudget is the daily budget of an origin, the energy spent is reset at midnight UTC.
+type DelegationBudget struct {
+	GasPayer  thor.Address          `json:"gasPayer"`
+	Origin    thor.Address          `json:"origin"`
+	Budget    *math.HexOrDecimal256 `json:"budget"`
+	Spent     *math.HexOrDecimal256 `json:"spent"`
+	Remaining *math.HexOrDecimal256 `json:"remaining"`
+}
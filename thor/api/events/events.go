@@ -10,38 +10,69 @@ import (
 	"fmt"
 	"math"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 	"github.com/vechain/thor/v2/api"
 	"github.com/vechain/thor/v2/api/utils"
+	"github.com/vechain/thor/v2/bft"
+	"github.com/vechain/thor/v2/block"
 	"github.com/vechain/thor/v2/chain"
 	"github.com/vechain/thor/v2/logdb"
 )
 
 type Events struct {
-	repo  *chain.Repository
-	db    *logdb.LogDB
-	limit uint64
+	repo   *chain.Repository
+	db     *logdb.LogDB
+	limit  uint64
+	budget logdb.QueryBudget
+	bft    bft.Committer
 }
 
-func New(repo *chain.Repository, db *logdb.LogDB, logsLimit uint64) *Events {
+func New(repo *chain.Repository, db *logdb.LogDB, logsLimit uint64, budget logdb.QueryBudget, bft bft.Committer) *Events {
 	return &Events{
 		repo,
 		db,
 		logsLimit,
+		budget,
+		bft,
 	}
 }
 
 // Filter query events with option
-func (e *Events) filter(ctx context.Context, ef *api.EventFilter) ([]*api.FilteredEvent, error) {
+func (e *Events) filter(ctx context.Context, w http.ResponseWriter, ef *api.EventFilter) ([]*api.FilteredEvent, error) {
 	chain := e.repo.NewBestChain()
 	filter, err := api.ConvertEventFilter(chain, ef)
 	if err != nil {
 		return nil, err
 	}
+
+	head := block.Number(chain.HeadID())
+	downgraded, err := e.budget.Apply(filter, head)
+	if err != nil {
+		return nil, utils.Forbidden(err)
+	}
+	if downgraded {
+		w.Header().Set(api.LogsDowngradedRangeHeader, fmt.Sprintf("%d-%d", filter.Range.From, filter.Range.To))
+	}
+	// logs of finalized blocks never change, unless the range was narrowed depending on the head
+	if !downgraded && filter.Range != nil && utils.IsFinalized(filter.Range.To, e.bft) {
+		utils.SetImmutable(w)
+	}
+
+	if e.budget.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.budget.Timeout)
+		defer cancel()
+	}
+	start := time.Now()
 	events, err := e.db.FilterEvents(ctx, filter)
+	e.budget.LogIfSlow(filter, head, time.Since(start))
 	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, utils.HTTPError(errors.New("query timeout, please narrow the range or criteria"), http.StatusServiceUnavailable)
+		}
 		return nil, err
 	}
 	fes := make([]*api.FilteredEvent, len(events))
@@ -81,7 +112,7 @@ func (e *Events) handleFilter(w http.ResponseWriter, req *http.Request) error {
 		}
 	}
 
-	fes, err := e.filter(req.Context(), &filter)
+	fes, err := e.filter(req.Context(), w, &filter)
 	if err != nil {
 		return err
 	}
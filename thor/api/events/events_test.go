@@ -12,6 +12,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
@@ -279,13 +280,51 @@ func testEventWithBlocks(t *testing.T, expectedBlocks int) {
 	}
 }
 
+func TestQueryBudget(t *testing.T) {
+	thorChain := initEventServerWithBudget(t, defaultLogLimit, logdb.QueryBudget{MaxCost: 3})
+	defer ts.Close()
+
+	tclient = thorclient.New(ts.URL)
+	insertBlocks(t, thorChain, 5)
+
+	// a single unindexed criterion over the chain fits the budget
+	res, statusCode, err := tclient.RawHTTPClient().RawHTTPPost("/logs/event", []byte(`{"criteriaSet": [{}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode, string(res))
+
+	res, statusCode, err = tclient.RawHTTPClient().RawHTTPPost("/logs/event", []byte(`{"criteriaSet": [{}, {}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, statusCode)
+	assert.Equal(t, "query cost 5 exceeds the maximum allowed value of 3, please narrow the range or criteria\n", string(res))
+
+	res, statusCode, err = tclient.RawHTTPClient().RawHTTPPost("/logs/event", []byte(`{"options": {"offset": 3000, "limit": 10}}`))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, statusCode, string(res))
+}
+
+func TestQueryTimeout(t *testing.T) {
+	thorChain := initEventServerWithBudget(t, defaultLogLimit, logdb.QueryBudget{Timeout: time.Nanosecond, SlowQuery: time.Nanosecond})
+	defer ts.Close()
+
+	tclient = thorclient.New(ts.URL)
+	insertBlocks(t, thorChain, 1)
+
+	_, statusCode, err := tclient.RawHTTPClient().RawHTTPPost("/logs/event", []byte(`{}`))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, statusCode)
+}
+
 // Init functions
 func initEventServer(t *testing.T, limit uint64) *testchain.Chain {
+	return initEventServerWithBudget(t, limit, logdb.QueryBudget{})
+}
+
+func initEventServerWithBudget(t *testing.T, limit uint64, budget logdb.QueryBudget) *testchain.Chain {
 	thorChain, err := testchain.NewDefault()
 	require.NoError(t, err)
 
 	router := mux.NewRouter()
-	New(thorChain.Repo(), thorChain.LogDB(), limit).Mount(router, "/logs/event")
+	New(thorChain.Repo(), thorChain.LogDB(), limit, budget, thorChain.Engine()).Mount(router, "/logs/event")
 	ts = httptest.NewServer(router)
 
 	return thorChain
@@ -11,8 +11,13 @@ import (
 	"github.com/vechain/thor/v2/chain"
 	"github.com/vechain/thor/v2/logdb"
 	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
 )
 
+// LogsDowngradedRangeHeader is set on logs responses whose block range was narrowed to fit
+// the query budget, with the queried range as "from-to" block numbers.
+const LogsDowngradedRangeHeader = "x-logs-downgraded-range"
+
 // FilteredEvent only comes from one contract
 type FilteredEvent struct {
 	Address thor.Address    `json:"address"`
@@ -63,6 +68,32 @@ type EventCriteria struct {
 	TopicSet
 }
 
+// Match returns whether the event matches the criteria.
+func (c *EventCriteria) Match(event *tx.Event) bool {
+	if c.Address != nil && *c.Address != event.Address {
+		return false
+	}
+	for i, topic := range []*thor.Bytes32{c.Topic0, c.Topic1, c.Topic2, c.Topic3, c.Topic4} {
+		if topic == nil {
+			continue
+		}
+		if len(event.Topics) <= i || *topic != event.Topics[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchEventCriteriaSet returns whether the event matches any of the criteria of the set.
+func MatchEventCriteriaSet(set []*EventCriteria, event *tx.Event) bool {
+	for _, c := range set {
+		if c.Match(event) {
+			return true
+		}
+	}
+	return false
+}
+
 type Options struct {
 	Offset         uint64
 	Limit          uint64
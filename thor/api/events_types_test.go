@@ -15,6 +15,7 @@ import (
 	"github.com/vechain/thor/v2/logdb"
 	"github.com/vechain/thor/v2/test/testchain"
 	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
 )
 
 func newRange(unit RangeType, from uint64, to uint64) *Range {
@@ -200,3 +201,24 @@ func TestConvertEvent(t *testing.T) {
 	assert.Equal(t, event.ClauseIndex, result.Meta.ClauseIndex)
 	assert.Equal(t, expectedTopics, result.Topics)
 }
+
+func TestEventCriteriaMatch(t *testing.T) {
+	addr := thor.BytesToAddress([]byte("addr"))
+	topic0 := thor.BytesToBytes32([]byte("topic0"))
+	topic1 := thor.BytesToBytes32([]byte("topic1"))
+	other := thor.BytesToBytes32([]byte("other"))
+
+	event := &tx.Event{Address: addr, Topics: []thor.Bytes32{topic0, topic1}}
+
+	assert.True(t, (&EventCriteria{}).Match(event))
+	assert.True(t, (&EventCriteria{Address: &addr}).Match(event))
+	assert.True(t, (&EventCriteria{Address: &addr, TopicSet: TopicSet{Topic0: &topic0, Topic1: &topic1}}).Match(event))
+	assert.False(t, (&EventCriteria{Address: &thor.Address{}}).Match(event))
+	assert.False(t, (&EventCriteria{TopicSet: TopicSet{Topic1: &other}}).Match(event))
+	assert.False(t, (&EventCriteria{TopicSet: TopicSet{Topic2: &topic0}}).Match(event))
+
+	// a set matches if any of its criteria does
+	assert.True(t, MatchEventCriteriaSet([]*EventCriteria{{Address: &thor.Address{}}, {Address: &addr}}, event))
+	assert.False(t, MatchEventCriteriaSet([]*EventCriteria{{Address: &thor.Address{}}, {TopicSet: TopicSet{Topic1: &other}}}, event))
+	assert.False(t, MatchEventCriteriaSet(nil, event))
+}
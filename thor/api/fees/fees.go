@@ -55,13 +55,13 @@ func New(repo *chain.Repository, bft bft.Committer, forkConfig *thor.ForkConfig,
 	}
 }
 
-func (f *Fees) validateGetFeesHistoryParams(req *http.Request) (uint32, *chain.BlockSummary, []float64, error) {
+func (f *Fees) validateGetFeesHistoryParams(req *http.Request, newestBlock *utils.Revision) (uint32, *chain.BlockSummary, []float64, error) {
 	blockCount, err := f.validateBlockCount(req)
 	if err != nil {
 		return 0, nil, nil, err
 	}
 
-	newestBlockSummary, adjustedBlockCount, err := f.validateNewestBlock(req, blockCount)
+	newestBlockSummary, adjustedBlockCount, err := f.validateNewestBlock(newestBlock, blockCount)
 	if err != nil {
 		return 0, nil, nil, err
 	}
@@ -88,12 +88,7 @@ func (f *Fees) validateBlockCount(req *http.Request) (uint64, error) {
 	return blockCount, nil
 }
 
-func (f *Fees) validateNewestBlock(req *http.Request, blockCount uint64) (*chain.BlockSummary, uint64, error) {
-	newestBlock, err := utils.ParseRevision(req.URL.Query().Get("newestBlock"), true)
-	if err != nil {
-		return nil, 0, utils.BadRequest(errors.WithMessage(err, "newestBlock"))
-	}
-
+func (f *Fees) validateNewestBlock(newestBlock *utils.Revision, blockCount uint64) (*chain.BlockSummary, uint64, error) {
 	newestBlockSummary, _, err := utils.GetSummaryAndState(newestBlock, f.data.repo, f.bft, f.data.stater, f.forkConfig)
 	if err != nil {
 		if f.data.repo.IsNotFound(err) {
@@ -148,7 +143,11 @@ func (f *Fees) validateRewardPercentiles(req *http.Request) ([]float64, error) {
 }
 
 func (f *Fees) handleGetFeesHistory(w http.ResponseWriter, req *http.Request) error {
-	blockCount, newestBlockSummary, rewardPercentiles, err := f.validateGetFeesHistoryParams(req)
+	newestBlock, err := utils.ParseRevision(req.URL.Query().Get("newestBlock"), true)
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "newestBlock"))
+	}
+	blockCount, newestBlockSummary, rewardPercentiles, err := f.validateGetFeesHistoryParams(req, newestBlock)
 	if err != nil {
 		return err
 	}
@@ -157,6 +156,9 @@ func (f *Fees) handleGetFeesHistory(w http.ResponseWriter, req *http.Request) er
 	if err != nil {
 		return err
 	}
+	if newestBlock.IsBest() || newestBlock.IsNext() {
+		utils.SetShortMaxAge(w)
+	}
 
 	return utils.WriteJSON(w, &api.FeesHistory{
 		OldestBlock:   oldestBlockRevision,
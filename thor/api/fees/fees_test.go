@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
@@ -106,6 +107,23 @@ func TestRewardPercentiles(t *testing.T) {
 	}
 }
 
+func TestFeeHistoryCacheControl(t *testing.T) {
+	ts, _ := initFeesServer(t, 8, 10, 10)
+	t.Cleanup(ts.Close)
+
+	for newestBlock, expected := range map[string]string{
+		"best": "public, max-age=1",
+		"next": "public, max-age=1",
+		"9":    "",
+	} {
+		res, err := http.Get(ts.URL + "/fees/history?blockCount=1&newestBlock=" + newestBlock)
+		require.NoError(t, err)
+		res.Body.Close()
+		assert.Equal(t, http.StatusOK, res.StatusCode, newestBlock)
+		assert.Equal(t, expected, res.Header.Get("Cache-Control"), newestBlock)
+	}
+}
+
 func TestFeeHistoryHugeBlockCountClamped(t *testing.T) {
 	// pick a small backtrace limit so we can observe the clamp easily
 	const backtraceLimit = 5
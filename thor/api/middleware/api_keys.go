@@ -0,0 +1,336 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/metrics"
+)
+
+const apiKeyHeader = "x-api-key"
+
+var metricAPIKeyRequestCount = metrics.LazyLoadCounterVec("api_key_request_count", []string{"key", "route", "result"})
+
+// ErrAPIKeyNotFound is returned when removing an unknown API key.
+var ErrAPIKeyNotFound = errors.New("api key not found")
+
+// tokenBucket is a token bucket refilled at rate tokens per second, holding at most burst tokens.
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int, now time.Time) *tokenBucket {
+	if rate <= 0 {
+		return nil
+	}
+	b := float64(burst)
+	if b < 1 {
+		b = math.Max(1, math.Ceil(rate))
+	}
+	return &tokenBucket{rate: rate, burst: b, tokens: b, last: now}
+}
+
+// wait refills the bucket and returns how long to wait until a token is available.
+func (b *tokenBucket) wait(now time.Time) time.Duration {
+	if b == nil {
+		return 0
+	}
+	if now.After(b.last) {
+		b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+	}
+	if b.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+func (b *tokenBucket) take() {
+	if b != nil {
+		b.tokens--
+	}
+}
+
+type quotaLimiter struct {
+	prefix string
+	bucket *tokenBucket
+}
+
+// keyLimiter holds the buckets of a single API key.
+type keyLimiter struct {
+	config *api.APIKey
+
+	mu     sync.Mutex
+	bucket *tokenBucket
+	quotas []*quotaLimiter // sorted by prefix length, longest first
+}
+
+func newKeyLimiter(config *api.APIKey, now time.Time) *keyLimiter {
+	l := &keyLimiter{
+		config: config,
+		bucket: newTokenBucket(config.Rate, config.Burst, now),
+	}
+	for _, q := range config.Quotas {
+		l.quotas = append(l.quotas, &quotaLimiter{
+			prefix: q.Prefix,
+			bucket: newTokenBucket(q.Rate, q.Burst, now),
+		})
+	}
+	sort.SliceStable(l.quotas, func(i, j int) bool { return len(l.quotas[i].prefix) > len(l.quotas[j].prefix) })
+	return l
+}
+
+// allow consumes a token from the key bucket and from the bucket of the quota matching the path.
+// It returns the matched route, and how long to wait when the request is not allowed.
+func (l *keyLimiter) allow(path string, now time.Time) (string, time.Duration) {
+	var quota *quotaLimiter
+	for _, q := range l.quotas {
+		if strings.HasPrefix(path, q.prefix) {
+			quota = q
+			break
+		}
+	}
+	route := "*"
+	if quota != nil {
+		route = quota.prefix
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	wait := l.bucket.wait(now)
+	if quota != nil {
+		wait = max(wait, quota.bucket.wait(now))
+	}
+	if wait > 0 {
+		return route, wait
+	}
+	l.bucket.take()
+	if quota != nil {
+		quota.bucket.take()
+	}
+	return route, 0
+}
+
+// APIKeys holds the accepted API keys and their rate limiters. Keys are loaded from a JSON file,
+// which is rewritten when keys are added or removed.
+type APIKeys struct {
+	path string
+
+	mu    sync.RWMutex
+	keys  map[string]*keyLimiter // by key
+	names map[string]string      // name to key
+}
+
+// NewAPIKeys loads API keys from the given file. A missing file is treated as an empty key set.
+func NewAPIKeys(path string) (*APIKeys, error) {
+	k := &APIKeys{
+		path:  path,
+		keys:  make(map[string]*keyLimiter),
+		names: make(map[string]string),
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return k, nil
+		}
+		return nil, err
+	}
+	var keys []*api.APIKey
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("decode api keys: %w", err)
+	}
+	for _, key := range keys {
+		if err := k.add(key); err != nil {
+			return nil, err
+		}
+	}
+	return k, nil
+}
+
+// Add validates and adds a new API key, then saves the key file.
+func (k *APIKeys) Add(key *api.APIKey) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if err := k.add(key); err != nil {
+		return err
+	}
+	if err := k.save(); err != nil {
+		k.remove(key.Name)
+		return err
+	}
+	return nil
+}
+
+// Remove removes the API key with the given name, then saves the key file.
+func (k *APIKeys) Remove(name string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	limiter, ok := k.remove(name)
+	if !ok {
+		return ErrAPIKeyNotFound
+	}
+	if err := k.save(); err != nil {
+		k.keys[limiter.config.Key] = limiter
+		k.names[name] = limiter.config.Key
+		return err
+	}
+	return nil
+}
+
+// List returns the configured API keys sorted by name, without the secret keys.
+func (k *APIKeys) List() []*api.APIKey {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	list := make([]*api.APIKey, 0, len(k.keys))
+	for _, l := range k.keys {
+		cpy := *l.config
+		cpy.Key = ""
+		list = append(list, &cpy)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+func (k *APIKeys) add(key *api.APIKey) error {
+	if key == nil {
+		return errors.New("api key: null not allowed")
+	}
+	if key.Name == "" {
+		return errors.New("name: must not be empty")
+	}
+	if key.Key == "" {
+		return fmt.Errorf("key of %s: must not be empty", key.Name)
+	}
+	if key.Rate < 0 || key.Burst < 0 {
+		return fmt.Errorf("rate of %s: must not be negative", key.Name)
+	}
+	for i, q := range key.Quotas {
+		if q == nil || !strings.HasPrefix(q.Prefix, "/") {
+			return fmt.Errorf("quotas[%d] of %s: prefix must start with /", i, key.Name)
+		}
+		if q.Rate < 0 || q.Burst < 0 {
+			return fmt.Errorf("quotas[%d] of %s: rate must not be negative", i, key.Name)
+		}
+	}
+	if _, ok := k.names[key.Name]; ok {
+		return fmt.Errorf("name: %s already exists", key.Name)
+	}
+	if _, ok := k.keys[key.Key]; ok {
+		return fmt.Errorf("key of %s: already exists", key.Name)
+	}
+
+	// keep a copy, so the caller can't change the stored key
+	cpy := *key
+	cpy.Quotas = make([]*api.APIKeyQuota, 0, len(key.Quotas))
+	for _, q := range key.Quotas {
+		qc := *q
+		cpy.Quotas = append(cpy.Quotas, &qc)
+	}
+	k.keys[cpy.Key] = newKeyLimiter(&cpy, time.Now())
+	k.names[cpy.Name] = cpy.Key
+	return nil
+}
+
+func (k *APIKeys) remove(name string) (*keyLimiter, bool) {
+	key, ok := k.names[name]
+	if !ok {
+		return nil, false
+	}
+	limiter := k.keys[key]
+	delete(k.keys, key)
+	delete(k.names, name)
+	return limiter, true
+}
+
+// save writes the keys to the key file atomically.
+func (k *APIKeys) save() error {
+	keys := make([]*api.APIKey, 0, len(k.keys))
+	for _, l := range k.keys {
+		keys = append(keys, l.config)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Name < keys[j].Name })
+
+	data, err := json.MarshalIndent(keys, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(k.path), filepath.Base(k.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), k.path)
+}
+
+func (k *APIKeys) get(key string) *keyLimiter {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.keys[key]
+}
+
+// HandleAPIKeys is a middleware that rejects requests without a known API key in the 'x-api-key'
+// header, and rate limits requests per key and per route quota. Keys are not read from the query,
+// which ends up in access logs and proxies.
+func HandleAPIKeys(keys *APIKeys) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// leave CORS preflight requests to the CORS handler
+			if r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			limiter := keys.get(r.Header.Get(apiKeyHeader))
+			if limiter == nil {
+				metricAPIKeyRequestCount().AddWithLabel(1, map[string]string{"key": "", "route": "", "result": "unauthorized"})
+				io.Copy(io.Discard, r.Body)
+				http.Error(w, "invalid api key", http.StatusUnauthorized)
+				return
+			}
+
+			route, wait := limiter.allow(r.URL.Path, time.Now())
+			if wait > 0 {
+				metricAPIKeyRequestCount().AddWithLabel(1, map[string]string{"key": limiter.config.Name, "route": route, "result": "limited"})
+				io.Copy(io.Discard, r.Body)
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+				http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+				return
+			}
+			metricAPIKeyRequestCount().AddWithLabel(1, map[string]string{"key": limiter.config.Name, "route": route, "result": "allowed"})
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gorilla/handlers"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/api"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+	b := newTokenBucket(2, 2, now)
+
+	for range 2 {
+		require.Zero(t, b.wait(now))
+		b.take()
+	}
+	assert.Equal(t, 500*time.Millisecond, b.wait(now))
+	assert.Zero(t, b.wait(now.Add(500*time.Millisecond)))
+
+	// refill never exceeds burst
+	b.take()
+	assert.Zero(t, b.wait(now.Add(time.Hour)))
+	assert.Equal(t, float64(2), b.tokens)
+
+	// zero rate means unlimited
+	assert.Nil(t, newTokenBucket(0, 10, now))
+	assert.Zero(t, (*tokenBucket)(nil).wait(now))
+}
+
+func TestKeyLimiter_Quotas(t *testing.T) {
+	now := time.Now()
+	l := newKeyLimiter(&api.APIKey{
+		Name: "test",
+		Rate: 3,
+		Quotas: []*api.APIKeyQuota{
+			{Prefix: "/logs", Rate: 1, Burst: 1},
+			{Prefix: "/logs/event", Rate: 2, Burst: 2},
+		},
+	}, now)
+
+	route, wait := l.allow("/logs/transfer", now)
+	assert.Equal(t, "/logs", route)
+	assert.Zero(t, wait)
+
+	route, wait = l.allow("/logs/transfer", now)
+	assert.Equal(t, "/logs", route)
+	assert.Equal(t, time.Second, wait)
+
+	// the longest prefix wins
+	route, wait = l.allow("/logs/event", now)
+	assert.Equal(t, "/logs/event", route)
+	assert.Zero(t, wait)
+
+	route, wait = l.allow("/blocks/best", now)
+	assert.Equal(t, "*", route)
+	assert.Zero(t, wait)
+
+	// key bucket is exhausted, without consuming the quota
+	_, wait = l.allow("/logs/event", now)
+	assert.NotZero(t, wait)
+	assert.Equal(t, float64(1), l.quotas[0].bucket.tokens)
+}
+
+func TestAPIKeys_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+
+	keys, err := NewAPIKeys(path)
+	require.NoError(t, err)
+	assert.Empty(t, keys.List())
+
+	require.NoError(t, keys.Add(&api.APIKey{Name: "a", Key: "key-a", Rate: 1}))
+	require.NoError(t, keys.Add(&api.APIKey{Name: "b", Key: "key-b", Quotas: []*api.APIKeyQuota{{Prefix: "/debug", Rate: 1}}}))
+
+	for _, key := range []*api.APIKey{
+		nil,
+		{Key: "key-c"},
+		{Name: "c"},
+		{Name: "a", Key: "key-c"},
+		{Name: "c", Key: "key-a"},
+		{Name: "c", Key: "key-c", Rate: -1},
+		{Name: "c", Key: "key-c", Quotas: []*api.APIKeyQuota{{Prefix: "debug"}}},
+	} {
+		assert.Error(t, keys.Add(key))
+	}
+
+	list := keys.List()
+	require.Len(t, list, 2)
+	assert.Equal(t, "a", list[0].Name)
+	assert.Empty(t, list[0].Key)
+
+	// reload from file
+	keys, err = NewAPIKeys(path)
+	require.NoError(t, err)
+	assert.Len(t, keys.List(), 2)
+	assert.NotNil(t, keys.get("key-b"))
+
+	require.NoError(t, keys.Remove("b"))
+	assert.Equal(t, ErrAPIKeyNotFound, keys.Remove("b"))
+
+	keys, err = NewAPIKeys(path)
+	require.NoError(t, err)
+	assert.Len(t, keys.List(), 1)
+	assert.Nil(t, keys.get("key-b"))
+
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0600))
+	_, err = NewAPIKeys(path)
+	assert.Error(t, err)
+}
+
+func TestHandleAPIKeys(t *testing.T) {
+	keys, err := NewAPIKeys(filepath.Join(t.TempDir(), "keys.json"))
+	require.NoError(t, err)
+	require.NoError(t, keys.Add(&api.APIKey{
+		Name:   "partner",
+		Key:    "secret",
+		Quotas: []*api.APIKeyQuota{{Prefix: "/logs", Rate: 1, Burst: 1}},
+	}))
+
+	router := mux.NewRouter()
+	router.PathPrefix("/").HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	router.Use(HandleAPIKeys(keys))
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	do := func(path, key string) *http.Response {
+		req, err := http.NewRequest(http.MethodGet, ts.URL+path, nil)
+		require.NoError(t, err)
+		if key != "" {
+			req.Header.Set("x-api-key", key)
+		}
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		res.Body.Close()
+		return res
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, do("/blocks/best", "").StatusCode)
+	assert.Equal(t, http.StatusUnauthorized, do("/blocks/best", "invalid").StatusCode)
+	assert.Equal(t, http.StatusOK, do("/blocks/best", "secret").StatusCode)
+	// keys in the query are not accepted
+	assert.Equal(t, http.StatusUnauthorized, do("/blocks/best?x-api-key=secret", "").StatusCode)
+
+	assert.Equal(t, http.StatusOK, do("/logs/event", "secret").StatusCode)
+	res := do("/logs/event", "secret")
+	assert.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+	assert.Equal(t, "1", res.Header.Get("Retry-After"))
+
+	// other routes are not limited by the logs quota
+	assert.Equal(t, http.StatusOK, do("/accounts/0x", "secret").StatusCode)
+
+	// keys removed at runtime are rejected
+	require.NoError(t, keys.Remove("partner"))
+	assert.Equal(t, http.StatusUnauthorized, do("/blocks/best", "secret").StatusCode)
+}
+
+func TestHandleAPIKeys_CORS(t *testing.T) {
+	keys, err := NewAPIKeys(filepath.Join(t.TempDir(), "keys.json"))
+	require.NoError(t, err)
+	require.NoError(t, keys.Add(&api.APIKey{Name: "partner", Key: "secret", Rate: 1, Burst: 1}))
+
+	router := mux.NewRouter()
+	router.PathPrefix("/").HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	router.Use(handlers.CORS(handlers.AllowedOrigins([]string{"*"})))
+	router.Use(HandleAPIKeys(keys))
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	do := func(key string) *http.Response {
+		req, err := http.NewRequest(http.MethodGet, ts.URL+"/blocks/best", nil)
+		require.NoError(t, err)
+		req.Header.Set("Origin", "https://example.org")
+		req.Header.Set("x-api-key", key)
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		res.Body.Close()
+		return res
+	}
+
+	for _, tt := range []struct {
+		key  string
+		code int
+	}{
+		{"invalid", http.StatusUnauthorized},
+		{"secret", http.StatusOK},
+		{"secret", http.StatusTooManyRequests},
+	} {
+		res := do(tt.key)
+		assert.Equal(t, tt.code, res.StatusCode)
+		assert.Equal(t, "*", res.Header.Get("Access-Control-Allow-Origin"))
+	}
+}
+
+func TestAPIKey_JSON(t *testing.T) {
+	var keys []*api.APIKey
+	require.NoError(t, json.Unmarshal([]byte(`[{"name":"a","key":"k","rate":1.5,"burst":3,"quotas":[{"prefix":"/logs","rate":0.1,"burst":1}]}]`), &keys))
+	require.Len(t, keys, 1)
+	assert.Equal(t, 1.5, keys[0].Rate)
+	assert.Equal(t, "/logs", keys[0].Quotas[0].Prefix)
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"github.com/vechain/thor/v2/api/utils"
+	"github.com/vechain/thor/v2/cache"
+	"github.com/vechain/thor/v2/metrics"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// maxCachedBodySize is the max size of a response body kept in the response cache.
+const maxCachedBodySize = 256 * 1024
+
+var metricResponseCacheCount = metrics.LazyLoadCounterVec("api_response_cache_count", []string{"result"})
+
+type cachedResponse struct {
+	etag   string
+	header http.Header // headers set by the handler, such as the content type
+	body   []byte
+}
+
+// ResponseCache keeps responses marked as immutable, keyed by the normalized request.
+// The least recently used responses are evicted first.
+type ResponseCache struct {
+	cache *cache.PrioCache
+	seq   atomic.Uint64
+}
+
+// NewResponseCache creates a response cache holding up to size responses.
+// A cache of size 0 keeps nothing, but still serves ETags for immutable responses.
+func NewResponseCache(size int) *ResponseCache {
+	c := &ResponseCache{}
+	if size > 0 {
+		c.cache = cache.NewPrioCache(size)
+	}
+	return c
+}
+
+func (c *ResponseCache) get(key string) *cachedResponse {
+	if c.cache == nil || key == "" {
+		return nil
+	}
+	v, _, ok := c.cache.Get(key)
+	if !ok {
+		return nil
+	}
+	// bump the priority, so that the least recently used entries have the lowest one
+	c.cache.Set(key, v, float64(c.seq.Add(1)))
+	return v.(*cachedResponse)
+}
+
+func (c *ResponseCache) set(key string, res *cachedResponse) {
+	if c.cache == nil || key == "" || len(res.body) > maxCachedBodySize {
+		return
+	}
+	c.cache.Set(key, res, float64(c.seq.Add(1)))
+}
+
+// cacheKey returns the normalized request as cache key, or an empty key if the request
+// can't be cached. Query parameters are sorted and JSON bodies are re-encoded in a compact form.
+// It returns an error if the body of the request can't be read.
+func cacheKey(r *http.Request) (string, error) {
+	switch r.Method {
+	case http.MethodGet:
+		if r.Header.Get("Upgrade") != "" {
+			return "", nil
+		}
+		return r.Method + " " + r.URL.Path + "?" + r.URL.Query().Encode(), nil
+	case http.MethodPost:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return "", err
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		var v any
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		if err := decoder.Decode(&v); err == nil {
+			if normalized, err := json.Marshal(v); err == nil {
+				body = normalized
+			}
+		}
+		return r.Method + " " + r.URL.Path + "?" + r.URL.Query().Encode() + "\n" + string(body), nil
+	}
+	return "", nil
+}
+
+// cachingResponseWriter buffers the response once the handler marked it as immutable,
+// other responses are passed through.
+type cachingResponseWriter struct {
+	http.ResponseWriter
+	decided   bool
+	buffering bool
+	buf       bytes.Buffer
+}
+
+func (w *cachingResponseWriter) WriteHeader(code int) {
+	if !w.decided {
+		w.decided = true
+		if utils.IsImmutable(w.Header()) {
+			if code == http.StatusOK {
+				w.buffering = true
+				return
+			}
+			// errors are never immutable
+			w.Header().Del("Cache-Control")
+		}
+	}
+	if !w.buffering {
+		w.ResponseWriter.WriteHeader(code)
+	}
+}
+
+func (w *cachingResponseWriter) Write(b []byte) (int, error) {
+	if !w.decided {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.buffering {
+		return w.buf.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Hijack complies the writer with WS subscriptions interface
+func (w *cachingResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("hijack not supported")
+	}
+	return h.Hijack()
+}
+
+func writeCachedResponse(w http.ResponseWriter, r *http.Request, res *cachedResponse) {
+	for k, v := range res.header {
+		w.Header()[k] = v
+	}
+	w.Header().Set("ETag", res.etag)
+	utils.SetImmutable(w)
+
+	for _, tag := range strings.Split(r.Header.Get("If-None-Match"), ",") {
+		if strings.TrimSpace(tag) == res.etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+	_, _ = w.Write(res.body)
+}
+
+// HandleResponseCache is a middleware that sets ETags on responses marked as immutable,
+// answers conditional requests for them, and serves them from the given cache.
+func HandleResponseCache(c *ResponseCache) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key, err := cacheKey(r)
+			if err != nil {
+				// a truncated body is never passed to the handler
+				status := http.StatusBadRequest
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					status = http.StatusRequestEntityTooLarge
+				}
+				http.Error(w, "body: "+err.Error(), status)
+				return
+			}
+			if res := c.get(key); res != nil {
+				metricResponseCacheCount().AddWithLabel(1, map[string]string{"result": "hit"})
+				writeCachedResponse(w, r, res)
+				return
+			}
+
+			before := w.Header().Clone()
+			cw := &cachingResponseWriter{ResponseWriter: w}
+			next.ServeHTTP(cw, r)
+			if !cw.buffering {
+				return
+			}
+
+			body := cw.buf.Bytes()
+			hash := thor.Blake2b(body)
+			res := &cachedResponse{
+				etag:   `"` + hex.EncodeToString(hash[:16]) + `"`,
+				header: handlerHeader(before, w.Header()),
+				body:   body,
+			}
+			if c.cache != nil && key != "" {
+				metricResponseCacheCount().AddWithLabel(1, map[string]string{"result": "store"})
+				c.set(key, res)
+			}
+			writeCachedResponse(w, r, res)
+		})
+	}
+}
+
+// handlerHeader returns the headers set by the handler, the ones differing from the headers set before it, without
+// the cache control ones, replayed on cache hits.
+func handlerHeader(before, after http.Header) http.Header {
+	header := make(http.Header)
+	for k, v := range after {
+		if k == "Cache-Control" || k == "Etag" || k == "Content-Length" || slices.Equal(before[k], v) {
+			continue
+		}
+		header[k] = slices.Clone(v)
+	}
+	return header
+}
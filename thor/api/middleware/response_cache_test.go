@@ -0,0 +1,175 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/api/utils"
+)
+
+func newResponseCacheServer(t *testing.T, size int) (*httptest.Server, map[string]int) {
+	calls := make(map[string]int)
+
+	router := mux.NewRouter()
+	router.Path("/immutable").HandlerFunc(utils.WrapHandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		calls["immutable"]++
+		utils.SetImmutable(w)
+		w.Header().Set("X-Query", r.URL.Query().Get("q"))
+		body, _ := io.ReadAll(r.Body)
+		return utils.WriteJSON(w, map[string]string{"query": r.URL.Query().Get("q"), "body": string(body)})
+	}))
+	router.Path("/best").HandlerFunc(utils.WrapHandlerFunc(func(w http.ResponseWriter, _ *http.Request) error {
+		calls["best"]++
+		utils.SetShortMaxAge(w)
+		return utils.WriteJSON(w, "best")
+	}))
+	router.Path("/error").HandlerFunc(utils.WrapHandlerFunc(func(w http.ResponseWriter, _ *http.Request) error {
+		calls["error"]++
+		utils.SetImmutable(w)
+		return errors.New("boom")
+	}))
+	// a header set per request before the cache
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Request", r.URL.RawQuery)
+			next.ServeHTTP(w, r)
+		})
+	})
+	router.Use(HandleResponseCache(NewResponseCache(size)))
+
+	ts := httptest.NewServer(router)
+	t.Cleanup(ts.Close)
+	return ts, calls
+}
+
+func doRequest(t *testing.T, method, url, body, etag string) (*http.Response, string) {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	data, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	return res, string(data)
+}
+
+func TestResponseCache(t *testing.T) {
+	ts, calls := newResponseCacheServer(t, 16)
+
+	res, body := doRequest(t, http.MethodGet, ts.URL+"/immutable?q=1&r=2", "", "")
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "public, max-age=31536000, immutable", res.Header.Get("Cache-Control"))
+	assert.Equal(t, utils.JSONContentType, res.Header.Get("Content-Type"))
+	etag := res.Header.Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	// query parameters are normalized
+	res, cached := doRequest(t, http.MethodGet, ts.URL+"/immutable?r=2&q=1", "", "")
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, body, cached)
+	assert.Equal(t, etag, res.Header.Get("ETag"))
+	assert.Equal(t, 1, calls["immutable"])
+	// the headers set by the handler are replayed, not the ones set before
+	assert.Equal(t, "1", res.Header.Get("X-Query"))
+	assert.Equal(t, "r=2&q=1", res.Header.Get("X-Request"))
+	assert.Equal(t, utils.JSONContentType, res.Header.Get("Content-Type"))
+
+	res, cached = doRequest(t, http.MethodGet, ts.URL+"/immutable?r=2&q=1", "", etag)
+	assert.Equal(t, http.StatusNotModified, res.StatusCode)
+	assert.Empty(t, cached)
+	assert.Equal(t, 1, calls["immutable"])
+
+	// JSON bodies are normalized
+	doRequest(t, http.MethodPost, ts.URL+"/immutable", `{"a": 1, "b": 12345678901234567890}`, "")
+	_, cached = doRequest(t, http.MethodPost, ts.URL+"/immutable", `{"b":12345678901234567890,"a":1}`, "")
+	assert.Contains(t, cached, `12345678901234567890`)
+	assert.Equal(t, 2, calls["immutable"])
+
+	doRequest(t, http.MethodPost, ts.URL+"/immutable", `{"a": 2}`, "")
+	assert.Equal(t, 3, calls["immutable"])
+
+	// short lived responses are not cached
+	res, _ = doRequest(t, http.MethodGet, ts.URL+"/best", "", "")
+	assert.Equal(t, "public, max-age=1", res.Header.Get("Cache-Control"))
+	assert.Empty(t, res.Header.Get("ETag"))
+	doRequest(t, http.MethodGet, ts.URL+"/best", "", "")
+	assert.Equal(t, 2, calls["best"])
+
+	// errors are never immutable
+	res, _ = doRequest(t, http.MethodGet, ts.URL+"/error", "", "")
+	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	assert.Empty(t, res.Header.Get("Cache-Control"))
+	doRequest(t, http.MethodGet, ts.URL+"/error", "", "")
+	assert.Equal(t, 2, calls["error"])
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errors.New("connection reset") }
+
+func TestResponseCache_BodyReadError(t *testing.T) {
+	var calls int
+	handler := HandleRequestBodyLimit(8)(HandleResponseCache(NewResponseCache(16))(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		utils.SetImmutable(w)
+		w.WriteHeader(http.StatusOK)
+	})))
+
+	// the body over the limit is rejected, not passed truncated to the handler
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/immutable", strings.NewReader(`{"a": "too large"}`)))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/immutable", failingReader{}))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Zero(t, calls)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/immutable", strings.NewReader(`{}`)))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 1, calls)
+}
+
+func TestResponseCache_Disabled(t *testing.T) {
+	ts, calls := newResponseCacheServer(t, 0)
+
+	res, _ := doRequest(t, http.MethodGet, ts.URL+"/immutable", "", "")
+	etag := res.Header.Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	res, _ = doRequest(t, http.MethodGet, ts.URL+"/immutable", "", etag)
+	assert.Equal(t, http.StatusNotModified, res.StatusCode)
+	assert.Equal(t, 2, calls["immutable"])
+}
+
+func TestResponseCache_LRU(t *testing.T) {
+	ts, calls := newResponseCacheServer(t, 2)
+
+	doRequest(t, http.MethodGet, ts.URL+"/immutable?q=1", "", "")
+	doRequest(t, http.MethodGet, ts.URL+"/immutable?q=2", "", "")
+	// touch q=1, so that q=2 is evicted
+	doRequest(t, http.MethodGet, ts.URL+"/immutable?q=1", "", "")
+	doRequest(t, http.MethodGet, ts.URL+"/immutable?q=3", "", "")
+	assert.Equal(t, 3, calls["immutable"])
+
+	doRequest(t, http.MethodGet, ts.URL+"/immutable?q=1", "", "")
+	assert.Equal(t, 3, calls["immutable"])
+	doRequest(t, http.MethodGet, ts.URL+"/immutable?q=2", "", "")
+	assert.Equal(t, 4, calls["immutable"])
+}
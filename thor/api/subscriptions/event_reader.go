@@ -9,22 +9,55 @@ import (
 	"github.com/vechain/thor/v2/api"
 	"github.com/vechain/thor/v2/chain"
 	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
 )
 
 type eventReader struct {
 	repo        *chain.Repository
-	filter      *api.SubscriptionEventFilter
+	matcher     *eventMatcher
 	blockReader chain.BlockReader
 }
 
 func newEventReader(repo *chain.Repository, position thor.Bytes32, filter *api.SubscriptionEventFilter) *eventReader {
 	return &eventReader{
 		repo:        repo,
-		filter:      filter,
+		matcher:     newEventMatcher(filter),
 		blockReader: repo.NewBlockReader(position),
 	}
 }
 
+// eventMatcher evaluates the filter of an event subscription.
+// Criteria with an address are indexed by it, so that an event is only tested
+// against the criteria of its emitter and those without address.
+type eventMatcher struct {
+	filter    *api.SubscriptionEventFilter
+	byAddress map[thor.Address][]*api.EventCriteria
+	others    []*api.EventCriteria
+}
+
+func newEventMatcher(filter *api.SubscriptionEventFilter) *eventMatcher {
+	m := &eventMatcher{
+		filter:    filter,
+		byAddress: make(map[thor.Address][]*api.EventCriteria),
+	}
+	for _, c := range filter.CriteriaSet {
+		if c.Address != nil {
+			m.byAddress[*c.Address] = append(m.byAddress[*c.Address], c)
+		} else {
+			m.others = append(m.others, c)
+		}
+	}
+	return m
+}
+
+func (m *eventMatcher) Match(event *tx.Event) bool {
+	if len(m.filter.CriteriaSet) == 0 {
+		return m.filter.Match(event)
+	}
+	return api.MatchEventCriteriaSet(m.byAddress[event.Address], event) ||
+		api.MatchEventCriteriaSet(m.others, event)
+}
+
 func (er *eventReader) Read() ([]any, bool, error) {
 	blocks, err := er.blockReader.Read()
 	if err != nil {
@@ -40,7 +73,7 @@ func (er *eventReader) Read() ([]any, bool, error) {
 		for i, receipt := range receipts {
 			for j, output := range receipt.Outputs {
 				for _, event := range output.Events {
-					if er.filter.Match(event) {
+					if er.matcher.Match(event) {
 						msg, err := api.ConvertSubscriptionEvent(block.Header(), txs[i], uint32(j), event, block.Obsolete)
 						if err != nil {
 							return nil, false, err
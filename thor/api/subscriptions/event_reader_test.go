@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/vechain/thor/v2/api"
 	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/thor"
 )
 
 func TestEventReader_Read(t *testing.T) {
@@ -25,7 +26,7 @@ func TestEventReader_Read(t *testing.T) {
 
 	er := &eventReader{
 		repo:        thorChain.Repo(),
-		filter:      &api.SubscriptionEventFilter{},
+		matcher:     newEventMatcher(&api.SubscriptionEventFilter{}),
 		blockReader: &mockBlockReaderWithError{},
 	}
 
@@ -62,6 +63,43 @@ func TestEventReader_Read(t *testing.T) {
 	assert.Equal(t, bestBlk.Header().Number(), eventMsg.Meta.BlockNumber)
 }
 
+func TestEventReader_Read_CriteriaSet(t *testing.T) {
+	thorChain := initChain(t)
+	allBlocks, err := thorChain.GetAllBlocks()
+	require.NoError(t, err)
+	bestBlk := allBlocks[len(allBlocks)-1]
+
+	// read all events to find the emitter of the deployed contract events
+	er := newEventReader(thorChain.Repo(), allBlocks[1].Header().ID(), &api.SubscriptionEventFilter{})
+	events, _, err := er.Read()
+	require.NoError(t, err)
+	require.NotEmpty(t, events)
+	emitter := events[0].(*api.EventMessage).Address
+	topic := events[0].(*api.EventMessage).Topics[0]
+
+	nonExisting := thor.MustParseAddress("0xffffffffffffffffffffffffffffffffffffffff")
+	for name, tc := range map[string]struct {
+		criteriaSet []*api.EventCriteria
+		expected    int
+	}{
+		"no matching criteria": {[]*api.EventCriteria{{Address: &nonExisting}}, 0},
+		"address":              {[]*api.EventCriteria{{Address: &nonExisting}, {Address: &emitter}}, len(events)},
+		"address and topic":    {[]*api.EventCriteria{{Address: &emitter, TopicSet: api.TopicSet{Topic0: &topic}}}, 1},
+		"topic only":           {[]*api.EventCriteria{{Address: &nonExisting}, {TopicSet: api.TopicSet{Topic0: &topic}}}, 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			er := newEventReader(thorChain.Repo(), allBlocks[1].Header().ID(), &api.SubscriptionEventFilter{CriteriaSet: tc.criteriaSet})
+			msgs, ok, err := er.Read()
+			assert.NoError(t, err)
+			assert.True(t, ok)
+			assert.Len(t, msgs, tc.expected)
+			for _, msg := range msgs {
+				assert.Equal(t, bestBlk.Header().ID(), msg.(*api.EventMessage).Meta.BlockID)
+			}
+		})
+	}
+}
+
 type mockBlockReaderWithError struct{}
 
 func (m *mockBlockReaderWithError) Read() ([]*chain.ExtendedBlock, error) {
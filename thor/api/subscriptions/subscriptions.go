@@ -8,6 +8,7 @@ package subscriptions
 import (
 	"fmt"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -19,6 +20,7 @@ import (
 	"github.com/vechain/thor/v2/block"
 	"github.com/vechain/thor/v2/chain"
 	"github.com/vechain/thor/v2/log"
+	"github.com/vechain/thor/v2/logdb"
 	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/tx"
 	"github.com/vechain/thor/v2/txpool"
@@ -125,13 +127,21 @@ func (s *Subscriptions) handleEventReader(w http.ResponseWriter, req *http.Reque
 	if err != nil {
 		return nil, utils.BadRequest(errors.WithMessage(err, "t4"))
 	}
+	var criteriaSet []*api.EventCriteria
+	if err := parseCriteriaSet(req.URL.Query().Get("criteriaSet"), &criteriaSet); err != nil {
+		return nil, utils.BadRequest(errors.WithMessage(err, "criteriaSet"))
+	}
+	if len(criteriaSet) > 0 && (address != nil || t0 != nil || t1 != nil || t2 != nil || t3 != nil || t4 != nil) {
+		return nil, utils.BadRequest(errors.New("criteriaSet: exclusive with addr and topics"))
+	}
 	eventFilter := &api.SubscriptionEventFilter{
-		Address: address,
-		Topic0:  t0,
-		Topic1:  t1,
-		Topic2:  t2,
-		Topic3:  t3,
-		Topic4:  t4,
+		Address:     address,
+		Topic0:      t0,
+		Topic1:      t1,
+		Topic2:      t2,
+		Topic3:      t3,
+		Topic4:      t4,
+		CriteriaSet: criteriaSet,
 	}
 	return newEventReader(s.repo, position, eventFilter), nil
 }
@@ -153,10 +163,18 @@ func (s *Subscriptions) handleTransferReader(_ http.ResponseWriter, req *http.Re
 	if err != nil {
 		return nil, utils.BadRequest(errors.WithMessage(err, "recipient"))
 	}
+	var criteriaSet []*logdb.TransferCriteria
+	if err := parseCriteriaSet(req.URL.Query().Get("criteriaSet"), &criteriaSet); err != nil {
+		return nil, utils.BadRequest(errors.WithMessage(err, "criteriaSet"))
+	}
+	if len(criteriaSet) > 0 && (txOrigin != nil || sender != nil || recipient != nil) {
+		return nil, utils.BadRequest(errors.New("criteriaSet: exclusive with txOrigin, sender and recipient"))
+	}
 	transferFilter := &api.SubscriptionTransferFilter{
-		TxOrigin:  txOrigin,
-		Sender:    sender,
-		Recipient: recipient,
+		TxOrigin:    txOrigin,
+		Sender:      sender,
+		Recipient:   recipient,
+		CriteriaSet: criteriaSet,
 	}
 	return newTransferReader(s.repo, position, transferFilter), nil
 }
@@ -352,6 +370,24 @@ func parseAddress(addr string) (*thor.Address, error) {
 	return &address, nil
 }
 
+// parseCriteriaSet decodes a JSON encoded criteria set, which has the same format
+// as the criteriaSet of the logs API.
+func parseCriteriaSet[T any](str string, criteriaSet *[]*T) error {
+	if str == "" {
+		return nil
+	}
+	if err := utils.ParseJSON(strings.NewReader(str), criteriaSet); err != nil {
+		return err
+	}
+	// reject null element, {} will be unmarshaled to default value which matches all
+	for i, c := range *criteriaSet {
+		if c == nil {
+			return fmt.Errorf("[%d]: null not allowed", i)
+		}
+	}
+	return nil
+}
+
 func (s *Subscriptions) Close() {
 	close(s.done)
 	s.wg.Wait()
@@ -47,6 +47,7 @@ func TestSubscriptions(t *testing.T) {
 		"testHandleSubjectWithBeat":             testHandleSubjectWithBeat,
 		"testHandleSubjectWithBeat2":            testHandleSubjectWithBeat2,
 		"testHandleSubjectWithNonValidArgument": testHandleSubjectWithNonValidArgument,
+		"testHandleSubjectWithCriteriaSet":      testHandleSubjectWithCriteriaSet,
 	} {
 		t.Run(name, tt)
 	}
@@ -218,6 +219,44 @@ func testHandleSubjectWithNonValidArgument(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
 }
 
+func testHandleSubjectWithCriteriaSet(t *testing.T) {
+	genesisBlock := blocks[0]
+	recipient := thor.BytesToAddress([]byte("to"))
+
+	for _, tc := range []struct {
+		path   string
+		query  url.Values
+		status int
+	}{
+		{"/subscriptions/event", url.Values{"criteriaSet": {"[{}]"}}, http.StatusSwitchingProtocols},
+		{"/subscriptions/event", url.Values{"criteriaSet": {"{"}}, http.StatusBadRequest},
+		{"/subscriptions/event", url.Values{"criteriaSet": {"[null]"}}, http.StatusBadRequest},
+		{"/subscriptions/event", url.Values{"criteriaSet": {"[{}]"}, "addr": {recipient.String()}}, http.StatusBadRequest},
+		{"/subscriptions/transfer", url.Values{"criteriaSet": {fmt.Sprintf(`[{"sender":"%v"},{"recipient":"%v"}]`, thor.Address{}, recipient)}}, http.StatusSwitchingProtocols},
+		{"/subscriptions/transfer", url.Values{"criteriaSet": {`[{"unknown":"0x"}]`}}, http.StatusBadRequest},
+		{"/subscriptions/transfer", url.Values{"criteriaSet": {"[{}]"}, "sender": {recipient.String()}}, http.StatusBadRequest},
+	} {
+		tc.query.Set("pos", genesisBlock.Header().ID().String())
+		u := url.URL{Scheme: "ws", Host: strings.TrimPrefix(ts.URL, "http://"), Path: tc.path, RawQuery: tc.query.Encode()}
+
+		conn, resp, err := websocket.DefaultDialer.Dial(u.String(), nil)
+		require.NotNil(t, resp)
+		assert.Equal(t, tc.status, resp.StatusCode, tc.query.Encode())
+		if err != nil {
+			continue
+		}
+
+		_, msg, err := conn.ReadMessage()
+		assert.NoError(t, err)
+		var logMsg struct {
+			Meta api.LogMeta `json:"meta"`
+		}
+		require.NoError(t, json.Unmarshal(msg, &logMsg))
+		assert.Equal(t, blocks[1].Header().ID(), logMsg.Meta.BlockID)
+		conn.Close()
+	}
+}
+
 func TestParseAddress(t *testing.T) {
 	addrStr := "0x0123456789abcdef0123456789abcdef01234567"
 	expectedAddr := thor.MustParseAddress(addrStr)
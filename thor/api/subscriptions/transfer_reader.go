@@ -8,23 +8,78 @@ package subscriptions
 import (
 	"github.com/vechain/thor/v2/api"
 	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/logdb"
 	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
 )
 
 type transferReader struct {
 	repo        *chain.Repository
-	filter      *api.SubscriptionTransferFilter
+	matcher     *transferMatcher
 	blockReader chain.BlockReader
 }
 
 func newTransferReader(repo *chain.Repository, position thor.Bytes32, filter *api.SubscriptionTransferFilter) *transferReader {
 	return &transferReader{
 		repo:        repo,
-		filter:      filter,
+		matcher:     newTransferMatcher(filter),
 		blockReader: repo.NewBlockReader(position),
 	}
 }
 
+// transferMatcher evaluates the filter of a transfer subscription.
+// Each criteria is indexed by the first address it restricts, in the order of
+// recipient, sender and txOrigin, so that a transfer is only tested against the
+// criteria that may match it.
+type transferMatcher struct {
+	filter      *api.SubscriptionTransferFilter
+	byRecipient map[thor.Address][]*logdb.TransferCriteria
+	bySender    map[thor.Address][]*logdb.TransferCriteria
+	byOrigin    map[thor.Address][]*logdb.TransferCriteria
+	others      []*logdb.TransferCriteria
+}
+
+func newTransferMatcher(filter *api.SubscriptionTransferFilter) *transferMatcher {
+	m := &transferMatcher{
+		filter:      filter,
+		byRecipient: make(map[thor.Address][]*logdb.TransferCriteria),
+		bySender:    make(map[thor.Address][]*logdb.TransferCriteria),
+		byOrigin:    make(map[thor.Address][]*logdb.TransferCriteria),
+	}
+	for _, c := range filter.CriteriaSet {
+		switch {
+		case c.Recipient != nil:
+			m.byRecipient[*c.Recipient] = append(m.byRecipient[*c.Recipient], c)
+		case c.Sender != nil:
+			m.bySender[*c.Sender] = append(m.bySender[*c.Sender], c)
+		case c.TxOrigin != nil:
+			m.byOrigin[*c.TxOrigin] = append(m.byOrigin[*c.TxOrigin], c)
+		default:
+			m.others = append(m.others, c)
+		}
+	}
+	return m
+}
+
+func (m *transferMatcher) Match(transfer *tx.Transfer, origin thor.Address) bool {
+	if len(m.filter.CriteriaSet) == 0 {
+		return m.filter.Match(transfer, origin)
+	}
+	for _, set := range [][]*logdb.TransferCriteria{
+		m.byRecipient[transfer.Recipient],
+		m.bySender[transfer.Sender],
+		m.byOrigin[origin],
+		m.others,
+	} {
+		for _, c := range set {
+			if api.MatchTransferCriteria(c, transfer, origin) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (tr *transferReader) Read() ([]any, bool, error) {
 	blocks, err := tr.blockReader.Read()
 	if err != nil {
@@ -38,13 +93,13 @@ func (tr *transferReader) Read() ([]any, bool, error) {
 		}
 		txs := block.Transactions()
 		for i, receipt := range receipts {
+			origin, err := txs[i].Origin()
+			if err != nil {
+				return nil, false, err
+			}
 			for j, output := range receipt.Outputs {
 				for _, transfer := range output.Transfers {
-					origin, err := txs[i].Origin()
-					if err != nil {
-						return nil, false, err
-					}
-					if tr.filter.Match(transfer, origin) {
+					if tr.matcher.Match(transfer, origin) {
 						msg, err := api.ConvertSubscriptionTransfer(block.Header(), txs[i], uint32(j), transfer, block.Obsolete)
 						if err != nil {
 							return nil, false, err
@@ -11,6 +11,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/logdb"
 	"github.com/vechain/thor/v2/thor"
 )
 
@@ -95,3 +97,47 @@ func TestTransferReader_Read_NoTransferMatchingTheFilter(t *testing.T) {
 	assert.True(t, ok)
 	assert.Empty(t, res)
 }
+
+func TestTransferReader_Read_CriteriaSet(t *testing.T) {
+	// Arrange
+	thorChain := initChain(t)
+	allBlocks, err := thorChain.GetAllBlocks()
+	require.NoError(t, err)
+	genesisBlk := allBlocks[0]
+	newBlock := allBlocks[1]
+
+	nonExistingAddress := thor.MustParseAddress("0xffffffffffffffffffffffffffffffffffffffff")
+	recipient := thor.BytesToAddress([]byte("to"))
+	origin := genesis.DevAccounts()[0].Address
+
+	for name, tc := range map[string]struct {
+		criteriaSet []*logdb.TransferCriteria
+		matched     bool
+	}{
+		"no matching criteria": {[]*logdb.TransferCriteria{
+			{Recipient: &nonExistingAddress},
+			{Sender: &nonExistingAddress},
+			{TxOrigin: &nonExistingAddress},
+		}, false},
+		"recipient":        {[]*logdb.TransferCriteria{{Sender: &nonExistingAddress}, {Recipient: &recipient}}, true},
+		"origin":           {[]*logdb.TransferCriteria{{Recipient: &nonExistingAddress}, {TxOrigin: &origin}}, true},
+		"partial mismatch": {[]*logdb.TransferCriteria{{TxOrigin: &origin, Recipient: &nonExistingAddress}}, false},
+		"match all":        {[]*logdb.TransferCriteria{{}}, true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			br := newTransferReader(thorChain.Repo(), genesisBlk.Header().ID(), &api.SubscriptionTransferFilter{CriteriaSet: tc.criteriaSet})
+			res, ok, err := br.Read()
+
+			// Assert
+			assert.NoError(t, err)
+			assert.True(t, ok)
+			if !tc.matched {
+				assert.Empty(t, res)
+				return
+			}
+			require.Len(t, res, 1)
+			assert.Equal(t, newBlock.Header().ID(), res[0].(*api.TransferMessage).Meta.BlockID)
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/vechain/thor/v2/block"
 	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/logdb"
 	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/tx"
 )
@@ -132,17 +133,24 @@ func ConvertSubscriptionEvent(header *block.Header, tx *tx.Transaction, clauseIn
 }
 
 // SubscriptionEventFilter contains options for contract event filtering.
+// When CriteriaSet is not empty, an event matches if it matches any of the criteria,
+// the same as POST /logs/event, and the single address and topics are ignored.
 type SubscriptionEventFilter struct {
-	Address *thor.Address // restricts matches to events created by specific contracts
-	Topic0  *thor.Bytes32
-	Topic1  *thor.Bytes32
-	Topic2  *thor.Bytes32
-	Topic3  *thor.Bytes32
-	Topic4  *thor.Bytes32
+	Address     *thor.Address // restricts matches to events created by specific contracts
+	Topic0      *thor.Bytes32
+	Topic1      *thor.Bytes32
+	Topic2      *thor.Bytes32
+	Topic3      *thor.Bytes32
+	Topic4      *thor.Bytes32
+	CriteriaSet []*EventCriteria
 }
 
 // Match returs whether event matches filter
 func (ef *SubscriptionEventFilter) Match(event *tx.Event) bool {
+	if len(ef.CriteriaSet) > 0 {
+		return MatchEventCriteriaSet(ef.CriteriaSet, event)
+	}
+
 	if (ef.Address != nil) && (*ef.Address != event.Address) {
 		return false
 	}
@@ -168,23 +176,43 @@ func (ef *SubscriptionEventFilter) Match(event *tx.Event) bool {
 }
 
 // SubscriptionTransferFilter contains options for contract transfer filtering.
+// When CriteriaSet is not empty, a transfer matches if it matches any of the criteria,
+// the same as POST /logs/transfer, and the single txOrigin, sender and recipient are ignored.
 type SubscriptionTransferFilter struct {
-	TxOrigin  *thor.Address // who send transaction
-	Sender    *thor.Address // who transferred tokens
-	Recipient *thor.Address // who received tokens
+	TxOrigin    *thor.Address // who send transaction
+	Sender      *thor.Address // who transferred tokens
+	Recipient   *thor.Address // who received tokens
+	CriteriaSet []*logdb.TransferCriteria
 }
 
 // Match returs whether transfer matches filter
 func (tf *SubscriptionTransferFilter) Match(transfer *tx.Transfer, origin thor.Address) bool {
-	if (tf.TxOrigin != nil) && (*tf.TxOrigin != origin) {
+	if len(tf.CriteriaSet) > 0 {
+		for _, c := range tf.CriteriaSet {
+			if MatchTransferCriteria(c, transfer, origin) {
+				return true
+			}
+		}
+		return false
+	}
+	return MatchTransferCriteria(&logdb.TransferCriteria{
+		TxOrigin:  tf.TxOrigin,
+		Sender:    tf.Sender,
+		Recipient: tf.Recipient,
+	}, transfer, origin)
+}
+
+// MatchTransferCriteria returns whether the transfer, sent by a transaction of the given origin, matches the criteria.
+func MatchTransferCriteria(c *logdb.TransferCriteria, transfer *tx.Transfer, origin thor.Address) bool {
+	if (c.TxOrigin != nil) && (*c.TxOrigin != origin) {
 		return false
 	}
 
-	if (tf.Sender != nil) && (*tf.Sender != transfer.Sender) {
+	if (c.Sender != nil) && (*c.Sender != transfer.Sender) {
 		return false
 	}
 
-	if (tf.Recipient != nil) && (*tf.Recipient != transfer.Recipient) {
+	if (c.Recipient != nil) && (*c.Recipient != transfer.Recipient) {
 		return false
 	}
 	return true
@@ -17,6 +17,7 @@ import (
 	"github.com/vechain/thor/v2/block"
 	"github.com/vechain/thor/v2/chain"
 	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/logdb"
 	"github.com/vechain/thor/v2/muxdb"
 	"github.com/vechain/thor/v2/state"
 	"github.com/vechain/thor/v2/thor"
@@ -360,3 +361,44 @@ func TestTransferFilter_Match(t *testing.T) {
 	}
 	assert.False(t, filter.Match(transfer, origin))
 }
+
+func TestEventFilter_MatchCriteriaSet(t *testing.T) {
+	addr := thor.BytesToAddress([]byte("address"))
+	other := thor.BytesToAddress([]byte("other_address"))
+	filter := &SubscriptionEventFilter{
+		// single fields are ignored when a criteria set is given
+		Address: &other,
+		CriteriaSet: []*EventCriteria{
+			{Address: &other},
+			{Address: &addr, TopicSet: TopicSet{Topic1: &thor.Bytes32{0x02}}},
+		},
+	}
+
+	event := &tx.Event{Address: addr, Topics: []thor.Bytes32{{0x01}, {0x02}}}
+	assert.True(t, filter.Match(event))
+
+	event = &tx.Event{Address: addr, Topics: []thor.Bytes32{{0x01}, {0x03}}}
+	assert.False(t, filter.Match(event))
+
+	event = &tx.Event{Address: other}
+	assert.True(t, filter.Match(event))
+}
+
+func TestTransferFilter_MatchCriteriaSet(t *testing.T) {
+	origin := thor.BytesToAddress([]byte("origin"))
+	sender := thor.BytesToAddress([]byte("sender"))
+	recipient := thor.BytesToAddress([]byte("recipient"))
+	filter := &SubscriptionTransferFilter{
+		CriteriaSet: []*logdb.TransferCriteria{
+			{TxOrigin: &origin, Recipient: &recipient},
+			{Sender: &sender},
+		},
+	}
+
+	transfer := &tx.Transfer{Sender: thor.BytesToAddress([]byte("other_sender")), Recipient: recipient}
+	assert.True(t, filter.Match(transfer, origin))
+	assert.False(t, filter.Match(transfer, thor.Address{}))
+
+	transfer = &tx.Transfer{Sender: sender, Recipient: thor.BytesToAddress([]byte("other_recipient"))}
+	assert.True(t, filter.Match(transfer, thor.Address{}))
+}
@@ -13,6 +13,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/vechain/thor/v2/api"
 	"github.com/vechain/thor/v2/api/utils"
+	"github.com/vechain/thor/v2/bft"
+	"github.com/vechain/thor/v2/block"
 	"github.com/vechain/thor/v2/chain"
 	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/txpool"
@@ -21,12 +23,14 @@ import (
 type Transactions struct {
 	repo *chain.Repository
 	pool *txpool.TxPool
+	bft  bft.Committer
 }
 
-func New(repo *chain.Repository, pool *txpool.TxPool) *Transactions {
+func New(repo *chain.Repository, pool *txpool.TxPool, bft bft.Committer) *Transactions {
 	return &Transactions{
 		repo,
 		pool,
+		bft,
 	}
 }
 
@@ -168,12 +172,22 @@ func (t *Transactions) handleGetTransactionByID(w http.ResponseWriter, req *http
 		if err != nil {
 			return err
 		}
+		if tx != nil && tx.Meta != nil {
+			if err := t.setCacheControl(w, tx.Meta.BlockID); err != nil {
+				return err
+			}
+		}
 		return utils.WriteJSON(w, tx)
 	}
 	tx, err := t.getTransactionByID(txID, head, pending == "true")
 	if err != nil {
 		return err
 	}
+	if tx != nil && tx.Meta != nil {
+		if err := t.setCacheControl(w, tx.Meta.BlockID); err != nil {
+			return err
+		}
+	}
 	return utils.WriteJSON(w, tx)
 }
 
@@ -199,9 +213,30 @@ func (t *Transactions) handleGetTransactionReceiptByID(w http.ResponseWriter, re
 	if err != nil {
 		return err
 	}
+	if receipt != nil {
+		if err := t.setCacheControl(w, receipt.Meta.BlockID); err != nil {
+			return err
+		}
+	}
 	return utils.WriteJSON(w, receipt)
 }
 
+// setCacheControl marks the response as immutable if the block including the transaction is finalized.
+func (t *Transactions) setCacheControl(w http.ResponseWriter, blockID thor.Bytes32) error {
+	num := block.Number(blockID)
+	if !utils.IsFinalized(num, t.bft) {
+		return nil
+	}
+	trunkID, err := t.repo.NewBestChain().GetBlockID(num)
+	if err != nil {
+		return err
+	}
+	if trunkID == blockID {
+		utils.SetImmutable(w)
+	}
+	return nil
+}
+
 func (t *Transactions) parseHead(head string) (thor.Bytes32, error) {
 	if head == "" {
 		return t.repo.BestBlockSummary().Header.ID(), nil
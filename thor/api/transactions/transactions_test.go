@@ -417,7 +417,7 @@ func initTransactionServer(t *testing.T) {
 	}
 
 	router := mux.NewRouter()
-	transactions.New(thorChain.Repo(), mempool).Mount(router, "/transactions")
+	transactions.New(thorChain.Repo(), mempool, thorChain.Engine()).Mount(router, "/transactions")
 
 	ts = httptest.NewServer(router)
 }
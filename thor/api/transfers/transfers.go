@@ -10,37 +10,45 @@ import (
 	"fmt"
 	"math"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 	"github.com/vechain/thor/v2/api"
 	"github.com/vechain/thor/v2/api/utils"
+	"github.com/vechain/thor/v2/bft"
+	"github.com/vechain/thor/v2/block"
 	"github.com/vechain/thor/v2/chain"
 	"github.com/vechain/thor/v2/logdb"
 )
 
 type Transfers struct {
-	repo  *chain.Repository
-	db    *logdb.LogDB
-	limit uint64
+	repo   *chain.Repository
+	db     *logdb.LogDB
+	limit  uint64
+	budget logdb.QueryBudget
+	bft    bft.Committer
 }
 
-func New(repo *chain.Repository, db *logdb.LogDB, logsLimit uint64) *Transfers {
+func New(repo *chain.Repository, db *logdb.LogDB, logsLimit uint64, budget logdb.QueryBudget, bft bft.Committer) *Transfers {
 	return &Transfers{
 		repo,
 		db,
 		logsLimit,
+		budget,
+		bft,
 	}
 }
 
 // Filter query logs with option
-func (t *Transfers) filter(ctx context.Context, filter *api.TransferFilter) ([]*api.FilteredTransfer, error) {
-	rng, err := api.ConvertRange(t.repo.NewBestChain(), filter.Range)
+func (t *Transfers) filter(ctx context.Context, w http.ResponseWriter, filter *api.TransferFilter) ([]*api.FilteredTransfer, error) {
+	chain := t.repo.NewBestChain()
+	rng, err := api.ConvertRange(chain, filter.Range)
 	if err != nil {
 		return nil, err
 	}
 
-	transfers, err := t.db.FilterTransfers(ctx, &logdb.TransferFilter{
+	tf := &logdb.TransferFilter{
 		CriteriaSet: filter.CriteriaSet,
 		Range:       rng,
 		Options: &logdb.Options{
@@ -48,8 +56,33 @@ func (t *Transfers) filter(ctx context.Context, filter *api.TransferFilter) ([]*
 			Limit:  filter.Options.Limit,
 		},
 		Order: filter.Order,
-	})
+	}
+
+	head := block.Number(chain.HeadID())
+	downgraded, err := t.budget.Apply(tf, head)
 	if err != nil {
+		return nil, utils.Forbidden(err)
+	}
+	if downgraded {
+		w.Header().Set(api.LogsDowngradedRangeHeader, fmt.Sprintf("%d-%d", tf.Range.From, tf.Range.To))
+	}
+	// logs of finalized blocks never change, unless the range was narrowed depending on the head
+	if !downgraded && tf.Range != nil && utils.IsFinalized(tf.Range.To, t.bft) {
+		utils.SetImmutable(w)
+	}
+
+	if t.budget.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.budget.Timeout)
+		defer cancel()
+	}
+	start := time.Now()
+	transfers, err := t.db.FilterTransfers(ctx, tf)
+	t.budget.LogIfSlow(tf, head, time.Since(start))
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, utils.HTTPError(errors.New("query timeout, please narrow the range or criteria"), http.StatusServiceUnavailable)
+		}
 		return nil, err
 	}
 	tLogs := make([]*api.FilteredTransfer, len(transfers))
@@ -89,7 +122,7 @@ func (t *Transfers) handleFilterTransferLogs(w http.ResponseWriter, req *http.Re
 		}
 	}
 
-	tLogs, err := t.filter(req.Context(), &filter)
+	tLogs, err := t.filter(req.Context(), w, &filter)
 	if err != nil {
 		return err
 	}
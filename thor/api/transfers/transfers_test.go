@@ -242,12 +242,32 @@ func insertBlocks(t *testing.T, db *logdb.LogDB, n int) {
 	}
 }
 
+func TestQueryBudget(t *testing.T) {
+	db := createDb(t)
+	initTransferServerWithBudget(t, db, defaultLogLimit, logdb.QueryBudget{MaxCost: 3})
+	defer ts.Close()
+
+	tclient = thorclient.New(ts.URL)
+	res, statusCode, err := tclient.RawHTTPClient().RawHTTPPost("/logs/transfer", []byte(`{"criteriaSet": [{}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode, string(res))
+
+	res, statusCode, err = tclient.RawHTTPClient().RawHTTPPost("/logs/transfer", []byte(`{"criteriaSet": [{}, {}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, statusCode)
+	assert.Equal(t, "query cost 5 exceeds the maximum allowed value of 3, please narrow the range or criteria\n", string(res))
+}
+
 func initTransferServer(t *testing.T, logDb *logdb.LogDB, limit uint64) {
+	initTransferServerWithBudget(t, logDb, limit, logdb.QueryBudget{})
+}
+
+func initTransferServerWithBudget(t *testing.T, logDb *logdb.LogDB, limit uint64, budget logdb.QueryBudget) {
 	thorChain, err := testchain.NewDefault()
 	require.NoError(t, err)
 
 	router := mux.NewRouter()
-	New(thorChain.Repo(), logDb, limit).Mount(router, "/logs/transfer")
+	New(thorChain.Repo(), logDb, limit, budget, thorChain.Engine()).Mount(router, "/logs/transfer")
 
 	ts = httptest.NewServer(router)
 }
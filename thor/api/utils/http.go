@@ -122,6 +122,27 @@ func WriteJSON(w http.ResponseWriter, obj any) error {
 	return nil
 }
 
+// cache control values
+const (
+	immutableCacheControl = "public, max-age=31536000, immutable"
+	shortCacheControl     = "public, max-age=1"
+)
+
+// SetImmutable marks the response as never changing, e.g. data of finalized blocks.
+func SetImmutable(w http.ResponseWriter) {
+	w.Header().Set("Cache-Control", immutableCacheControl)
+}
+
+// SetShortMaxAge marks the response as cacheable for a short time, e.g. data of the best block.
+func SetShortMaxAge(w http.ResponseWriter) {
+	w.Header().Set("Cache-Control", shortCacheControl)
+}
+
+// IsImmutable returns whether the response headers mark it as immutable.
+func IsImmutable(header http.Header) bool {
+	return header.Get("Cache-Control") == immutableCacheControl
+}
+
 // HandleGone is a handler for deprecated endpoints that returns HTTP 410 Gone.
 func HandleGone(w http.ResponseWriter, _ *http.Request) error {
 	w.WriteHeader(http.StatusGone)
@@ -34,6 +34,21 @@ func (rev *Revision) IsNext() bool {
 	return rev.val == revNext
 }
 
+// IsBest returns whether the revision is the best block.
+func (rev *Revision) IsBest() bool {
+	return rev.val == revBest
+}
+
+// IsFixed returns whether the revision is a block number or ID, as opposed to a
+// moving one such as "best" or "finalized".
+func (rev *Revision) IsFixed() bool {
+	switch rev.val.(type) {
+	case uint32, thor.Bytes32:
+		return true
+	}
+	return false
+}
+
 // ParseRevision parses a query parameter into a block number or block ID.
 func ParseRevision(revision string, allowNext bool) (*Revision, error) {
 	if revision == "" || revision == "best" {
@@ -107,6 +122,12 @@ func GetSummary(rev *Revision, repo *chain.Repository, bft bft.Committer) (sum *
 	return summary, nil
 }
 
+// IsFinalized returns whether the block with the given number is at or below the finalized block.
+// The block is expected to be on the best chain.
+func IsFinalized(num uint32, bft bft.Committer) bool {
+	return num <= block.Number(bft.Finalized())
+}
+
 // GetSummaryAndState returns the block summary and state for the given revision,
 // this function supports the "next" revision.
 func GetSummaryAndState(rev *Revision, repo *chain.Repository, bft bft.Committer, stater *state.Stater, forkConfig *thor.ForkConfig) (*chain.BlockSummary, *state.State, error) {
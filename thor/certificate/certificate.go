@@ -0,0 +1,166 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package certificate signs and verifies the certificates wallets sign to identify their users or to agree on a
+// text. A certificate is signed over the blake2b hash of its canonical JSON, the JSON of the certificate without the
+// signature, with the keys sorted, the signer in lower case and the strings escaped as by JSON.stringify.
+package certificate
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// maxSafeInteger is the max integer a JSON number holds without loss in JavaScript.
+const maxSafeInteger = 1<<53 - 1
+
+// Payload is the content of a certificate.
+type Payload struct {
+	Type    string `json:"type"`
+	Content string `json:"content"`
+}
+
+// Certificate is a statement of the signer, e.g. the purpose identification to log in a domain.
+type Certificate struct {
+	Purpose   string        `json:"purpose"`
+	Payload   Payload       `json:"payload"`
+	Domain    string        `json:"domain"`
+	Timestamp uint64        `json:"timestamp"`
+	Signer    thor.Address  `json:"signer"`
+	Signature hexutil.Bytes `json:"signature,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler, to encode the certificate alike as a value or a pointer.
+func (c Certificate) MarshalJSON() ([]byte, error) {
+	type certificate Certificate
+	return json.Marshal((*certificate)(&c))
+}
+
+// Encode returns the canonical JSON of the certificate, the signature excluded.
+func (c *Certificate) Encode() ([]byte, error) {
+	if c.Timestamp > maxSafeInteger {
+		return nil, errors.New("timestamp exceeds the max safe integer")
+	}
+	buf := make([]byte, 0, 128+len(c.Payload.Content))
+	buf = append(buf, `{"domain":`...)
+	buf = appendString(buf, c.Domain)
+	buf = append(buf, `,"payload":{"content":`...)
+	buf = appendString(buf, c.Payload.Content)
+	buf = append(buf, `,"type":`...)
+	buf = appendString(buf, c.Payload.Type)
+	buf = append(buf, `},"purpose":`...)
+	buf = appendString(buf, c.Purpose)
+	buf = append(buf, `,"signer":`...)
+	buf = appendString(buf, c.Signer.String())
+	buf = append(buf, `,"timestamp":`...)
+	buf = strconv.AppendUint(buf, c.Timestamp, 10)
+	buf = append(buf, '}')
+	return buf, nil
+}
+
+// SigningHash returns the hash of the canonical JSON, to be signed by the signer.
+func (c *Certificate) SigningHash() (thor.Bytes32, error) {
+	data, err := c.Encode()
+	if err != nil {
+		return thor.Bytes32{}, err
+	}
+	return thor.Blake2b(data), nil
+}
+
+// Sign sets the signer and signs the certificate with the key.
+func (c *Certificate) Sign(key *ecdsa.PrivateKey) error {
+	c.Signer = thor.Address(crypto.PubkeyToAddress(key.PublicKey))
+	hash, err := c.SigningHash()
+	if err != nil {
+		return err
+	}
+	sig, err := crypto.Sign(hash.Bytes(), key)
+	if err != nil {
+		return err
+	}
+	c.Signature = sig
+	return nil
+}
+
+// Recover returns the address of the key which signed the certificate, which may differ from the signer.
+func (c *Certificate) Recover() (thor.Address, error) {
+	if len(c.Signature) != 65 {
+		return thor.Address{}, errors.New("invalid signature length")
+	}
+	hash, err := c.SigningHash()
+	if err != nil {
+		return thor.Address{}, err
+	}
+	sig := make([]byte, 65)
+	copy(sig, c.Signature)
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+	pub, err := crypto.SigToPub(hash.Bytes(), sig)
+	if err != nil {
+		return thor.Address{}, errors.Wrap(err, "recover signature")
+	}
+	return thor.Address(crypto.PubkeyToAddress(*pub)), nil
+}
+
+// Verify checks the certificate is signed by the key of the signer.
+func (c *Certificate) Verify() error {
+	key, err := c.Recover()
+	if err != nil {
+		return err
+	}
+	if key != c.Signer {
+		return errors.Errorf("signature of %v, not the signer", key)
+	}
+	return nil
+}
+
+const hexDigits = "0123456789abcdef"
+
+// appendString appends the string quoted as by JSON.stringify, which escapes the quotation mark, the reverse solidus
+// and the control characters only.
+func appendString(buf []byte, s string) []byte {
+	buf = append(buf, '"')
+	for i := 0; i < len(s); {
+		b := s[i]
+		if b >= utf8.RuneSelf {
+			r, size := utf8.DecodeRuneInString(s[i:])
+			buf = utf8.AppendRune(buf, r)
+			i += size
+			continue
+		}
+		switch b {
+		case '"':
+			buf = append(buf, '\\', '"')
+		case '\\':
+			buf = append(buf, '\\', '\\')
+		case '\b':
+			buf = append(buf, '\\', 'b')
+		case '\f':
+			buf = append(buf, '\\', 'f')
+		case '\n':
+			buf = append(buf, '\\', 'n')
+		case '\r':
+			buf = append(buf, '\\', 'r')
+		case '\t':
+			buf = append(buf, '\\', 't')
+		default:
+			if b < 0x20 {
+				buf = append(buf, '\\', 'u', '0', '0', hexDigits[b>>4], hexDigits[b&0xf])
+			} else {
+				buf = append(buf, b)
+			}
+		}
+		i++
+	}
+	return append(buf, '"')
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package certificate
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/thor"
+)
+
+func TestEncode(t *testing.T) {
+	// the expected JSON is produced by JSON.stringify with the keys sorted
+	tests := []struct {
+		cert Certificate
+		want string
+	}{
+		{
+			Certificate{
+				Purpose:   "identification",
+				Payload:   Payload{Type: "text", Content: "fyi"},
+				Domain:    "localhost",
+				Timestamp: 1545035330,
+				Signer:    thor.MustParseAddress("0x7567d83b7b8d80addcb281a71d54fc7b3364ffed"),
+				Signature: []byte{1, 2, 3},
+			},
+			`{"domain":"localhost","payload":{"content":"fyi","type":"text"},"purpose":"identification",` +
+				`"signer":"0x7567d83b7b8d80addcb281a71d54fc7b3364ffed","timestamp":1545035330}`,
+		},
+		{
+			Certificate{
+				Purpose:   "agreement",
+				Payload:   Payload{Type: "text", Content: "Ünïcödé 中文 😀 <tag> & \"quote\" \\ back\n\ttab \x00\x1f\x7f "},
+				Domain:    "app.example.com:3000",
+				Timestamp: 1700000000,
+				Signer:    thor.MustParseAddress("0x7567D83B7B8D80ADDCB281A71D54FC7B3364FFED"),
+			},
+			`{"domain":"app.example.com:3000","payload":{"content":"Ünïcödé 中文 😀 <tag> & \"quote\" \\ back\n\ttab ` +
+				`\u0000\u001f` + "\x7f " + `","type":"text"},"purpose":"agreement",` +
+				`"signer":"0x7567d83b7b8d80addcb281a71d54fc7b3364ffed","timestamp":1700000000}`,
+		},
+	}
+	for _, tt := range tests {
+		data, err := tt.cert.Encode()
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, string(data))
+	}
+
+	_, err := (&Certificate{Timestamp: 1 << 53}).Encode()
+	assert.Error(t, err)
+}
+
+func TestSignVerify(t *testing.T) {
+	key, err := crypto.HexToECDSA("7582be841ca040aa940fff6c05773129e135623e41acce3e0b8ba520dc1ae26a")
+	require.NoError(t, err)
+
+	cert := Certificate{
+		Purpose:   "identification",
+		Payload:   Payload{Type: "text", Content: "fyi"},
+		Domain:    "localhost",
+		Timestamp: 1545035330,
+	}
+	require.NoError(t, cert.Sign(key))
+	assert.Equal(t, thor.Address(crypto.PubkeyToAddress(key.PublicKey)), cert.Signer)
+	assert.NoError(t, cert.Verify())
+
+	// round trip through JSON, keys in any order and the signer in upper case
+	data, err := json.Marshal(cert)
+	require.NoError(t, err)
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(data, &fields))
+	assert.Equal(t, cert.Signer.String(), fields["signer"])
+	fields["signer"] = "0x" + strings.ToUpper(cert.Signer.String()[2:])
+	data, err = json.Marshal(fields)
+	require.NoError(t, err)
+	var decoded Certificate
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.NoError(t, decoded.Verify())
+
+	// v in 27 and 28 is accepted
+	legacy := cert
+	legacy.Signature = append([]byte(nil), cert.Signature...)
+	legacy.Signature[64] += 27
+	assert.NoError(t, legacy.Verify())
+
+	tampered := cert
+	tampered.Payload.Content = "fyi!"
+	recovered, err := tampered.Recover()
+	require.NoError(t, err)
+	assert.NotEqual(t, cert.Signer, recovered)
+	assert.Error(t, tampered.Verify())
+
+	other := cert
+	other.Signer = thor.Address{1}
+	assert.Error(t, other.Verify())
+
+	short := cert
+	short.Signature = cert.Signature[:64]
+	assert.Error(t, short.Verify())
+}
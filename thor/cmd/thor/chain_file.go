@@ -0,0 +1,206 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/bft"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/cmd/thor/chainfile"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/logdb"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+	"gopkg.in/cheggaaa/pb.v1"
+	"gopkg.in/urfave/cli.v1"
+)
+
+func exportAction(ctx *cli.Context) error {
+	exitSignal := handleExitSignal()
+
+	outPath := ctx.String(exportOutFlag.Name)
+	if outPath == "" {
+		return fmt.Errorf("flag %s not specified", exportOutFlag.Name)
+	}
+	from, err := readBlockNumFlag(ctx, exportFromFlag.Name)
+	if err != nil {
+		return err
+	}
+	to, err := readBlockNumFlag(ctx, exportToFlag.Name)
+	if err != nil {
+		return err
+	}
+
+	gene, _, err := selectChainGenesis(ctx)
+	if err != nil {
+		return err
+	}
+	mainDB, logDB, repo, err := openChain(ctx, gene)
+	if err != nil {
+		return err
+	}
+	defer mainDB.Close()
+	defer logDB.Close()
+
+	if !ctx.IsSet(exportToFlag.Name) {
+		to = repo.BestBlockSummary().Header.Number()
+	}
+	if to < from {
+		return fmt.Errorf("invalid range [%v, %v]", from, to)
+	}
+
+	file, err := os.Create(outPath)
+	if err != nil {
+		return errors.Wrap(err, "create chain file")
+	}
+	defer file.Close()
+
+	var w io.Writer = file
+	if strings.HasSuffix(outPath, ".gz") {
+		gw := gzip.NewWriter(file)
+		defer gw.Close()
+		w = gw
+	}
+
+	fmt.Printf(">> Exporting blocks [%v, %v] <<\n", from, to)
+	bar := pb.New64(int64(to-from) + 1).SetMaxWidth(90).Start()
+	defer func() { bar.NotPrint = true }()
+
+	if err := chainfile.Export(exitSignal, repo, from, to, ctx.Bool(exportReceiptsFlag.Name), w, func(uint32) {
+		bar.Increment()
+	}); err != nil {
+		return err
+	}
+	if gw, ok := w.(*gzip.Writer); ok {
+		if err := gw.Close(); err != nil {
+			return err
+		}
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	bar.Finish()
+	return nil
+}
+
+func importAction(ctx *cli.Context) error {
+	exitSignal := handleExitSignal()
+
+	inPath := ctx.String(importInFlag.Name)
+	if inPath == "" {
+		return fmt.Errorf("flag %s not specified", importInFlag.Name)
+	}
+	file, err := os.Open(inPath)
+	if err != nil {
+		return errors.Wrap(err, "open chain file")
+	}
+	defer file.Close()
+
+	var r io.Reader = file
+	if strings.HasSuffix(inPath, ".gz") {
+		gr, err := gzip.NewReader(file)
+		if err != nil {
+			return errors.Wrap(err, "open chain file")
+		}
+		defer gr.Close()
+		r = gr
+	}
+	fr, err := chainfile.NewReader(r)
+	if err != nil {
+		return errors.Wrap(err, "read chain file")
+	}
+
+	gene, forkConfig, err := selectChainGenesis(ctx)
+	if err != nil {
+		return err
+	}
+	mainDB, logDB, repo, err := openChain(ctx, gene)
+	if err != nil {
+		return err
+	}
+	defer mainDB.Close()
+	defer logDB.Close()
+
+	solo := ctx.Bool(soloChainFlag.Name)
+	var bftEngine *bft.Engine
+	if !solo {
+		if bftEngine, err = bft.NewEngine(repo, mainDB, forkConfig, thor.Address{}); err != nil {
+			return errors.Wrap(err, "init bft engine")
+		}
+	}
+
+	importer := chainfile.NewImporter(repo, state.NewStater(mainDB), bftEngine, logDB, forkConfig, chainfile.Options{
+		SkipLogs: ctx.Bool(skipLogsFlag.Name),
+		Solo:     solo,
+	})
+
+	fmt.Printf(">> Importing blocks [%v, %v] <<\n", fr.From, fr.To)
+	bar := pb.New64(int64(fr.To-fr.From) + 1).SetMaxWidth(90).Start()
+	defer func() { bar.NotPrint = true }()
+
+	n, err := importer.Import(exitSignal, fr, func(uint32) {
+		bar.Increment()
+	})
+	if err != nil {
+		return err
+	}
+	bar.Finish()
+
+	best := repo.BestBlockSummary().Header
+	fmt.Printf("Imported %v blocks, best block %v %v\n", n, best.Number(), best.ID())
+	return nil
+}
+
+// selectChainGenesis selects the genesis of a network, or the one of solo mode if the solo flag is set.
+func selectChainGenesis(ctx *cli.Context) (*genesis.Genesis, *thor.ForkConfig, error) {
+	if !ctx.Bool(soloChainFlag.Name) {
+		return selectGenesis(ctx)
+	}
+	if flagGenesis := ctx.String(genesisFlag.Name); flagGenesis != "" {
+		return parseGenesisFile(flagGenesis)
+	}
+	return genesis.NewDevnet(), &thor.SoloFork, nil
+}
+
+// openChain opens the databases of the instance of the given genesis.
+func openChain(ctx *cli.Context, gene *genesis.Genesis) (*muxdb.MuxDB, *logdb.LogDB, *chain.Repository, error) {
+	instanceDir, err := makeInstanceDir(ctx, gene)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	mainDB, err := openMainDB(ctx, instanceDir)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	logDB, err := openLogDB(instanceDir)
+	if err != nil {
+		mainDB.Close()
+		return nil, nil, nil, err
+	}
+	repo, err := initChainRepository(gene, mainDB, logDB)
+	if err != nil {
+		logDB.Close()
+		mainDB.Close()
+		return nil, nil, nil, err
+	}
+	return mainDB, logDB, repo, nil
+}
+
+func readBlockNumFlag(ctx *cli.Context, name string) (uint32, error) {
+	val := ctx.Uint64(name)
+	if val > math.MaxUint32 {
+		return 0, fmt.Errorf("flag %s out of range", name)
+	}
+	return uint32(val), nil
+}
@@ -0,0 +1,197 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package chainfile
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/bft"
+	"github.com/vechain/thor/v2/consensus"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/logdb"
+	"github.com/vechain/thor/v2/packer"
+	"github.com/vechain/thor/v2/test/testchain"
+	"github.com/vechain/thor/v2/tx"
+)
+
+const launchTime = 1_700_000_000
+
+func newChain(t *testing.T) *testchain.Chain {
+	chain, err := testchain.NewIntegrationTestChain(genesis.DevConfig{
+		ForkConfig: &testchain.DefaultForkConfig,
+		LaunchTime: launchTime,
+	})
+	require.NoError(t, err)
+	return chain
+}
+
+func newSourceChain(t *testing.T, blocks int) *testchain.Chain {
+	chain := newChain(t)
+	accounts := genesis.DevAccounts()
+	for i := range blocks {
+		to := accounts[(i+1)%len(accounts)].Address
+		require.NoError(t, chain.MintClauses(accounts[0], []*tx.Clause{
+			tx.NewClause(&to).WithValue(big.NewInt(int64(i + 1))),
+		}))
+	}
+	return chain
+}
+
+func exportChain(t *testing.T, chain *testchain.Chain, from, to uint32, withReceipts bool) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, Export(context.Background(), chain.Repo(), from, to, withReceipts, &buf, nil))
+	return buf.Bytes()
+}
+
+func transfers(t *testing.T, db *logdb.LogDB) []*logdb.Transfer {
+	res, err := db.FilterTransfers(context.Background(), &logdb.TransferFilter{})
+	require.NoError(t, err)
+	return res
+}
+
+func TestFormat(t *testing.T) {
+	chain := newSourceChain(t, 3)
+	data := exportChain(t, chain, 1, 3, true)
+
+	r, err := NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, Header{GenesisID: chain.GenesisBlock().Header().ID(), From: 1, To: 3, Receipts: true}, r.Header)
+
+	bestChain := chain.Repo().NewBestChain()
+	for num := uint32(1); num <= 3; num++ {
+		blk, receipts, err := r.Read()
+		require.NoError(t, err)
+		id, err := bestChain.GetBlockID(num)
+		require.NoError(t, err)
+		assert.Equal(t, id, blk.Header().ID())
+		assert.Len(t, receipts, 1)
+	}
+	_, _, err = r.Read()
+	assert.Equal(t, io.EOF, err)
+
+	// truncated files are detected
+	r, err = NewReader(bytes.NewReader(data[:len(data)-1]))
+	require.NoError(t, err)
+	var readErr error
+	for readErr == nil {
+		_, _, readErr = r.Read()
+	}
+	assert.ErrorIs(t, readErr, io.ErrUnexpectedEOF)
+
+	_, err = NewReader(bytes.NewReader([]byte("not a chain file, surely not a chain file at all")))
+	assert.Error(t, err)
+}
+
+func TestImport(t *testing.T) {
+	src := newSourceChain(t, 5)
+	data := exportChain(t, src, 1, 5, true)
+
+	dst := newChain(t)
+	engine, err := bft.NewEngine(dst.Repo(), dst.Database(), dst.GetForkConfig(), genesis.DevAccounts()[0].Address)
+	require.NoError(t, err)
+	importer := NewImporter(dst.Repo(), dst.Stater(), engine, dst.LogDB(), dst.GetForkConfig(), Options{})
+
+	r, err := NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	n, err := importer.Import(context.Background(), r, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 5, n)
+	assert.Equal(t, src.Repo().BestBlockSummary().Header.ID(), dst.Repo().BestBlockSummary().Header.ID())
+	assert.Equal(t, transfers(t, src.LogDB()), transfers(t, dst.LogDB()))
+
+	// known blocks are skipped
+	r, err = NewReader(bytes.NewReader(exportChain(t, src, 3, 5, false)))
+	require.NoError(t, err)
+	n, err = importer.Import(context.Background(), r, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	// blocks of another chain are rejected
+	other, err := testchain.NewDefault()
+	require.NoError(t, err)
+	require.NoError(t, other.MintBlock(genesis.DevAccounts()[0]))
+	r, err = NewReader(bytes.NewReader(exportChain(t, other, 1, 1, false)))
+	require.NoError(t, err)
+	_, err = importer.Import(context.Background(), r, nil)
+	assert.ErrorContains(t, err, "genesis mismatch")
+}
+
+func TestImportLogsFailure(t *testing.T) {
+	src := newSourceChain(t, 2)
+	data := exportChain(t, src, 1, 2, true)
+
+	dst := newChain(t)
+	engine, err := bft.NewEngine(dst.Repo(), dst.Database(), dst.GetForkConfig(), genesis.DevAccounts()[0].Address)
+	require.NoError(t, err)
+	logDB, err := logdb.NewMem()
+	require.NoError(t, err)
+	require.NoError(t, logDB.Close())
+
+	// a block whose logs fail to be written is not added, to be imported again
+	r, err := NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	_, err = NewImporter(dst.Repo(), dst.Stater(), engine, logDB, dst.GetForkConfig(), Options{}).Import(context.Background(), r, nil)
+	assert.ErrorContains(t, err, "write logs")
+	assert.Equal(t, uint32(0), dst.Repo().BestBlockSummary().Header.Number())
+
+	r, err = NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	n, err := NewImporter(dst.Repo(), dst.Stater(), engine, dst.LogDB(), dst.GetForkConfig(), Options{}).Import(context.Background(), r, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, transfers(t, src.LogDB()), transfers(t, dst.LogDB()))
+}
+
+func TestImportSolo(t *testing.T) {
+	// pack blocks the way solo does, at any time
+	src := newChain(t)
+	master := genesis.DevAccounts()[0]
+	to := genesis.DevAccounts()[1].Address
+	p := packer.New(src.Repo(), src.Stater(), master.Address, &master.Address, src.GetForkConfig(), 0)
+	for i := range 3 {
+		best := src.Repo().BestBlockSummary()
+		flow, err := p.Mock(best, best.Header.Timestamp()+uint64(i+1), best.Header.GasLimit())
+		require.NoError(t, err)
+		trx := tx.NewBuilder(tx.TypeLegacy).
+			ChainTag(src.Repo().ChainTag()).
+			Clause(tx.NewClause(&to).WithValue(big.NewInt(1))).
+			Gas(21000).
+			Expiration(100).
+			Nonce(uint64(i)).
+			Build()
+		require.NoError(t, flow.Adopt(tx.MustSign(trx, master.PrivateKey)))
+		blk, stage, receipts, err := flow.Pack(master.PrivateKey, 0, false)
+		require.NoError(t, err)
+		_, err = stage.Commit()
+		require.NoError(t, err)
+		require.NoError(t, src.Repo().AddBlock(blk, receipts, 0, true))
+	}
+	data := exportChain(t, src, 1, 3, false)
+
+	dst := newChain(t)
+	r, err := NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	engine, err := bft.NewEngine(dst.Repo(), dst.Database(), dst.GetForkConfig(), master.Address)
+	require.NoError(t, err)
+	_, err = NewImporter(dst.Repo(), dst.Stater(), engine, dst.LogDB(), dst.GetForkConfig(), Options{}).Import(context.Background(), r, nil)
+	assert.True(t, consensus.IsCritical(errors.Cause(err)))
+
+	importer := NewImporter(dst.Repo(), dst.Stater(), nil, dst.LogDB(), dst.GetForkConfig(), Options{Solo: true})
+	r, err = NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	n, err := importer.Import(context.Background(), r, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, src.Repo().BestBlockSummary().Header.ID(), dst.Repo().BestBlockSummary().Header.ID())
+	assert.Len(t, transfers(t, dst.LogDB()), 3)
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package chainfile
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/tx"
+)
+
+// Export writes the blocks of the best chain from 'from' to 'to' to w, along with their receipts if withReceipts
+// is set. The progress callback, if not nil, is called after each written block.
+func Export(ctx context.Context, repo *chain.Repository, from, to uint32, withReceipts bool, w io.Writer, progress func(num uint32)) error {
+	best := repo.BestBlockSummary().Header.Number()
+	if to > best {
+		return fmt.Errorf("block %v beyond the best block %v", to, best)
+	}
+
+	fw, err := NewWriter(w, Header{
+		GenesisID: repo.GenesisBlock().Header().ID(),
+		From:      from,
+		To:        to,
+		Receipts:  withReceipts,
+	})
+	if err != nil {
+		return err
+	}
+
+	bestChain := repo.NewBestChain()
+	for num := from; ; num++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		id, err := bestChain.GetBlockID(num)
+		if err != nil {
+			return errors.Wrapf(err, "get block id %v", num)
+		}
+		blk, err := repo.GetBlock(id)
+		if err != nil {
+			return errors.Wrapf(err, "get block %v", num)
+		}
+		var receipts tx.Receipts
+		if withReceipts {
+			if receipts, err = repo.GetBlockReceipts(id); err != nil {
+				return errors.Wrapf(err, "get receipts %v", num)
+			}
+		}
+		if err := fw.Write(blk, receipts); err != nil {
+			return errors.Wrapf(err, "write block %v", num)
+		}
+		if progress != nil {
+			progress(num)
+		}
+		if num == to {
+			break
+		}
+	}
+	return fw.Flush()
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package chainfile exports blocks of a chain to a file, and imports them back into a node.
+//
+// A chain file starts with a header:
+//
+//	magic "THORBLKS" | version (1 byte) | flags (1 byte) | genesis ID (32 bytes) | from (4 bytes) | to (4 bytes)
+//
+// followed by the blocks from 'from' to 'to', each as a uvarint length-prefixed RLP encoded block. If the
+// receipts flag is set, each block is followed by its uvarint length-prefixed RLP encoded receipts.
+package chainfile
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+const (
+	// Version is the version of the file format.
+	Version = 1
+
+	magic        = "THORBLKS"
+	flagReceipts = 1 << 0
+
+	headerSize = len(magic) + 1 + 1 + 32 + 4 + 4
+
+	// maxRecordSize limits the size of a record, to reject corrupted files early.
+	maxRecordSize = 64 * 1024 * 1024
+)
+
+// Header is the header of a chain file.
+type Header struct {
+	GenesisID thor.Bytes32
+	From      uint32 // number of the first block
+	To        uint32 // number of the last block
+	Receipts  bool   // whether receipts follow each block
+}
+
+// Writer writes blocks to a chain file.
+type Writer struct {
+	w      *bufio.Writer
+	header Header
+	next   uint32
+	buf    bytes.Buffer
+	lenBuf [binary.MaxVarintLen64]byte
+}
+
+// NewWriter writes the header, and returns a writer expecting the blocks in the header range.
+func NewWriter(w io.Writer, header Header) (*Writer, error) {
+	if header.To < header.From {
+		return nil, fmt.Errorf("invalid range [%v, %v]", header.From, header.To)
+	}
+	bw := bufio.NewWriterSize(w, 1024*1024)
+
+	var buf [headerSize]byte
+	n := copy(buf[:], magic)
+	buf[n] = Version
+	if header.Receipts {
+		buf[n+1] |= flagReceipts
+	}
+	n += 2
+	n += copy(buf[n:], header.GenesisID[:])
+	binary.BigEndian.PutUint32(buf[n:], header.From)
+	binary.BigEndian.PutUint32(buf[n+4:], header.To)
+	if _, err := bw.Write(buf[:]); err != nil {
+		return nil, err
+	}
+	return &Writer{w: bw, header: header, next: header.From}, nil
+}
+
+// Write writes the next block, along with its receipts if the file includes receipts.
+func (w *Writer) Write(blk *block.Block, receipts tx.Receipts) error {
+	if num := blk.Header().Number(); num != w.next || num > w.header.To {
+		return fmt.Errorf("unexpected block %v, want %v", num, w.next)
+	}
+	if err := w.writeRecord(blk); err != nil {
+		return err
+	}
+	if w.header.Receipts {
+		if err := w.writeRecord(receipts); err != nil {
+			return err
+		}
+	}
+	w.next++
+	return nil
+}
+
+// Flush writes any buffered data, and checks that all blocks in the header range are written.
+func (w *Writer) Flush() error {
+	if err := w.w.Flush(); err != nil {
+		return err
+	}
+	if w.next != w.header.To+1 {
+		return fmt.Errorf("incomplete file: %v blocks missing", w.header.To+1-w.next)
+	}
+	return nil
+}
+
+func (w *Writer) writeRecord(val any) error {
+	w.buf.Reset()
+	if err := rlp.Encode(&w.buf, val); err != nil {
+		return err
+	}
+	n := binary.PutUvarint(w.lenBuf[:], uint64(w.buf.Len()))
+	if _, err := w.w.Write(w.lenBuf[:n]); err != nil {
+		return err
+	}
+	_, err := w.w.Write(w.buf.Bytes())
+	return err
+}
+
+// Reader reads blocks from a chain file.
+type Reader struct {
+	Header
+
+	r    *bufio.Reader
+	next uint32
+	done bool
+	buf  []byte
+}
+
+// NewReader reads the header, and returns a reader of the blocks.
+func NewReader(r io.Reader) (*Reader, error) {
+	br := bufio.NewReaderSize(r, 1024*1024)
+
+	var buf [headerSize]byte
+	if _, err := io.ReadFull(br, buf[:]); err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+	if string(buf[:len(magic)]) != magic {
+		return nil, errors.New("not a chain file")
+	}
+	n := len(magic)
+	if buf[n] != Version {
+		return nil, fmt.Errorf("unsupported version %v", buf[n])
+	}
+	var header Header
+	header.Receipts = buf[n+1]&flagReceipts != 0
+	n += 2
+	n += copy(header.GenesisID[:], buf[n:])
+	header.From = binary.BigEndian.Uint32(buf[n:])
+	header.To = binary.BigEndian.Uint32(buf[n+4:])
+	if header.To < header.From {
+		return nil, fmt.Errorf("invalid range [%v, %v]", header.From, header.To)
+	}
+	return &Reader{Header: header, r: br, next: header.From}, nil
+}
+
+// Read reads the next block, and its receipts if the file includes them.
+// It returns io.EOF once all blocks are read.
+func (r *Reader) Read() (*block.Block, tx.Receipts, error) {
+	if r.done {
+		return nil, nil, io.EOF
+	}
+	var blk block.Block
+	if err := r.readRecord(&blk); err != nil {
+		return nil, nil, err
+	}
+	if num := blk.Header().Number(); num != r.next {
+		return nil, nil, fmt.Errorf("unexpected block %v, want %v", num, r.next)
+	}
+	var receipts tx.Receipts
+	if r.Receipts {
+		if err := r.readRecord(&receipts); err != nil {
+			return nil, nil, err
+		}
+	}
+	r.done = r.next == r.To
+	r.next++
+	return &blk, receipts, nil
+}
+
+// readRecord reads a record, the end of the file being unexpected as the header tells the number of blocks.
+func (r *Reader) readRecord(val any) error {
+	size, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return fmt.Errorf("block %v: %w", r.next, err)
+	}
+	if size > maxRecordSize {
+		return fmt.Errorf("block %v: record too large: %v", r.next, size)
+	}
+	if uint64(cap(r.buf)) < size {
+		r.buf = make([]byte, size)
+	}
+	r.buf = r.buf[:size]
+	if _, err := io.ReadFull(r.r, r.buf); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return fmt.Errorf("block %v: %w", r.next, err)
+	}
+	if err := rlp.DecodeBytes(r.buf, val); err != nil {
+		return fmt.Errorf("block %v: %w", r.next, err)
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package chainfile
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/bft"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/consensus"
+	"github.com/vechain/thor/v2/logdb"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+// Options configures the import of blocks.
+type Options struct {
+	SkipLogs bool // skip writing event and transfer logs
+	Solo     bool // the blocks were packed in solo mode, which skips the schedule checks and the bft engine
+}
+
+// Importer processes the blocks of chain files the same way the node processes blocks received from peers.
+type Importer struct {
+	repo       *chain.Repository
+	cons       *consensus.Consensus
+	bft        *bft.Engine
+	logDB      *logdb.LogDB
+	forkConfig *thor.ForkConfig
+	options    Options
+}
+
+// NewImporter creates an importer. The bft engine is not used in solo mode, and can be nil.
+func NewImporter(
+	repo *chain.Repository,
+	stater *state.Stater,
+	bft *bft.Engine,
+	logDB *logdb.LogDB,
+	forkConfig *thor.ForkConfig,
+	options Options,
+) *Importer {
+	return &Importer{
+		repo:       repo,
+		cons:       consensus.New(repo, stater, forkConfig),
+		bft:        bft,
+		logDB:      logDB,
+		forkConfig: forkConfig,
+		options:    options,
+	}
+}
+
+// Import processes the blocks read from r, skipping the known ones. It returns the number of imported blocks.
+// The progress callback, if not nil, is called after each read block.
+func (im *Importer) Import(ctx context.Context, r *Reader, progress func(num uint32)) (n int, err error) {
+	if genesisID := im.repo.GenesisBlock().Header().ID(); r.GenesisID != genesisID {
+		return 0, fmt.Errorf("genesis mismatch: file %v, chain %v", r.GenesisID, genesisID)
+	}
+
+	var w *logdb.Writer
+	if !im.options.SkipLogs {
+		w = im.logDB.NewWriterSyncOff()
+		defer func() {
+			// the logs of the imported blocks are kept on failures, the ones of a block failing to be
+			// added are written again when it's reimported
+			if e := w.Commit(); e != nil && err == nil {
+				err = errors.Wrap(e, "commit logs")
+			}
+		}()
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return n, err
+		}
+		blk, receipts, err := r.Read()
+		if err != nil {
+			if err == io.EOF {
+				return n, nil
+			}
+			return n, err
+		}
+		imported, err := im.importBlock(blk, receipts, w)
+		if err != nil {
+			return n, errors.Wrapf(err, "import block %v", blk.Header().ID())
+		}
+		if imported {
+			n++
+		}
+		if w != nil && w.UncommittedCount() > 2048 {
+			if err := w.Commit(); err != nil {
+				return n, errors.Wrap(err, "commit logs")
+			}
+		}
+		if progress != nil {
+			progress(blk.Header().Number())
+		}
+	}
+}
+
+// importBlock processes a block, and returns false if the block is already known.
+func (im *Importer) importBlock(blk *block.Block, fileReceipts tx.Receipts, w *logdb.Writer) (bool, error) {
+	header := blk.Header()
+	if _, err := im.repo.GetBlockSummary(header.ID()); err == nil {
+		return false, nil
+	} else if !im.repo.IsNotFound(err) {
+		return false, err
+	}
+
+	parentSummary, err := im.repo.GetBlockSummary(header.ParentID())
+	if err != nil {
+		if im.repo.IsNotFound(err) {
+			return false, errors.New("parent block is missing")
+		}
+		return false, err
+	}
+	conflicts, err := im.repo.ScanConflicts(header.Number())
+	if err != nil {
+		return false, err
+	}
+	oldBest := im.repo.BestBlockSummary()
+
+	var (
+		stage    *state.Stage
+		receipts tx.Receipts
+	)
+	if im.options.Solo {
+		stage, receipts, err = im.cons.ProcessSolo(parentSummary, blk, conflicts)
+	} else {
+		if ok, err := im.bft.Accepts(header.ParentID()); err != nil {
+			return false, errors.Wrap(err, "bft accepts")
+		} else if !ok {
+			return false, errors.New("block rejected by bft engine")
+		}
+		stage, receipts, err = im.cons.Process(parentSummary, blk, uint64(time.Now().Unix()), conflicts)
+	}
+	if err != nil {
+		return false, err
+	}
+	if fileReceipts != nil && fileReceipts.RootHash() != receipts.RootHash() {
+		return false, errors.New("receipts mismatch")
+	}
+
+	var becomeNewBest bool
+	// let bft engine decide the best block after fork FINALITY
+	if !im.options.Solo && header.Number() >= im.forkConfig.FINALITY && oldBest.Header.Number() >= im.forkConfig.FINALITY {
+		if becomeNewBest, err = im.bft.Select(header); err != nil {
+			return false, errors.Wrap(err, "bft select")
+		}
+	} else {
+		becomeNewBest = header.BetterThan(oldBest.Header)
+	}
+
+	// logs are written before the block is added, as a known block is skipped on reimport
+	if w != nil && becomeNewBest {
+		if err := im.writeLogs(w, blk, receipts, oldBest.Header.ID()); err != nil {
+			return false, errors.Wrap(err, "write logs")
+		}
+	}
+
+	if _, err := stage.Commit(); err != nil {
+		return false, errors.Wrap(err, "commit state")
+	}
+	if err := im.repo.AddBlock(blk, receipts, conflicts, becomeNewBest); err != nil {
+		return false, errors.Wrap(err, "add block")
+	}
+	if !im.options.Solo && header.Number() >= im.forkConfig.FINALITY {
+		if err := im.bft.CommitBlock(header, false); err != nil {
+			return false, errors.Wrap(err, "bft commits")
+		}
+	}
+	return true, nil
+}
+
+// writeLogs moves the logs from the old best chain to the new one.
+func (im *Importer) writeLogs(w *logdb.Writer, newBlock *block.Block, newReceipts tx.Receipts, oldBestBlockID thor.Bytes32) error {
+	oldTrunk := im.repo.NewChain(oldBestBlockID)
+	newTrunk := im.repo.NewChain(newBlock.Header().ParentID())
+
+	oldBranch, err := oldTrunk.Exclude(newTrunk)
+	if err != nil {
+		return err
+	}
+	// to clear logs on the old branch.
+	if len(oldBranch) > 0 {
+		if err := w.Truncate(block.Number(oldBranch[0])); err != nil {
+			return err
+		}
+	}
+
+	newBranch, err := newTrunk.Exclude(oldTrunk)
+	if err != nil {
+		return err
+	}
+	// write logs on the new branch.
+	for _, id := range newBranch {
+		blk, err := im.repo.GetBlock(id)
+		if err != nil {
+			return err
+		}
+		receipts, err := im.repo.GetBlockReceipts(id)
+		if err != nil {
+			return err
+		}
+		if err := w.Write(blk, receipts); err != nil {
+			return err
+		}
+	}
+	return w.Write(newBlock, newReceipts)
+}
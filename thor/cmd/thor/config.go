@@ -0,0 +1,302 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/thor"
+	"gopkg.in/urfave/cli.v1"
+	"gopkg.in/yaml.v3"
+)
+
+// envVarName returns the environment variable of a flag, e.g. THOR_API_ADDR for api-addr.
+func envVarName(flag string) string {
+	return "THOR_" + strings.ToUpper(strings.ReplaceAll(flag, "-", "_"))
+}
+
+// withEnvVars returns a copy of the flags, which can be set by their environment variables as well.
+func withEnvVars(flags []cli.Flag) []cli.Flag {
+	out := make([]cli.Flag, 0, len(flags))
+	for _, f := range flags {
+		env := envVarName(f.GetName())
+		switch f := f.(type) {
+		case cli.StringFlag:
+			f.EnvVar = env
+			out = append(out, f)
+		case cli.BoolFlag:
+			f.EnvVar = env
+			out = append(out, f)
+		case cli.Uint64Flag:
+			f.EnvVar = env
+			out = append(out, f)
+		case cli.IntFlag:
+			f.EnvVar = env
+			out = append(out, f)
+		case cli.StringSliceFlag:
+			f.EnvVar = env
+			out = append(out, f)
+		default:
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// loadConfigFile applies the config file set by the config flag to the flags not set on the command line or by
+// environment variables. The keys of the file must be flags of the mode, the other mode is named in the errors
+// about its flags.
+func loadConfigFile(ctx *cli.Context, flags []cli.Flag, otherMode string, otherFlags []cli.Flag) error {
+	path := ctx.String(configFileFlag.Name)
+	if path == "" {
+		return nil
+	}
+	values, err := readConfigFile(path)
+	if err != nil {
+		return err
+	}
+
+	var (
+		known = flagsByName(flags)
+		other = flagsByName(otherFlags)
+		keys  = make([]string, 0, len(values))
+		errs  []error
+	)
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if key == configFileFlag.Name {
+			errs = append(errs, errors.Errorf("%v: not allowed in the config file", key))
+			continue
+		}
+		flag, ok := known[key]
+		if !ok {
+			if _, ok := other[key]; ok {
+				errs = append(errs, errors.Errorf("%v: only for %v", key, otherMode))
+			} else {
+				errs = append(errs, errors.Errorf("%v: unknown option", key))
+			}
+			continue
+		}
+		strs, err := configValueStrings(values[key])
+		if err != nil {
+			errs = append(errs, errors.Errorf("%v: %v", key, err))
+			continue
+		}
+		// the command line and environment variables take precedence over the file
+		if ctx.IsSet(key) {
+			continue
+		}
+		if _, ok := flag.(cli.StringSliceFlag); !ok {
+			strs = []string{strings.Join(strs, ",")}
+		}
+		for _, str := range strs {
+			if err := ctx.Set(key, str); err != nil {
+				errs = append(errs, errors.Errorf("%v: %v", key, err))
+				break
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Wrapf(joinErrors(errs), "config file %v", path)
+	}
+	return nil
+}
+
+// readConfigFile reads a TOML or YAML file, according to its extension.
+func readConfigFile(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "read config file")
+	}
+
+	values := make(map[string]any)
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".toml":
+		if _, err := toml.Decode(string(data), &values); err != nil {
+			return nil, errors.Wrap(err, "decode config file")
+		}
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &values); err != nil {
+			return nil, errors.Wrap(err, "decode config file")
+		}
+	default:
+		return nil, errors.Errorf("unsupported config file extension %q, want .toml, .yaml or .yml", ext)
+	}
+	return values, nil
+}
+
+// configValueStrings converts a value of the config file to the strings of the flag values. Lists are converted
+// element-wise.
+func configValueStrings(v any) ([]string, error) {
+	switch v := v.(type) {
+	case string:
+		return []string{v}, nil
+	case bool:
+		return []string{strconv.FormatBool(v)}, nil
+	case int:
+		return []string{strconv.Itoa(v)}, nil
+	case int64:
+		return []string{strconv.FormatInt(v, 10)}, nil
+	case uint64:
+		return []string{strconv.FormatUint(v, 10)}, nil
+	case float64:
+		if v != math.Trunc(v) {
+			return nil, errors.Errorf("%v is not an integer", v)
+		}
+		return []string{strconv.FormatFloat(v, 'f', -1, 64)}, nil
+	case []any:
+		strs := make([]string, 0, len(v))
+		for _, elem := range v {
+			if _, ok := elem.([]any); ok {
+				return nil, errors.New("nested lists are not supported")
+			}
+			s, err := configValueStrings(elem)
+			if err != nil {
+				return nil, err
+			}
+			strs = append(strs, s...)
+		}
+		return strs, nil
+	case nil:
+		return nil, errors.New("empty value")
+	default:
+		return nil, errors.Errorf("unsupported value of type %T", v)
+	}
+}
+
+func flagsByName(flags []cli.Flag) map[string]cli.Flag {
+	m := make(map[string]cli.Flag, len(flags))
+	for _, f := range flags {
+		m[f.GetName()] = f
+	}
+	return m
+}
+
+// mergeFlags returns the flags of both lists, the ones of the same name are kept once.
+func mergeFlags(a, b []cli.Flag) []cli.Flag {
+	names := flagsByName(a)
+	merged := append([]cli.Flag(nil), a...)
+	for _, f := range b {
+		if _, ok := names[f.GetName()]; !ok {
+			merged = append(merged, f)
+		}
+	}
+	return merged
+}
+
+// validateSoloFlags checks the options of solo mode. The options of the node are rejected by loadConfigFile.
+func validateSoloFlags(ctx *cli.Context) error {
+	var errs []error
+	if ctx.Uint64(blockInterval.Name) == 0 {
+		errs = append(errs, errors.New("block-interval cannot be zero"))
+	}
+	// a zero gas limit is adaptive, a fixed one can't be lower than the protocol minimum
+	if gasLimit := ctx.Uint64(gasLimitFlag.Name); gasLimit != 0 && gasLimit < thor.MinGasLimit {
+		errs = append(errs, errors.Errorf("gas-limit must be 0 (adaptive) or at least %v", thor.MinGasLimit))
+	}
+	// transactions are packed, on demand or at each block interval, from the pool
+	limit, limitPerAccount := ctx.Uint64(txPoolLimitFlag.Name), ctx.Uint64(txPoolLimitPerAccountFlag.Name)
+	if limit == 0 {
+		errs = append(errs, errors.New("txpool-limit cannot be zero"))
+	}
+	if limitPerAccount == 0 {
+		errs = append(errs, errors.New("txpool-limit-per-account cannot be zero"))
+	} else if limit != 0 && limitPerAccount > limit {
+		errs = append(errs, errors.New("txpool-limit-per-account cannot exceed txpool-limit"))
+	}
+	if len(errs) > 0 {
+		return joinErrors(errs)
+	}
+	return nil
+}
+
+func dumpConfigAction(ctx *cli.Context) error {
+	flags, otherMode, otherFlags := nodeFlags, "solo", soloFlags
+	if ctx.Bool(dumpConfigSoloFlag.Name) {
+		flags, otherMode, otherFlags = soloFlags, "node", nodeFlags
+	}
+
+	// the command accepts the flags of both modes, the ones of the other mode must not be set on the command line
+	known := flagsByName(flags)
+	var errs []error
+	for _, f := range otherFlags {
+		name := f.GetName()
+		if _, ok := known[name]; ok || !ctx.IsSet(name) {
+			continue
+		}
+		if _, ok := os.LookupEnv(envVarName(name)); !ok {
+			errs = append(errs, errors.Errorf("%v: only for %v", name, otherMode))
+		}
+	}
+	if len(errs) > 0 {
+		return joinErrors(errs)
+	}
+
+	if err := loadConfigFile(ctx, flags, otherMode, otherFlags); err != nil {
+		return err
+	}
+	if ctx.Bool(dumpConfigSoloFlag.Name) {
+		if err := validateSoloFlags(ctx); err != nil {
+			return err
+		}
+	}
+
+	data, err := encodeConfig(ctx, flags)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(data)
+	return err
+}
+
+// encodeConfig encodes the values of the flags as a TOML config file, with their usage as comments.
+func encodeConfig(ctx *cli.Context, flags []cli.Flag) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, f := range flags {
+		name := f.GetName()
+		var (
+			value  any
+			usage  string
+			hidden bool
+		)
+		switch f := f.(type) {
+		case cli.StringFlag:
+			value, usage, hidden = ctx.String(name), f.Usage, f.Hidden
+		case cli.BoolFlag:
+			value, usage, hidden = ctx.Bool(name), f.Usage, f.Hidden
+		case cli.Uint64Flag:
+			value, usage, hidden = ctx.Uint64(name), f.Usage, f.Hidden
+		case cli.IntFlag:
+			value, usage, hidden = ctx.Int(name), f.Usage, f.Hidden
+		case cli.StringSliceFlag:
+			value, usage, hidden = ctx.StringSlice(name), f.Usage, f.Hidden
+		default:
+			continue
+		}
+		if hidden || name == configFileFlag.Name {
+			continue
+		}
+		fmt.Fprintf(&buf, "# %v\n", usage)
+		// encode a key at a time to keep the order of the flags
+		if err := toml.NewEncoder(&buf).Encode(map[string]any{name: value}); err != nil {
+			return nil, errors.Wrapf(err, "encode %v", name)
+		}
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,266 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/urfave/cli.v1"
+)
+
+// runApp runs an app of the flags, which can be set by environment variables too, with the command line args.
+func runApp(flags []cli.Flag, args []string, action func(ctx *cli.Context) error) error {
+	app := cli.NewApp()
+	app.Flags = withEnvVars(flags)
+	app.Action = action
+	return app.Run(append([]string{"thor"}, args...))
+}
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestLoadConfigFile_Precedence(t *testing.T) {
+	path := writeConfigFile(t, "thor.toml", `
+api-addr = "file:8669"
+api-cors = ["https://a.org", "https://b.org"]
+verbosity = 1
+network = "test"
+`)
+
+	for name, tt := range map[string]struct {
+		env     map[string]string
+		args    []string
+		addr    string
+		verbose uint64
+	}{
+		"file": {
+			addr: "file:8669", verbose: 1,
+		},
+		"env over file": {
+			env:  map[string]string{"THOR_API_ADDR": "env:8669", "THOR_VERBOSITY": "2"},
+			addr: "env:8669", verbose: 2,
+		},
+		"command line over env": {
+			env:  map[string]string{"THOR_API_ADDR": "env:8669", "THOR_VERBOSITY": "2"},
+			args: []string{"--api-addr", "cli:8669"},
+			addr: "cli:8669", verbose: 2,
+		},
+		"command line over file": {
+			args: []string{"--verbosity", "3"},
+			addr: "file:8669", verbose: 3,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			args := append([]string{"--config", path}, tt.args...)
+			require.NoError(t, runApp(nodeFlags, args, func(ctx *cli.Context) error {
+				require.NoError(t, loadConfigFile(ctx, nodeFlags, "solo", soloFlags))
+				assert.Equal(t, tt.addr, ctx.String(apiAddrFlag.Name))
+				assert.Equal(t, tt.verbose, ctx.Uint64(verbosityFlag.Name))
+				assert.Equal(t, "test", ctx.String(networkFlag.Name))
+				// lists are joined for the flags taking comma separated lists
+				assert.Equal(t, "https://a.org,https://b.org", ctx.String(apiCorsFlag.Name))
+				return nil
+			}))
+		})
+	}
+}
+
+func TestLoadConfigFile_Keys(t *testing.T) {
+	for name, tt := range map[string]struct {
+		flags      []cli.Flag
+		otherMode  string
+		otherFlags []cli.Flag
+		content    string
+		err        string
+	}{
+		"node": {
+			flags: nodeFlags, otherMode: "solo", otherFlags: soloFlags,
+			content: "on-demand = true\nfoo = 1\nconfig = \"other.toml\"\nnetwork = \"test\"\n",
+			err:     "config: not allowed in the config file\nfoo: unknown option\non-demand: only for solo",
+		},
+		"solo": {
+			flags: soloFlags, otherMode: "node", otherFlags: nodeFlags,
+			content: "network = \"test\"\non-demand = true\nbootnode = \"enode://a@127.0.0.1:11235\"\n" +
+				"master-key-passphrase-file = \"passphrase\"\nmaster-key-require-encrypted = true\nallowed-peers = \"127.0.0.1\"\n",
+			err: "allowed-peers: only for node\nbootnode: only for node\nmaster-key-passphrase-file: only for node\n" +
+				"master-key-require-encrypted: only for node\nnetwork: only for node",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := writeConfigFile(t, "thor.toml", tt.content)
+			err := runApp(tt.flags, []string{"--config", path}, func(ctx *cli.Context) error {
+				return loadConfigFile(ctx, tt.flags, tt.otherMode, tt.otherFlags)
+			})
+			assert.EqualError(t, err, "config file "+path+": "+tt.err)
+		})
+	}
+}
+
+func TestLoadConfigFile_Values(t *testing.T) {
+	flags := []cli.Flag{
+		configFileFlag,
+		cli.StringSliceFlag{Name: "alloc"},
+		cli.StringFlag{Name: "api-cors"},
+		cli.Uint64Flag{Name: "verbosity"},
+		cli.BoolFlag{Name: "on-demand"},
+	}
+	load := func(t *testing.T, content string, args ...string) (*cli.Context, error) {
+		var (
+			loaded *cli.Context
+			err    error
+		)
+		path := writeConfigFile(t, "thor.yaml", content)
+		require.NoError(t, runApp(flags, append([]string{"--config", path}, args...), func(ctx *cli.Context) error {
+			loaded, err = ctx, loadConfigFile(ctx, flags, "solo", nil)
+			return nil
+		}))
+		return loaded, err
+	}
+
+	ctx, err := load(t, `
+alloc: ["0x01=1", "0x02=2"]
+api-cors: [a.org, b.org]
+verbosity: 3
+on-demand: true
+`)
+	require.NoError(t, err)
+	// a slice flag takes the elements of a list as values
+	assert.Equal(t, []string{"0x01=1", "0x02=2"}, ctx.StringSlice("alloc"))
+	assert.Equal(t, "a.org,b.org", ctx.String("api-cors"))
+	assert.Equal(t, uint64(3), ctx.Uint64("verbosity"))
+	assert.True(t, ctx.Bool("on-demand"))
+
+	// the values of the command line replace the list of the file
+	ctx, err = load(t, "alloc: [\"0x01=1\", \"0x02=2\"]\n", "--alloc", "0x03=3")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0x03=3"}, ctx.StringSlice("alloc"))
+
+	ctx, err = load(t, "alloc: \"0x01=1\"\n")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0x01=1"}, ctx.StringSlice("alloc"))
+
+	_, err = load(t, `
+alloc: [[a]]
+api-cors:
+verbosity: 1.5
+on-demand: {a: b}
+`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), ": alloc: nested lists are not supported\n"+
+		"api-cors: empty value\n"+
+		"on-demand: unsupported value of type map[string]interface {}\n"+
+		"verbosity: 1.5 is not an integer")
+
+	_, err = load(t, "verbosity: -1\n")
+	assert.ErrorContains(t, err, "verbosity: parse error")
+}
+
+func TestReadConfigFile(t *testing.T) {
+	_, err := readConfigFile(writeConfigFile(t, "thor.json", "{}"))
+	assert.EqualError(t, err, `unsupported config file extension ".json", want .toml, .yaml or .yml`)
+
+	_, err = readConfigFile(writeConfigFile(t, "thor.toml", "network = "))
+	assert.ErrorContains(t, err, "decode config file")
+
+	_, err = readConfigFile(filepath.Join(t.TempDir(), "missing.toml"))
+	assert.ErrorContains(t, err, "read config file")
+
+	toml, err := readConfigFile(writeConfigFile(t, "thor.toml", "network = \"test\"\nmax-peers = 10\n"))
+	require.NoError(t, err)
+	yaml, err := readConfigFile(writeConfigFile(t, "thor.yml", "network: test\nmax-peers: 10\n"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"network": "test", "max-peers": int64(10)}, toml)
+	assert.Equal(t, map[string]any{"network": "test", "max-peers": 10}, yaml)
+}
+
+func TestDumpConfig_RoundTrip(t *testing.T) {
+	for name, tt := range map[string]struct {
+		flags      []cli.Flag
+		otherMode  string
+		otherFlags []cli.Flag
+		args       []string
+		expected   string
+	}{
+		"node": {
+			flags: nodeFlags, otherMode: "solo", otherFlags: soloFlags,
+			args:     []string{"--network", "test", "--api-addr", "0.0.0.0:8669", "--enable-metrics", "--max-peers", "10"},
+			expected: "api-addr = \"0.0.0.0:8669\"\n",
+		},
+		"solo": {
+			flags: soloFlags, otherMode: "node", otherFlags: nodeFlags,
+			args:     []string{"--on-demand", "--block-interval", "5", "--api-cors", "a.org,b.org"},
+			expected: "on-demand = true\n",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			encode := func(args []string) []byte {
+				var data []byte
+				require.NoError(t, runApp(tt.flags, args, func(ctx *cli.Context) error {
+					if err := loadConfigFile(ctx, tt.flags, tt.otherMode, tt.otherFlags); err != nil {
+						return err
+					}
+					var err error
+					data, err = encodeConfig(ctx, tt.flags)
+					return err
+				}))
+				return data
+			}
+
+			dumped := encode(tt.args)
+			assert.Contains(t, string(dumped), tt.expected)
+			assert.NotContains(t, string(dumped), "config =")
+
+			path := writeConfigFile(t, "thor.toml", string(dumped))
+			assert.Equal(t, string(dumped), string(encode([]string{"--config", path})))
+		})
+	}
+}
+
+func TestDumpConfig_OtherMode(t *testing.T) {
+	flags := append(withEnvVars(mergeFlags(nodeFlags, soloFlags)), dumpConfigSoloFlag)
+	run := func(args ...string) error {
+		app := cli.NewApp()
+		app.Flags = flags
+		app.Action = dumpConfigAction
+		return app.Run(append([]string{"thor"}, args...))
+	}
+
+	assert.EqualError(t, run("--on-demand"), "on-demand: only for solo")
+	assert.EqualError(t, run("--solo", "--network", "test"), "network: only for node")
+	assert.EqualError(t, run("--solo", "--block-interval", "0"), "block-interval cannot be zero")
+}
+
+func TestValidateSoloFlags(t *testing.T) {
+	// data-dir without persist is only warned about
+	require.NoError(t, runApp(soloFlags, []string{"--data-dir", t.TempDir()}, validateSoloFlags))
+	require.NoError(t, runApp(soloFlags, []string{"--on-demand", "--gas-limit", "0"}, validateSoloFlags))
+	assert.EqualError(t, runApp(soloFlags, []string{"--block-interval", "0"}, validateSoloFlags), "block-interval cannot be zero")
+	assert.EqualError(t, runApp(soloFlags, []string{"--gas-limit", "999999"}, validateSoloFlags),
+		"gas-limit must be 0 (adaptive) or at least 1000000")
+	assert.EqualError(t, runApp(soloFlags, []string{"--on-demand", "--txpool-limit", "0", "--txpool-limit-per-account", "0"}, validateSoloFlags),
+		"txpool-limit cannot be zero\ntxpool-limit-per-account cannot be zero")
+	assert.EqualError(t, runApp(soloFlags, []string{"--txpool-limit", "10", "--txpool-limit-per-account", "11"}, validateSoloFlags),
+		"txpool-limit-per-account cannot exceed txpool-limit")
+
+	// the solo options of the config file are checked as well
+	path := writeConfigFile(t, "thor.toml", "gas-limit = 1000\n")
+	assert.EqualError(t, runApp(soloFlags, []string{"--config", path}, func(ctx *cli.Context) error {
+		if err := loadConfigFile(ctx, soloFlags, "node", nodeFlags); err != nil {
+			return err
+		}
+		return validateSoloFlags(ctx)
+	}), "gas-limit must be 0 (adaptive) or at least 1000000")
+}
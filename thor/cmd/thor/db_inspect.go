@@ -0,0 +1,266 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/vechain/thor/v2/cmd/thor/pruner"
+	"github.com/vechain/thor/v2/muxdb"
+	"gopkg.in/urfave/cli.v1"
+)
+
+// the named stores of the main db, with the key spaces they are grouped by.
+var inspectedStores = []struct {
+	name  string
+	group string
+}{
+	{"chain.hdr", "block headers"},
+	{"chain.body", "block bodies"}, // receipts are split out by inspectStoreGroup
+	{"chain.txi", "tx index"},
+	{"chain.heads", "chain heads"},
+	{"chain.props", "chain props"},
+	{"state.code", "contract code"},
+	{"bft.engine", "bft"},
+	{"pruner.props", "pruner"},
+	{"webhooks", "webhooks"},
+	{"delegator", "delegator"},
+	{"muxdb.props", "muxdb props"},
+}
+
+// trie names are grouped by their leading byte, see state.AccountTrieName, state.StorageTrieNamePrefix and
+// chain.IndexTrieName.
+var inspectedTries = []struct {
+	prefix byte
+	group  string
+}{
+	{'a', "account"},
+	{'s', "storage"},
+	{'i', "chain index"},
+}
+
+func dbInspectAction(ctx *cli.Context) error {
+	exitSignal := handleExitSignal()
+
+	gene, _, err := selectChainGenesis(ctx)
+	if err != nil {
+		return err
+	}
+	instanceDir, err := makeInstanceDir(ctx, gene)
+	if err != nil {
+		return err
+	}
+	mainDB, logDB, repo, err := openChain(ctx, gene)
+	if err != nil {
+		return err
+	}
+	defer mainDB.Close()
+	defer logDB.Close()
+
+	out := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	defer out.Flush()
+
+	best := repo.BestBlockSummary().Header
+	fmt.Fprintf(out, "Instance dir:\t%v\t\n", instanceDir)
+	fmt.Fprintf(out, "Best block:\t#%v %v\t\n", best.Number(), best.ID())
+	if ctx.Bool(disablePrunerFlag.Name) {
+		fmt.Fprintf(out, "Pruner:\tdisabled\t\n")
+	} else {
+		base, err := pruner.LoadBase(mainDB)
+		if err != nil {
+			return errors.Wrap(err, "load pruner status")
+		}
+		fmt.Fprintf(out, "Pruner:\thistory pruned below block #%v\t\n", base)
+	}
+
+	fmt.Fprintf(out, "\nFile\tSize\t\n")
+	if err := inspectFiles(out, instanceDir); err != nil {
+		return errors.Wrap(err, "inspect instance dir")
+	}
+	out.Flush()
+
+	fmt.Fprintln(os.Stderr, ">> Scanning trie nodes <<")
+	if err := inspectTries(exitSignal, out, mainDB); err != nil {
+		return errors.Wrap(err, "inspect tries")
+	}
+	out.Flush()
+
+	fmt.Fprintln(os.Stderr, ">> Scanning named stores <<")
+	if err := inspectStores(exitSignal, out, mainDB); err != nil {
+		return errors.Wrap(err, "inspect stores")
+	}
+	out.Flush()
+
+	fmt.Fprintln(os.Stderr, ">> Scanning log db <<")
+	stats, err := logDB.Stats(exitSignal)
+	if err != nil {
+		return errors.Wrap(err, "inspect log db")
+	}
+	fmt.Fprintf(out, "\nLog DB\tTable\tEntries\tSize\t\n")
+	for _, ts := range stats.Tables {
+		fmt.Fprintf(out, "%v\t%v\t%v\t%v\t\n", ts.Name, ts.Table, ts.Entries, formatSize(ts.Size))
+	}
+	fmt.Fprintf(out, "free pages\t\t%v\t%v\t\n", stats.FreePages, formatSize(stats.FreePages*stats.PageSize))
+	fmt.Fprintf(out, "total\t\t\t%v\t\n", formatSize(stats.Pages*stats.PageSize))
+
+	var dbStats leveldb.DBStats
+	if err := mainDB.Stats(&dbStats); err != nil {
+		return errors.Wrap(err, "read leveldb stats")
+	}
+	fmt.Fprintf(out, "\nLevel\tTables\tSize\tTime\tRead\tWrite\t\n")
+	for i := range dbStats.LevelSizes {
+		fmt.Fprintf(out, "%v\t%v\t%v\t%v\t%v\t%v\t\n",
+			i,
+			dbStats.LevelTablesCounts[i],
+			formatSize(dbStats.LevelSizes[i]),
+			dbStats.LevelDurations[i].Round(time.Millisecond),
+			formatSize(dbStats.LevelRead[i]),
+			formatSize(dbStats.LevelWrite[i]),
+		)
+	}
+	return nil
+}
+
+// inspectFiles writes the size of the entries of the instance dir, directories are summed up.
+func inspectFiles(w io.Writer, dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	var total int64
+	for _, entry := range entries {
+		var size int64
+		if err := filepath.WalkDir(filepath.Join(dir, entry.Name()), func(_ string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			size += info.Size()
+			return nil
+		}); err != nil {
+			return err
+		}
+		total += size
+		fmt.Fprintf(w, "%v\t%v\t\n", entry.Name(), formatSize(size))
+	}
+	fmt.Fprintf(w, "total\t%v\t\n", formatSize(total))
+	return nil
+}
+
+func inspectTries(ctx context.Context, w io.Writer, db *muxdb.MuxDB) error {
+	usage, err := db.InspectTries(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\nTrie nodes\tEntries\tSize\tDisk\t\n")
+	for _, space := range []struct {
+		name     string
+		groups   map[byte]*muxdb.Usage
+		diskSize int64
+	}{
+		{"history", usage.Hist, usage.HistDiskSize},
+		{"deduped", usage.Deduped, usage.DedupedDiskSize},
+	} {
+		var total muxdb.Usage
+		for _, trie := range inspectedTries {
+			u := space.groups[trie.prefix]
+			if u == nil {
+				u = &muxdb.Usage{}
+			}
+			total.Entries += u.Entries
+			total.Size += u.Size
+			fmt.Fprintf(w, "%v: %v\t%v\t%v\t\t\n", space.name, trie.group, u.Entries, formatSize(u.Size))
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t\n", space.name, total.Entries, formatSize(total.Size), formatSize(space.diskSize))
+	}
+	return nil
+}
+
+func inspectStores(ctx context.Context, w io.Writer, db *muxdb.MuxDB) error {
+	usage, err := db.InspectStores(ctx, inspectStoreGroup)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\nStore\tName\tEntries\tSize\tDisk\t\n")
+	for _, store := range inspectedStores {
+		diskSize, err := db.StoreDiskSize(store.name)
+		if err != nil {
+			return err
+		}
+		groups := []string{store.group}
+		if store.name == "chain.body" {
+			groups = append(groups, "receipts")
+		}
+		for i, group := range groups {
+			u := usage[group]
+			if u == nil {
+				u = &muxdb.Usage{}
+			}
+			disk := ""
+			if i == 0 {
+				disk = formatSize(diskSize)
+			}
+			fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t\n", group, store.name, u.Entries, formatSize(u.Size), disk)
+		}
+	}
+	// stores unknown to this command
+	if u := usage["other"]; u != nil {
+		fmt.Fprintf(w, "other\t\t%v\t%v\t\t\n", u.Entries, formatSize(u.Size))
+	}
+	return nil
+}
+
+// inspectStoreGroup maps a key of the named store space to its group.
+func inspectStoreGroup(key []byte) string {
+	for _, store := range inspectedStores {
+		if !strings.HasPrefix(string(key), store.name) {
+			continue
+		}
+		if store.name == "chain.body" {
+			// keys of txs and receipts are block number, block conflicts, flag and index, see chain.appendTxKey
+			rest := key[len(store.name):]
+			if len(rest) > 4 {
+				if _, n := binary.Uvarint(rest[4:]); n > 0 && len(rest) > 4+n && rest[4+n] == 1 {
+					return "receipts"
+				}
+			}
+		}
+		return store.group
+	}
+	return "other"
+}
+
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.2f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
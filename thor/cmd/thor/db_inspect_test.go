@@ -0,0 +1,27 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInspectStoreGroup(t *testing.T) {
+	for key, group := range map[string]string{
+		"chain.hdr\x01":       "block headers",
+		"bft.engine\x01":      "bft",
+		"webhooks\x01":        "webhooks",
+		"delegator\x01":       "delegator",
+		"muxdb.props\x01":     "muxdb props",
+		"unknown.store\x01":   "other",
+		"state.code\x01\x02":  "contract code",
+		"pruner.props\x01abc": "pruner",
+	} {
+		assert.Equal(t, group, inspectStoreGroup([]byte(key)), key)
+	}
+}
@@ -0,0 +1,274 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/thor"
+	"gopkg.in/urfave/cli.v1"
+)
+
+// devnetNode is a node of the devnet, run as a child process.
+type devnetNode struct {
+	name    string
+	dir     string
+	master  thor.Address
+	enode   string
+	apiAddr string
+	p2pPort int
+	cmd     *exec.Cmd
+}
+
+func devnetAction(ctx *cli.Context) error {
+	dir := ctx.String(devnetDirFlag.Name)
+	if dir == "" {
+		return errors.New("devnet dir required")
+	}
+	genesisPath := filepath.Join(dir, "genesis.json")
+
+	gen, err := loadOrCreateDevnetGenesis(ctx, dir, genesisPath)
+	if err != nil {
+		return err
+	}
+	gene, err := genesis.NewCustomNet(gen)
+	if err != nil {
+		return errors.Wrap(err, "build genesis")
+	}
+
+	var (
+		apiPort = ctx.Int(devnetAPIPortFlag.Name)
+		p2pPort = ctx.Int(p2pPortFlag.Name)
+		nodes   = make([]*devnetNode, len(gen.Authority))
+	)
+	for i := range nodes {
+		node := &devnetNode{
+			name:    fmt.Sprintf("node%v", i+1),
+			apiAddr: fmt.Sprintf("127.0.0.1:%v", apiPort+i),
+			p2pPort: p2pPort + i,
+		}
+		node.dir = filepath.Join(dir, node.name)
+		master, err := crypto.LoadECDSA(filepath.Join(node.dir, "master.key"))
+		if err != nil {
+			return errors.Wrapf(err, "load master key of %v", node.name)
+		}
+		node.master = thor.Address(crypto.PubkeyToAddress(master.PublicKey))
+		// the P2P key is generated ahead, for the nodes to know each other
+		p2pKey, err := loadOrGeneratePrivateKey(filepath.Join(node.dir, "p2p.key"))
+		if err != nil {
+			return errors.Wrapf(err, "load or generate P2P key of %v", node.name)
+		}
+		node.enode = fmt.Sprintf("enode://%x@127.0.0.1:%v", discover.PubkeyID(&p2pKey.PublicKey).Bytes(), node.p2pPort)
+		nodes[i] = node
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	var outLock sync.Mutex
+	for _, node := range nodes {
+		var peers []string
+		for _, other := range nodes {
+			if other != node {
+				peers = append(peers, other.enode)
+			}
+		}
+		args := []string{
+			"--network", genesisPath,
+			"--config-dir", node.dir,
+			"--data-dir", filepath.Join(node.dir, "data"),
+			"--api-addr", node.apiAddr,
+			"--p2p-port", fmt.Sprint(node.p2pPort),
+			"--nat", "none",
+			"--allowed-peers", strings.Join(peers, ","),
+		}
+		// the extra arguments are passed through to every node
+		node.cmd = exec.Command(executable, append(args, ctx.Args()...)...)
+		out := &prefixWriter{prefix: node.name + " | ", w: os.Stdout, lock: &outLock}
+		node.cmd.Stdout, node.cmd.Stderr = out, out
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Node\tMaster\tAPI\tEnode\t\n")
+	for _, node := range nodes {
+		fmt.Fprintf(w, "%v\t%v\thttp://%v\t%v\t\n", node.name, node.master, node.apiAddr, node.enode)
+	}
+	w.Flush()
+	fmt.Printf("\nGenesis ID: %v\nGenesis:    %v\n\n", gene.ID(), genesisPath)
+
+	exitSignal := handleExitSignal()
+	exited := make(chan *devnetNode, len(nodes))
+	running := 0
+	for _, node := range nodes {
+		if err := node.cmd.Start(); err != nil {
+			fmt.Fprintf(os.Stderr, "start %v: %v\n", node.name, err)
+			continue
+		}
+		running++
+		outLock.Lock()
+		fmt.Printf("%v started, pid %v\n", node.name, node.cmd.Process.Pid)
+		outLock.Unlock()
+		go func(node *devnetNode) {
+			err := node.cmd.Wait()
+			outLock.Lock()
+			fmt.Fprintf(os.Stderr, "%v exited: %v\n", node.name, exitStatus(err))
+			outLock.Unlock()
+			exited <- node
+		}(node)
+	}
+
+	interrupted := false
+	for running > 0 {
+		select {
+		case <-exitSignal.Done():
+			if !interrupted {
+				interrupted = true
+				for _, node := range nodes {
+					interruptProcess(node.cmd)
+				}
+			}
+		case <-exited:
+			running--
+		}
+	}
+	return nil
+}
+
+// loadOrCreateDevnetGenesis loads the genesis of the devnet in the dir, or creates it with the keys of its nodes.
+func loadOrCreateDevnetGenesis(ctx *cli.Context, dir, genesisPath string) (*genesis.CustomGenesis, error) {
+	n := ctx.Int(devnetNodesFlag.Name)
+	if _, err := os.Stat(genesisPath); err == nil {
+		gen, err := readGenesisFile(genesisPath)
+		if err != nil {
+			return nil, err
+		}
+		if ctx.IsSet(devnetNodesFlag.Name) && n != len(gen.Authority) {
+			return nil, errors.Errorf("devnet in %v has %v nodes, remove it to start a new one", dir, len(gen.Authority))
+		}
+		fmt.Printf("Restarting the devnet in %v\n", dir)
+		return gen, nil
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	if n < 2 {
+		return nil, errors.New("at least 2 nodes required, use solo mode for a single node")
+	}
+	gen := defaultGenesisTemplate()
+	if path := ctx.String(genesisTemplateFlag.Name); path != "" {
+		template, err := readGenesisFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if template.LaunchTime == 0 {
+			template.LaunchTime = gen.LaunchTime
+		}
+		gen = template
+	} else {
+		// the nodes are all the block proposers, to reach finality
+		maxBlockProposers := uint64(n)
+		gen.Params.MaxBlockProposers = &maxBlockProposers
+	}
+	if gen.ExtraData == "" {
+		gen.ExtraData = "Devnet"
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, errors.Wrap(err, "create devnet dir")
+	}
+	nodes, err := addGenesisAuthorities(gen, dir, n, nil)
+	if err != nil {
+		return nil, err
+	}
+	// the dev accounts are funded as in solo mode
+	for _, acc := range genesis.DevAccounts() {
+		bal, _ := new(big.Int).SetString("1000000000000000000000000000", 10)
+		account := findGenesisAccount(gen, acc.Address)
+		account.Balance = (*genesis.HexOrDecimal256)(bal)
+		account.Energy = (*genesis.HexOrDecimal256)(new(big.Int).Set(bal))
+	}
+	for _, alloc := range ctx.StringSlice(genesisAllocFlag.Name) {
+		addr, amount, err := parseGenesisAlloc(alloc)
+		if err != nil {
+			return nil, err
+		}
+		findGenesisAccount(gen, addr).Balance = (*genesis.HexOrDecimal256)(amount)
+	}
+	if errs := gen.Validate(); len(errs) > 0 {
+		return nil, errors.Wrap(joinErrors(errs), "invalid genesis")
+	}
+	if err := writeGenesisKeys(nodes); err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(gen, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(genesisPath, append(data, '\n'), 0o644); err != nil {
+		return nil, errors.Wrap(err, "write genesis file")
+	}
+	fmt.Printf("Created a devnet of %v nodes in %v\n", n, dir)
+	return gen, nil
+}
+
+// interruptProcess asks the process to exit, or kills it where interrupts are not supported.
+func interruptProcess(cmd *exec.Cmd) {
+	if cmd.Process == nil {
+		return
+	}
+	if err := cmd.Process.Signal(os.Interrupt); err != nil {
+		_ = cmd.Process.Kill()
+	}
+}
+
+func exitStatus(err error) string {
+	if err == nil {
+		return "exit status 0"
+	}
+	return err.Error()
+}
+
+// prefixWriter writes the prefix before every line, the lines of the writers sharing the lock are not interleaved.
+type prefixWriter struct {
+	prefix string
+	w      io.Writer
+	lock   *sync.Mutex
+	buf    []byte
+}
+
+func (p *prefixWriter) Write(b []byte) (int, error) {
+	p.buf = append(p.buf, b...)
+	for {
+		i := bytes.IndexByte(p.buf, '\n')
+		if i < 0 {
+			break
+		}
+		p.lock.Lock()
+		_, err := fmt.Fprintf(p.w, "%s%s\n", p.prefix, p.buf[:i])
+		p.lock.Unlock()
+		if err != nil {
+			return 0, err
+		}
+		p.buf = p.buf[i+1:]
+	}
+	return len(b), nil
+}
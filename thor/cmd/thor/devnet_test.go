@@ -0,0 +1,119 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/thor"
+	"gopkg.in/urfave/cli.v1"
+)
+
+func TestPrefixWriter(t *testing.T) {
+	var (
+		out  bytes.Buffer
+		lock sync.Mutex
+		w1   = &prefixWriter{prefix: "[node1] ", w: &out, lock: &lock}
+		w2   = &prefixWriter{prefix: "[node2] ", w: &out, lock: &lock}
+	)
+
+	// lines are written once complete, whatever the writes
+	n, err := w1.Write([]byte("first "))
+	require.NoError(t, err)
+	assert.Equal(t, 6, n)
+	assert.Empty(t, out.String())
+
+	_, err = w2.Write([]byte("a\n\nb"))
+	require.NoError(t, err)
+	_, err = w1.Write([]byte("line\nsecond line\nthird"))
+	require.NoError(t, err)
+	_, err = w2.Write([]byte("\n"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "[node2] a\n"+
+		"[node2] \n"+
+		"[node1] first line\n"+
+		"[node1] second line\n"+
+		"[node2] b\n", out.String())
+	assert.Equal(t, "third", string(w1.buf))
+
+	// concurrent writers don't interleave their lines
+	out.Reset()
+	w1 = &prefixWriter{prefix: "[node1] ", w: &out, lock: &lock}
+	w2 = &prefixWriter{prefix: "[node2] ", w: &out, lock: &lock}
+	var wg sync.WaitGroup
+	for _, w := range []*prefixWriter{w1, w2} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				_, _ = w.Write([]byte("0123456789\n"))
+			}
+		}()
+	}
+	wg.Wait()
+	lines := bytes.Split(bytes.TrimSuffix(out.Bytes(), []byte("\n")), []byte("\n"))
+	assert.Len(t, lines, 200)
+	for _, line := range lines {
+		assert.Regexp(t, `^\[node[12]\] 0123456789$`, string(line))
+	}
+}
+
+func TestLoadOrCreateDevnetGenesis(t *testing.T) {
+	flags := []cli.Flag{devnetNodesFlag, genesisTemplateFlag, genesisAllocFlag}
+	load := func(t *testing.T, dir string, args ...string) (*genesis.CustomGenesis, error) {
+		var (
+			gen *genesis.CustomGenesis
+			err error
+		)
+		require.NoError(t, runApp(flags, args, func(ctx *cli.Context) error {
+			gen, err = loadOrCreateDevnetGenesis(ctx, dir, filepath.Join(dir, "genesis.json"))
+			return nil
+		}))
+		return gen, err
+	}
+
+	dir := filepath.Join(t.TempDir(), "devnet")
+	allocated := thor.BytesToAddress([]byte("allocated"))
+	gen, err := load(t, dir, "--nodes", "4", "--alloc", allocated.String()+"=1000")
+	require.NoError(t, err)
+	assert.Len(t, gen.Authority, 4)
+	assert.Equal(t, uint64(4), *gen.Params.MaxBlockProposers)
+	assert.Equal(t, "Devnet", gen.ExtraData)
+	for i := 1; i <= 4; i++ {
+		assert.FileExists(t, filepath.Join(dir, fmt.Sprintf("node%v", i), "master.key"))
+	}
+	balances := make(map[thor.Address]*big.Int)
+	for _, acc := range gen.Accounts {
+		balances[acc.Address] = (*big.Int)(acc.Balance)
+	}
+	assert.Equal(t, big.NewInt(1000), balances[allocated])
+	for _, acc := range genesis.DevAccounts() {
+		assert.NotNil(t, balances[acc.Address])
+	}
+
+	// the devnet is restarted with the genesis of the dir
+	restarted, err := load(t, dir)
+	require.NoError(t, err)
+	assert.Equal(t, gen.Authority, restarted.Authority)
+	restarted, err = load(t, dir, "--nodes", "4")
+	require.NoError(t, err)
+	assert.Equal(t, gen.Authority, restarted.Authority)
+
+	_, err = load(t, dir, "--nodes", "3")
+	assert.EqualError(t, err, "devnet in "+dir+" has 4 nodes, remove it to start a new one")
+
+	_, err = load(t, filepath.Join(t.TempDir(), "devnet"), "--nodes", "1")
+	assert.EqualError(t, err, "at least 2 nodes required, use solo mode for a single node")
+}
@@ -11,6 +11,10 @@ import (
 )
 
 var (
+	configFileFlag = cli.StringFlag{
+		Name:  "config",
+		Usage: "path to a TOML or YAML config file, whose keys are the flag names",
+	}
 	networkFlag = cli.StringFlag{
 		Name:  "network",
 		Usage: "the network to join (main|test) or the path/URL to a genesis file",
@@ -26,6 +30,18 @@ var (
 		Usage:  "read master key from stdin",
 		Hidden: true,
 	}
+	masterKeyPassphraseFileFlag = cli.StringFlag{
+		Name:  "master-key-passphrase-file",
+		Usage: "path to the file holding the passphrase of the encrypted master key, on its first line",
+	}
+	masterKeyRequireEncryptedFlag = cli.BoolFlag{
+		Name:  "master-key-require-encrypted",
+		Usage: "refuse to start with a plaintext master key",
+	}
+	masterSignerFlag = cli.StringFlag{
+		Name:  "master-signer",
+		Usage: "URL of the remote signer holding the master key (http(s)://host:port or unix:///path/to/socket)",
+	}
 	dataDirFlag = cli.StringFlag{
 		Name:  "data-dir",
 		Value: defaultDataDir(),
@@ -40,6 +56,14 @@ var (
 		Value: "localhost:8669",
 		Usage: "API service listening address",
 	}
+	apiTLSCertFlag = cli.StringFlag{
+		Name:  "api-tls-cert",
+		Usage: "path to the TLS certificate file of the API service, enables HTTPS and is reloaded on change",
+	}
+	apiTLSKeyFlag = cli.StringFlag{
+		Name:  "api-tls-key",
+		Usage: "path to the TLS private key file of the API service",
+	}
 	apiCorsFlag = cli.StringFlag{
 		Name:  "api-cors",
 		Value: "",
@@ -81,12 +105,43 @@ var (
 		Name:  "api-enable-txpool",
 		Usage: "enable txpool REST API endpoints",
 	}
+	apiLogsMaxCostFlag = cli.Uint64Flag{
+		Name:  "api-logs-max-cost",
+		Usage: "limit the estimated cost of /logs API queries, 0 for no limit",
+	}
+	apiLogsDowngradeFlag = cli.BoolFlag{
+		Name:  "api-logs-downgrade",
+		Usage: "narrow the block range of /logs API queries above the max cost instead of rejecting them",
+	}
+	apiLogsQueryTimeoutFlag = cli.Uint64Flag{
+		Name:  "api-logs-query-timeout",
+		Usage: "/logs API query timeout value in milliseconds, 0 for no timeout besides the API request timeout",
+	}
+	apiLogsSlowQueryFlag = cli.Uint64Flag{
+		Name:  "api-logs-slow-query",
+		Value: 1000,
+		Usage: "log /logs API queries taking longer than the value in milliseconds, 0 to disable",
+	}
+	apiCacheSizeFlag = cli.IntFlag{
+		Name:  "api-cache-size",
+		Value: 512,
+		Usage: "number of immutable API responses kept in memory, 0 to disable the cache",
+	}
 	// priority fees API flags
 	apiPriorityFeesPercentageFlag = cli.Uint64Flag{
 		Name:  "api-priority-fees-percentage",
 		Value: 5,
 		Usage: "percentage of the block base fee for priority fees calculation",
 	}
+	apiCertDomainsFlag = cli.StringFlag{
+		Name:  "api-cert-domains",
+		Usage: "comma separated list of domains of the certificates accepted by /certificates API, a leading '*.' matches the sub domains, any domain if not set",
+	}
+	apiCertMaxAgeFlag = cli.Uint64Flag{
+		Name:  "api-cert-max-age",
+		Value: 300,
+		Usage: "max difference in seconds between the timestamp of the certificates accepted by /certificates API and now, 0 for no limit",
+	}
 
 	verbosityFlag = cli.Uint64Flag{
 		Name:  "verbosity",
@@ -129,6 +184,10 @@ var (
 		Name:  "export",
 		Usage: "export master key to keystore",
 	}
+	encryptMasterKeyFlag = cli.BoolFlag{
+		Name:  "encrypt",
+		Usage: "encrypt the plaintext master key with a passphrase, or generate an encrypted one if missing",
+	}
 	targetGasLimitFlag = cli.Uint64Flag{
 		Name:  "target-gas-limit",
 		Value: 0,
@@ -165,6 +224,14 @@ var (
 		Value: "localhost:2112",
 		Usage: "metrics service listening address",
 	}
+	metricsTLSCertFlag = cli.StringFlag{
+		Name:  "metrics-tls-cert",
+		Usage: "path to the TLS certificate file of the metrics service, enables HTTPS and is reloaded on change",
+	}
+	metricsTLSKeyFlag = cli.StringFlag{
+		Name:  "metrics-tls-key",
+		Usage: "path to the TLS private key file of the metrics service",
+	}
 
 	enableAdminFlag = cli.BoolFlag{
 		Name:  "enable-admin",
@@ -175,6 +242,30 @@ var (
 		Value: "localhost:2113",
 		Usage: "admin service listening address",
 	}
+	adminTLSCertFlag = cli.StringFlag{
+		Name:  "admin-tls-cert",
+		Usage: "path to the TLS certificate file of the admin service, enables HTTPS and is reloaded on change",
+	}
+	adminTLSKeyFlag = cli.StringFlag{
+		Name:  "admin-tls-key",
+		Usage: "path to the TLS private key file of the admin service",
+	}
+	adminTLSClientCAFlag = cli.StringFlag{
+		Name:  "admin-tls-client-ca",
+		Usage: "path to the CA bundle of the admin service clients, requires client certificates signed by it (mTLS)",
+	}
+	apiKeysFlag = cli.StringFlag{
+		Name:  "api-keys",
+		Usage: "path to the API keys file, enables API key authentication and per-key rate limiting",
+	}
+	delegatorPolicyFlag = cli.StringFlag{
+		Name:  "delegator-policy",
+		Usage: "path to the sponsorship policy file, enables the VIP-191 gas payer API at /delegator",
+	}
+	delegatorSignerFlag = cli.StringFlag{
+		Name:  "delegator-signer",
+		Usage: "URL of the remote signer holding the gas payer key (http(s)://host:port or unix:///path/to/socket)",
+	}
 	txPoolLimitPerAccountFlag = cli.Uint64Flag{
 		Name:  "txpool-limit-per-account",
 		Value: 128,
@@ -221,4 +312,174 @@ var (
 		Name:  "genesis",
 		Usage: "path or URL to genesis file, if not set, the default devnet genesis will be used",
 	}
+
+	// flags for chain export & import
+	soloChainFlag = cli.BoolFlag{
+		Name:  "solo",
+		Usage: "use the chain of solo mode (persisted with --persist), the genesis is set by --genesis",
+	}
+	exportFromFlag = cli.Uint64Flag{
+		Name:  "from",
+		Value: 1,
+		Usage: "number of the first block to export",
+	}
+	exportToFlag = cli.Uint64Flag{
+		Name:  "to",
+		Usage: "number of the last block to export (best block if not set)",
+	}
+	exportOutFlag = cli.StringFlag{
+		Name:  "out",
+		Usage: "path of the chain file to write, gzip compressed if it ends with .gz",
+	}
+	exportReceiptsFlag = cli.BoolFlag{
+		Name:  "receipts",
+		Usage: "include receipts in the chain file",
+	}
+	importInFlag = cli.StringFlag{
+		Name:  "in",
+		Usage: "path of the chain file to read, gzip compressed if it ends with .gz",
+	}
+
+	// flags for state dump & load
+	replayFromFlag = cli.Uint64Flag{
+		Name:  "from",
+		Value: 1,
+		Usage: "number of the first block to replay",
+	}
+	replayToFlag = cli.Uint64Flag{
+		Name:  "to",
+		Usage: "number of the last block to replay (best block if not set)",
+	}
+	stateRevisionFlag = cli.StringFlag{
+		Name:  "revision",
+		Value: "best",
+		Usage: "block number, ID, or best|finalized|justified of the state to dump",
+	}
+	stateAddressFlag = cli.StringFlag{
+		Name:  "address",
+		Usage: "comma separated list of the accounts to dump, all accounts are dumped if not set",
+	}
+	stateNDJSONFlag = cli.BoolFlag{
+		Name:  "ndjson",
+		Usage: "write the dump as newline delimited JSON, an account per line",
+	}
+	stateOutFlag = cli.StringFlag{
+		Name:  "out",
+		Usage: "path of the file to write, stdout if not set",
+	}
+	stateInFlag = cli.StringFlag{
+		Name:  "in",
+		Usage: "path of the dump to read, stdin if not set",
+	}
+	stateTemplateFlag = cli.StringFlag{
+		Name:  "template",
+		Usage: "path to the genesis file providing the network settings, a solo network if not set",
+	}
+
+	// flags for genesis authoring
+	genesisTemplateFlag = cli.StringFlag{
+		Name:  "template",
+		Usage: "path to the genesis file to start from, a network with all forks enabled if not set",
+	}
+	genesisAuthoritiesFlag = cli.IntFlag{
+		Name:  "authorities",
+		Value: 1,
+		Usage: "number of authority nodes to generate",
+	}
+	genesisEndorsorFlag = cli.StringFlag{
+		Name:  "endorsor",
+		Usage: "endorsor address of the generated authority nodes, a fresh key per node if not set",
+	}
+	genesisAllocFlag = cli.StringSliceFlag{
+		Name:  "alloc",
+		Usage: "balance of an account as address=amount in wei, can be repeated",
+	}
+	genesisKeysDirFlag = cli.StringFlag{
+		Name:  "keys-dir",
+		Value: "keys",
+		Usage: "directory to write the generated keys, a sub directory per node to be used as its config dir",
+	}
+	genesisOutFlag = cli.StringFlag{
+		Name:  "out",
+		Usage: "path of the genesis file to write, stdout if not set",
+	}
+
+	// flags for config dump
+	dumpConfigSoloFlag = cli.BoolFlag{
+		Name:  "solo",
+		Usage: "dump the configuration of solo mode",
+	}
+
+	// flags for the signing service
+	signerListenFlag = cli.StringFlag{
+		Name:  "listen",
+		Usage: "address to serve on, host:port or unix:///path/to/socket",
+	}
+	signerAllowFlag = cli.StringFlag{
+		Name:  "allow",
+		Value: "127.0.0.1,::1",
+		Usage: "comma separated list of IPs or CIDRs of the clients allowed over TCP",
+	}
+	signerAuditLogFlag = cli.StringFlag{
+		Name:  "audit-log",
+		Usage: "path of the file to append the audit log of the sign requests to, stderr if not set",
+	}
+
+	// flags for key management
+	keysWordsFlag = cli.IntFlag{
+		Name:  "words",
+		Value: 24,
+		Usage: "number of words of the mnemonic, 12, 15, 18, 21 or 24",
+	}
+	keysBIP39PassphraseFlag = cli.BoolFlag{
+		Name:  "bip39-passphrase",
+		Usage: "prompt for the optional BIP-39 passphrase of the mnemonic",
+	}
+	keysFromFlag = cli.Uint64Flag{
+		Name:  "from",
+		Usage: "first index to derive on the path m/44'/818'/0'/0",
+	}
+	keysCountFlag = cli.Uint64Flag{
+		Name:  "count",
+		Value: 1,
+		Usage: "number of keys to derive",
+	}
+	keysPrivateFlag = cli.BoolFlag{
+		Name:  "private",
+		Usage: "also print the private keys",
+	}
+	keysAddressFlag = cli.StringFlag{
+		Name:  "address",
+		Usage: "address to search for",
+	}
+	keysMaxFlag = cli.Uint64Flag{
+		Name:  "max",
+		Value: 1000,
+		Usage: "number of indexes to search",
+	}
+	keysKeystoreDirFlag = cli.StringFlag{
+		Name:  "keystore-dir",
+		Usage: "directory of the keystore v3 files, keystore under the config dir if not set",
+	}
+	keysKeystoreFileFlag = cli.StringSliceFlag{
+		Name:  "keystore-file",
+		Usage: "keystore v3 file to import instead of deriving from a mnemonic, can be repeated",
+	}
+
+	// flags for the devnet
+	devnetNodesFlag = cli.IntFlag{
+		Name:  "nodes",
+		Value: 3,
+		Usage: "number of authority nodes of a new devnet",
+	}
+	devnetDirFlag = cli.StringFlag{
+		Name:  "dir",
+		Value: "devnet",
+		Usage: "directory of the genesis, keys and data of the devnet, reused if it exists",
+	}
+	devnetAPIPortFlag = cli.IntFlag{
+		Name:  "api-port",
+		Value: 8669,
+		Usage: "API port of the first node, incremented for each next node",
+	}
 )
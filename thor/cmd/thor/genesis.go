@@ -0,0 +1,302 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/thor"
+	"gopkg.in/urfave/cli.v1"
+)
+
+func genesisNewAction(ctx *cli.Context) error {
+	gen := defaultGenesisTemplate()
+	if path := ctx.String(genesisTemplateFlag.Name); path != "" {
+		template, err := readGenesisFile(path)
+		if err != nil {
+			return err
+		}
+		if template.LaunchTime == 0 {
+			template.LaunchTime = gen.LaunchTime
+		}
+		gen = template
+	}
+
+	keysDir := ctx.String(genesisKeysDirFlag.Name)
+	if keysDir == "" {
+		return errors.New("keys dir required")
+	}
+
+	var endorsor *thor.Address
+	if s := ctx.String(genesisEndorsorFlag.Name); s != "" {
+		addr, err := thor.ParseAddress(s)
+		if err != nil {
+			return errors.Wrap(err, "parse endorsor")
+		}
+		endorsor = &addr
+	}
+
+	nodes, err := addGenesisAuthorities(gen, keysDir, ctx.Int(genesisAuthoritiesFlag.Name), endorsor)
+	if err != nil {
+		return err
+	}
+
+	for _, alloc := range ctx.StringSlice(genesisAllocFlag.Name) {
+		addr, amount, err := parseGenesisAlloc(alloc)
+		if err != nil {
+			return err
+		}
+		findGenesisAccount(gen, addr).Balance = (*genesis.HexOrDecimal256)(amount)
+	}
+
+	if errs := gen.Validate(); len(errs) > 0 {
+		return errors.Wrap(joinErrors(errs), "invalid genesis")
+	}
+	gene, err := genesis.NewCustomNet(gen)
+	if err != nil {
+		return errors.Wrap(err, "build genesis")
+	}
+	// the keys are written once the genesis is valid, not to be left behind by a failed run
+	if err := writeGenesisKeys(nodes); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(gen, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	out := ctx.String(genesisOutFlag.Name)
+	if out != "" {
+		if err := os.WriteFile(out, data, 0o644); err != nil {
+			return errors.Wrap(err, "write genesis file")
+		}
+	} else if _, err := os.Stdout.Write(data); err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stderr, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Config dir\tMaster\tEndorsor\t\n")
+	for _, n := range nodes {
+		fmt.Fprintf(w, "%v\t%v\t%v\t\n", n.dir, n.master, n.endorsor)
+	}
+	w.Flush()
+	fmt.Fprintf(os.Stderr, "\nGenesis ID: %v\nChain tag:  0x%02x\n", gene.ID(), gene.ID()[31])
+	if out != "" && len(nodes) > 0 {
+		fmt.Fprintf(os.Stderr, "\nStart a node with: thor --network %v --config-dir %v\n", out, nodes[0].dir)
+	}
+	return nil
+}
+
+func genesisValidateAction(ctx *cli.Context) error {
+	gen, err := readGenesisFlag(ctx)
+	if err != nil {
+		return err
+	}
+	if errs := gen.Validate(); len(errs) > 0 {
+		for _, err := range errs {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		}
+		return errors.Errorf("invalid genesis file: %v errors", len(errs))
+	}
+
+	// nodes of endorsors without the endorsement balance are not eligible to propose blocks
+	endorsement := thor.InitialProposerEndorsement
+	if e := gen.Params.ProposerEndorsement; e != nil {
+		endorsement = (*big.Int)(e)
+	}
+	balances := make(map[thor.Address]*big.Int)
+	for _, acc := range gen.Accounts {
+		balances[acc.Address] = (*big.Int)(acc.Balance)
+	}
+	for i, node := range gen.Authority {
+		if bal := balances[node.EndorsorAddress]; bal == nil || bal.Cmp(endorsement) < 0 {
+			fmt.Fprintf(os.Stderr, "warning: authority[%v] %v: endorsor %v balance below the proposer endorsement %v\n",
+				i, node.MasterAddress, node.EndorsorAddress, endorsement)
+		}
+	}
+
+	gene, err := genesis.NewCustomNet(gen)
+	if err != nil {
+		return errors.Wrap(err, "build genesis")
+	}
+	fmt.Printf("Genesis file is valid, ID %v\n", gene.ID())
+	return nil
+}
+
+func genesisIDAction(ctx *cli.Context) error {
+	gen, err := readGenesisFlag(ctx)
+	if err != nil {
+		return err
+	}
+	if errs := gen.Validate(); len(errs) > 0 {
+		return errors.Wrap(joinErrors(errs), "invalid genesis file")
+	}
+	gene, err := genesis.NewCustomNet(gen)
+	if err != nil {
+		return errors.Wrap(err, "build genesis")
+	}
+	fmt.Printf("Genesis ID: %v\nChain tag:  0x%02x\n", gene.ID(), gene.ID()[31])
+	return nil
+}
+
+func readGenesisFlag(ctx *cli.Context) (*genesis.CustomGenesis, error) {
+	path := ctx.String(genesisFlag.Name)
+	if path == "" {
+		return nil, errors.New("genesis file required")
+	}
+	return readGenesisFile(path)
+}
+
+// genesisNode is an authority node of a generated genesis, whose keys are to be written in its config dir.
+type genesisNode struct {
+	dir         string
+	master      thor.Address
+	endorsor    thor.Address
+	masterKey   *ecdsa.PrivateKey
+	endorsorKey *ecdsa.PrivateKey // nil if the endorsor is given
+}
+
+// addGenesisAuthorities generates the keys of n authority nodes, in a sub directory of keysDir per node, and adds
+// the nodes to the genesis. The endorsors are funded with the proposer endorsement, a fresh one per node if the
+// endorsor is nil. The keys are only written by writeGenesisKeys, once the genesis is validated.
+func addGenesisAuthorities(gen *genesis.CustomGenesis, keysDir string, n int, endorsor *thor.Address) ([]genesisNode, error) {
+	endorsement := thor.InitialProposerEndorsement
+	if e := gen.Params.ProposerEndorsement; e != nil {
+		endorsement = (*big.Int)(e)
+	}
+
+	// endorsors need the endorsement balance for the nodes to be eligible proposers
+	var endorsors []thor.Address
+	if endorsor != nil {
+		endorsors = append(endorsors, *endorsor)
+	}
+
+	var nodes []genesisNode
+	for i := 1; i <= n; i++ {
+		node := genesisNode{dir: filepath.Join(keysDir, fmt.Sprintf("node%v", i))}
+		var err error
+		if node.masterKey, err = generateKey(filepath.Join(node.dir, "master.key")); err != nil {
+			return nil, errors.Wrap(err, "generate master key")
+		}
+		node.master = thor.Address(crypto.PubkeyToAddress(node.masterKey.PublicKey))
+		if endorsor != nil {
+			node.endorsor = *endorsor
+		} else {
+			if node.endorsorKey, err = generateKey(filepath.Join(node.dir, "endorsor.key")); err != nil {
+				return nil, errors.Wrap(err, "generate endorsor key")
+			}
+			node.endorsor = thor.Address(crypto.PubkeyToAddress(node.endorsorKey.PublicKey))
+			endorsors = append(endorsors, node.endorsor)
+		}
+
+		var identity thor.Bytes32
+		if _, err := rand.Read(identity[:]); err != nil {
+			return nil, errors.Wrap(err, "generate identity")
+		}
+		gen.Authority = append(gen.Authority, genesis.Authority{
+			MasterAddress:   node.master,
+			EndorsorAddress: node.endorsor,
+			Identity:        identity,
+		})
+		nodes = append(nodes, node)
+	}
+
+	for _, addr := range endorsors {
+		acc := findGenesisAccount(gen, addr)
+		if bal := (*big.Int)(acc.Balance); bal == nil || bal.Cmp(endorsement) < 0 {
+			acc.Balance = (*genesis.HexOrDecimal256)(new(big.Int).Set(endorsement))
+		}
+	}
+	return nodes, nil
+}
+
+// writeGenesisKeys writes the keys of the nodes in hex, in their config dirs.
+func writeGenesisKeys(nodes []genesisNode) error {
+	for _, node := range nodes {
+		if err := os.MkdirAll(node.dir, 0o700); err != nil {
+			return errors.Wrap(err, "create keys dir")
+		}
+		if err := crypto.SaveECDSA(filepath.Join(node.dir, "master.key"), node.masterKey); err != nil {
+			return errors.Wrap(err, "write master key")
+		}
+		if node.endorsorKey != nil {
+			if err := crypto.SaveECDSA(filepath.Join(node.dir, "endorsor.key"), node.endorsorKey); err != nil {
+				return errors.Wrap(err, "write endorsor key")
+			}
+		}
+	}
+	return nil
+}
+
+// defaultGenesisTemplate returns the template of a network with all forks enabled, launched now.
+func defaultGenesisTemplate() *genesis.CustomGenesis {
+	forkConfig := thor.SoloFork
+	maxBlockProposers := thor.InitialMaxBlockProposers
+	return &genesis.CustomGenesis{
+		LaunchTime: uint64(time.Now().Unix()) / thor.BlockInterval * thor.BlockInterval,
+		GasLimit:   thor.InitialGasLimit,
+		Params: genesis.Params{
+			RewardRatio:         (*genesis.HexOrDecimal256)(new(big.Int).Set(thor.InitialRewardRatio)),
+			BaseGasPrice:        (*genesis.HexOrDecimal256)(new(big.Int).Set(thor.InitialBaseGasPrice)),
+			ProposerEndorsement: (*genesis.HexOrDecimal256)(new(big.Int).Set(thor.InitialProposerEndorsement)),
+			MaxBlockProposers:   &maxBlockProposers,
+		},
+		ForkConfig: &forkConfig,
+	}
+}
+
+// generateKey generates a private key to be saved at path, as the master key of a node. Existing files are
+// never overwritten.
+func generateKey(path string) (*ecdsa.PrivateKey, error) {
+	if _, err := os.Stat(path); err == nil {
+		return nil, errors.Errorf("%v already exists", path)
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	return crypto.GenerateKey()
+}
+
+// findGenesisAccount returns the account of the address in the genesis, which is added if not found.
+func findGenesisAccount(gen *genesis.CustomGenesis, addr thor.Address) *genesis.Account {
+	for i := range gen.Accounts {
+		if gen.Accounts[i].Address == addr {
+			return &gen.Accounts[i]
+		}
+	}
+	gen.Accounts = append(gen.Accounts, genesis.Account{Address: addr})
+	return &gen.Accounts[len(gen.Accounts)-1]
+}
+
+// parseGenesisAlloc parses an account balance in the form of address=amount, the amount in wei, hex or decimal.
+func parseGenesisAlloc(s string) (thor.Address, *big.Int, error) {
+	parts := strings.SplitN(s, "=", 2)
+	if len(parts) != 2 {
+		return thor.Address{}, nil, errors.Errorf("invalid alloc %q, want address=amount", s)
+	}
+	addr, err := thor.ParseAddress(parts[0])
+	if err != nil {
+		return thor.Address{}, nil, errors.Wrapf(err, "invalid alloc %q", s)
+	}
+	amount, ok := math.ParseBig256(parts[1])
+	if !ok || amount.Sign() < 0 {
+		return thor.Address{}, nil, errors.Errorf("invalid alloc %q, amount must be a non-negative integer", s)
+	}
+	return addr, amount, nil
+}
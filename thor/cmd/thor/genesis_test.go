@@ -0,0 +1,42 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/urfave/cli.v1"
+)
+
+func TestGenesisNew(t *testing.T) {
+	flags := []cli.Flag{genesisTemplateFlag, genesisAuthoritiesFlag, genesisEndorsorFlag, genesisAllocFlag, genesisKeysDirFlag, genesisOutFlag}
+	dir := t.TempDir()
+	keysDir := filepath.Join(dir, "keys")
+	out := filepath.Join(dir, "genesis.json")
+
+	// no key is left behind by an invalid genesis
+	template := writeConfigFile(t, "template.json", `{"extraData": "an extra data over 28 bytes long"}`)
+	err := runApp(flags, []string{"--template", template, "--keys-dir", keysDir, "--out", out, "--authorities", "2"}, genesisNewAction)
+	assert.ErrorContains(t, err, "invalid genesis")
+	assert.NoDirExists(t, keysDir)
+	assert.NoFileExists(t, out)
+
+	require.NoError(t, runApp(flags, []string{"--keys-dir", keysDir, "--out", out, "--authorities", "2"}, genesisNewAction))
+	for _, node := range []string{"node1", "node2"} {
+		assert.FileExists(t, filepath.Join(keysDir, node, "master.key"))
+		assert.FileExists(t, filepath.Join(keysDir, node, "endorsor.key"))
+	}
+	gen, err := readGenesisFile(out)
+	require.NoError(t, err)
+	assert.Len(t, gen.Authority, 2)
+
+	// existing keys are never overwritten
+	err = runApp(flags, []string{"--keys-dir", keysDir, "--out", out}, genesisNewAction)
+	assert.ErrorContains(t, err, "already exists")
+}
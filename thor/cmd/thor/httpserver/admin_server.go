@@ -7,7 +7,6 @@ package httpserver
 
 import (
 	"log/slog"
-	"net"
 	"net/http"
 	"sync/atomic"
 	"time"
@@ -15,6 +14,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/vechain/thor/v2/api/admin"
 	"github.com/vechain/thor/v2/api/admin/health"
+	"github.com/vechain/thor/v2/api/admin/webhooks"
+	"github.com/vechain/thor/v2/api/middleware"
 	"github.com/vechain/thor/v2/chain"
 	"github.com/vechain/thor/v2/co"
 	"github.com/vechain/thor/v2/comm"
@@ -22,24 +23,27 @@ import (
 
 func StartAdminServer(
 	addr string,
+	tlsConfig *TLSConfig,
 	logLevel *slog.LevelVar,
 	repo *chain.Repository,
 	p2p *comm.Communicator,
 	apiLogs *atomic.Bool,
+	hooks *webhooks.Webhooks,
+	apiKeys *middleware.APIKeys,
 ) (string, func(), error) {
-	listener, err := net.Listen("tcp", addr)
+	listener, url, err := listen(addr, tlsConfig)
 	if err != nil {
 		return "", nil, errors.Wrapf(err, "listen admin API addr [%v]", addr)
 	}
 
-	adminHandler := admin.NewHTTPHandler(logLevel, health.New(repo, p2p), apiLogs)
+	adminHandler := admin.NewHTTPHandler(logLevel, health.New(repo, p2p), apiLogs, hooks, apiKeys)
 
 	srv := &http.Server{Handler: adminHandler, ReadHeaderTimeout: time.Second, ReadTimeout: 5 * time.Second}
 	var goes co.Goes
 	goes.Go(func() {
 		srv.Serve(listener)
 	})
-	return "http://" + listener.Addr().String() + "/admin", func() {
+	return url + "/admin", func() {
 		srv.Close()
 		goes.Wait()
 	}, nil
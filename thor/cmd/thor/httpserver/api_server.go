@@ -6,7 +6,6 @@
 package httpserver
 
 import (
-	"net"
 	"net/http"
 	"net/http/pprof"
 	"strings"
@@ -19,7 +18,9 @@ import (
 	"github.com/vechain/thor/v2/api"
 	"github.com/vechain/thor/v2/api/accounts"
 	"github.com/vechain/thor/v2/api/blocks"
+	"github.com/vechain/thor/v2/api/certificates"
 	"github.com/vechain/thor/v2/api/debug"
+	"github.com/vechain/thor/v2/api/delegator"
 	"github.com/vechain/thor/v2/api/doc"
 	"github.com/vechain/thor/v2/api/events"
 	"github.com/vechain/thor/v2/api/fees"
@@ -55,6 +56,7 @@ type APIConfig struct {
 	EnableReqLogger            *atomic.Bool
 	EnableMetrics              bool
 	LogsLimit                  uint64
+	LogsBudget                 logdb.QueryBudget
 	AllowedTracers             []string
 	SoloMode                   bool
 	EnableDeprecated           bool
@@ -62,6 +64,11 @@ type APIConfig struct {
 	APIBacktraceLimit          int
 	PriorityIncreasePercentage int
 	Timeout                    int
+	APIKeys                    *middleware.APIKeys
+	ResponseCacheSize          int
+	Delegator                  *delegator.Delegator
+	Certificates               certificates.Config
+	TLS                        *TLSConfig
 }
 
 func StartAPIServer(
@@ -75,7 +82,7 @@ func StartAPIServer(
 	forkConfig *thor.ForkConfig,
 	config APIConfig,
 ) (string, func(), error) {
-	listener, err := net.Listen("tcp", addr)
+	listener, url, err := listen(addr, config.TLS)
 	if err != nil {
 		return "", nil, errors.Wrapf(err, "listen API addr [%v]", addr)
 	}
@@ -100,11 +107,11 @@ func StartAPIServer(
 
 	accounts.New(repo, stater, config.CallGasLimit, forkConfig, bft, config.EnableDeprecated).Mount(router, "/accounts")
 	if !config.SkipLogs {
-		events.New(repo, logDB, config.LogsLimit).Mount(router, "/logs/event")
-		transfers.New(repo, logDB, config.LogsLimit).Mount(router, "/logs/transfer")
+		events.New(repo, logDB, config.LogsLimit, config.LogsBudget, bft).Mount(router, "/logs/event")
+		transfers.New(repo, logDB, config.LogsLimit, config.LogsBudget, bft).Mount(router, "/logs/transfer")
 	}
 	blocks.New(repo, bft).Mount(router, "/blocks")
-	transactions.New(repo, txPool).Mount(router, "/transactions")
+	transactions.New(repo, txPool, bft).Mount(router, "/transactions")
 	debug.New(repo, stater, forkConfig, bft,
 		config.CallGasLimit,
 		config.AllowCustomTracer,
@@ -117,6 +124,10 @@ func StartAPIServer(
 		PriorityIncreasePercentage: config.PriorityIncreasePercentage,
 		FixedCacheSize:             defaultFeeCacheSize,
 	}).Mount(router, "/fees")
+	certificates.New(repo, stater, forkConfig, config.CallGasLimit, config.Certificates).Mount(router, "/certificates")
+	if config.Delegator != nil {
+		delegator.NewAPI(config.Delegator).Mount(router, "/delegator")
+	}
 	subs := subscriptions.New(repo, origins, config.BacktraceLimit, txPool, config.EnableDeprecated)
 	subs.Mount(router, "/subscriptions")
 
@@ -146,16 +157,23 @@ func StartAPIServer(
 	router.Use(handlers.CompressHandler)
 	router.Use(handlers.CORS(
 		handlers.AllowedOrigins(origins),
-		handlers.AllowedHeaders([]string{"content-type", "x-genesis-id"}),
-		handlers.ExposedHeaders([]string{"x-genesis-id", "x-thorest-ver"}),
+		handlers.AllowedHeaders([]string{"content-type", "x-genesis-id", "x-api-key"}),
+		handlers.ExposedHeaders([]string{"x-genesis-id", "x-thorest-ver", "retry-after", api.LogsDowngradedRangeHeader}),
 	))
+	// api keys are checked inside the CORS handler, so rejected requests carry the CORS headers
+	if config.APIKeys != nil {
+		router.Use(middleware.HandleAPIKeys(config.APIKeys))
+	}
+	// the response cache is the innermost middleware, so cached responses are
+	// stored before compression
+	router.Use(middleware.HandleResponseCache(middleware.NewResponseCache(config.ResponseCacheSize)))
 
 	srv := &http.Server{Handler: router, ReadHeaderTimeout: time.Second, ReadTimeout: 5 * time.Second}
 	var goes co.Goes
 	goes.Go(func() {
 		srv.Serve(listener)
 	})
-	return "http://" + listener.Addr().String() + "/", func() {
+	return url + "/", func() {
 		srv.Close()
 		subs.Close()
 		goes.Wait()
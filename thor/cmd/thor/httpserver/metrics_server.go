@@ -6,7 +6,6 @@
 package httpserver
 
 import (
-	"net"
 	"net/http"
 	"time"
 
@@ -17,8 +16,8 @@ import (
 	"github.com/vechain/thor/v2/metrics"
 )
 
-func StartMetricsServer(addr string) (string, func(), error) {
-	listener, err := net.Listen("tcp", addr)
+func StartMetricsServer(addr string, tlsConfig *TLSConfig) (string, func(), error) {
+	listener, url, err := listen(addr, tlsConfig)
 	if err != nil {
 		return "", nil, errors.Wrapf(err, "listen metrics API addr [%v]", addr)
 	}
@@ -32,7 +31,7 @@ func StartMetricsServer(addr string) (string, func(), error) {
 	goes.Go(func() {
 		srv.Serve(listener)
 	})
-	return "http://" + listener.Addr().String() + "/metrics", func() {
+	return url + "/metrics", func() {
 		srv.Close()
 		goes.Wait()
 	}, nil
@@ -0,0 +1,60 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package httpserver
+
+import (
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/co"
+	"github.com/vechain/thor/v2/signer"
+)
+
+// StartSignerServer serves the signing service on a TCP address or, prefixed with unix://, a unix socket, which is
+// only accessible to the user running the service.
+func StartSignerServer(listen string, server *signer.Server) (string, func(), error) {
+	var (
+		listener net.Listener
+		err      error
+	)
+	if path, ok := strings.CutPrefix(listen, "unix://"); ok {
+		if path == "" {
+			return "", nil, errors.New("signer socket path required")
+		}
+		// remove the socket left by a previous run, never other files
+		if fi, err := os.Lstat(path); err == nil && fi.Mode()&os.ModeSocket != 0 {
+			if err := os.Remove(path); err != nil {
+				return "", nil, errors.Wrap(err, "remove stale signer socket")
+			}
+		}
+		if listener, err = net.Listen("unix", path); err != nil {
+			return "", nil, errors.Wrapf(err, "listen signer socket [%v]", path)
+		}
+		if err := os.Chmod(path, 0o600); err != nil {
+			listener.Close()
+			return "", nil, errors.Wrap(err, "restrict signer socket")
+		}
+	} else {
+		if listener, err = net.Listen("tcp", listen); err != nil {
+			return "", nil, errors.Wrapf(err, "listen signer addr [%v]", listen)
+		}
+		listen = "http://" + listener.Addr().String()
+	}
+
+	srv := &http.Server{Handler: server, ReadHeaderTimeout: time.Second, ReadTimeout: 5 * time.Second}
+	var goes co.Goes
+	goes.Go(func() {
+		srv.Serve(listener)
+	})
+	return listen, func() {
+		srv.Close()
+		goes.Wait()
+	}, nil
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package httpserver
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/log"
+)
+
+var tlsLogger = log.WithContext("pkg", "tls")
+
+// tlsReloadInterval is the min interval between two checks of the TLS files for changes.
+var tlsReloadInterval = time.Second
+
+// TLSConfig is the certificate and key files of a server, and the CA bundle of the client certificates it
+// requires if set. The files are reloaded once changed.
+type TLSConfig struct {
+	CertFile     string
+	KeyFile      string
+	ClientCAFile string
+}
+
+// listen listens on the TCP address, over TLS if the config is set, and returns the URL of the listener.
+func listen(addr string, config *TLSConfig) (net.Listener, string, error) {
+	var tlsConfig *tls.Config
+	if config != nil {
+		var err error
+		if tlsConfig, err = newTLSConfig(config); err != nil {
+			return nil, "", err
+		}
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, "", err
+	}
+	if tlsConfig == nil {
+		return listener, "http://" + listener.Addr().String(), nil
+	}
+	return tls.NewListener(listener, tlsConfig), "https://" + listener.Addr().String(), nil
+}
+
+// newTLSConfig loads the TLS files, and returns the server config which serves them as reloaded.
+func newTLSConfig(config *TLSConfig) (*tls.Config, error) {
+	r := &tlsReloader{config: *config}
+	stamp, err := r.stamp()
+	if err != nil {
+		return nil, err
+	}
+	if r.current, err = r.load(); err != nil {
+		return nil, err
+	}
+	r.loaded, r.checked = stamp, time.Now()
+	return &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		GetConfigForClient: r.getConfigForClient,
+	}, nil
+}
+
+// tlsReloader keeps the config of the TLS files, reloaded when their modification time or size change.
+type tlsReloader struct {
+	config TLSConfig
+
+	lock    sync.Mutex
+	current *tls.Config
+	loaded  string    // the stamp of the files loaded
+	checked time.Time // the last time the files were checked
+}
+
+func (r *tlsReloader) getConfigForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if now := time.Now(); now.Sub(r.checked) >= tlsReloadInterval {
+		r.checked = now
+		stamp, err := r.stamp()
+		if err != nil {
+			tlsLogger.Warn("failed to check TLS files, keep the loaded ones", "err", err)
+		} else if stamp != r.loaded {
+			// the files changed, replaced only when loaded successfully, they may be written one after the other
+			if current, err := r.load(); err != nil {
+				tlsLogger.Warn("failed to reload TLS files, keep the loaded ones", "err", err)
+			} else {
+				r.current, r.loaded = current, stamp
+				tlsLogger.Info("TLS files reloaded", "cert", r.config.CertFile)
+			}
+		}
+	}
+	return r.current, nil
+}
+
+// stamp returns the modification times and sizes of the files, which change with their content.
+func (r *tlsReloader) stamp() (string, error) {
+	var stamp string
+	for _, path := range []string{r.config.CertFile, r.config.KeyFile, r.config.ClientCAFile} {
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return "", err
+		}
+		stamp += fmt.Sprintf("%v:%v;", info.ModTime().UnixNano(), info.Size())
+	}
+	return stamp, nil
+}
+
+func (r *tlsReloader) load() (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(r.config.CertFile, r.config.KeyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "load TLS certificate")
+	}
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+	if r.config.ClientCAFile != "" {
+		data, err := os.ReadFile(r.config.ClientCAFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "load TLS client CA")
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, errors.Errorf("load TLS client CA: no certificate found in %v", r.config.ClientCAFile)
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return config, nil
+}
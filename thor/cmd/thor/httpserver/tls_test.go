@@ -0,0 +1,195 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package httpserver
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// newTestCert issues a certificate for localhost, self-signed if the issuer is nil.
+func newTestCert(t *testing.T, name string, issuer *testCert, isCA bool) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: name},
+		DNSNames:              []string{"localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+	parent, signer := template, key
+	if issuer != nil {
+		parent, signer = issuer.cert, issuer.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, signer)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCert{cert, key}
+}
+
+func (c *testCert) certPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw})
+}
+
+func (c *testCert) write(t *testing.T, certFile, keyFile string) {
+	der, err := x509.MarshalECPrivateKey(c.key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(certFile, c.certPEM(), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0o600))
+}
+
+func (c *testCert) tlsCert() tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{c.cert.Raw}, PrivateKey: c.key}
+}
+
+func startTestServer(t *testing.T, config *TLSConfig) string {
+	listener, url, err := listen("127.0.0.1:0", config)
+	require.NoError(t, err)
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("ok"))
+	}), ReadHeaderTimeout: time.Second}
+	go srv.Serve(listener)
+	t.Cleanup(func() { srv.Close() })
+	return url
+}
+
+func newTestClient(roots *x509.CertPool, certs ...tls.Certificate) *http.Client {
+	return &http.Client{Transport: &http.Transport{
+		TLSClientConfig: &tls.Config{RootCAs: roots, Certificates: certs, ServerName: "localhost"},
+	}}
+}
+
+// servedCert returns the certificate the server presents, without verifying it.
+func servedCert(t *testing.T, url string) *x509.Certificate {
+	conn, err := tls.Dial("tcp", url[len("https://"):], &tls.Config{InsecureSkipVerify: true}) //nolint:gosec
+	require.NoError(t, err)
+	defer conn.Close()
+	return conn.ConnectionState().PeerCertificates[0]
+}
+
+func TestListenPlain(t *testing.T) {
+	url := startTestServer(t, nil)
+	assert.Regexp(t, `^http://127\.0\.0\.1:\d+$`, url)
+
+	res, err := http.Get(url)
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+}
+
+func TestListenTLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+
+	ca := newTestCert(t, "ca", nil, true)
+	first := newTestCert(t, "first", ca, false)
+	first.write(t, certFile, keyFile)
+
+	url := startTestServer(t, &TLSConfig{CertFile: certFile, KeyFile: keyFile})
+	assert.Regexp(t, `^https://127\.0\.0\.1:\d+$`, url)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	res, err := newTestClient(roots).Get(url)
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "first", servedCert(t, url).Subject.CommonName)
+
+	// plain HTTP is refused
+	res, err = http.Get("http" + url[len("https"):])
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	t.Run("reload", func(t *testing.T) {
+		defer func(d time.Duration) { tlsReloadInterval = d }(tlsReloadInterval)
+		tlsReloadInterval = 0
+
+		second := newTestCert(t, "second", ca, false)
+		second.write(t, certFile, keyFile)
+		// the file size may be unchanged, make sure the modification time is
+		later := time.Now().Add(time.Minute)
+		require.NoError(t, os.Chtimes(certFile, later, later))
+		assert.Equal(t, "second", servedCert(t, url).Subject.CommonName)
+
+		// a broken file keeps the loaded certificate
+		require.NoError(t, os.WriteFile(keyFile, []byte("broken"), 0o600))
+		assert.Equal(t, "second", servedCert(t, url).Subject.CommonName)
+	})
+}
+
+func TestListenMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	caFile := filepath.Join(dir, "ca.pem")
+
+	ca := newTestCert(t, "ca", nil, true)
+	newTestCert(t, "server", ca, false).write(t, certFile, keyFile)
+	clientCA := newTestCert(t, "client-ca", nil, true)
+	require.NoError(t, os.WriteFile(caFile, clientCA.certPEM(), 0o600))
+
+	url := startTestServer(t, &TLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: caFile})
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	// the client certificate issued by the client CA is accepted
+	res, err := newTestClient(roots, newTestCert(t, "client", clientCA, false).tlsCert()).Get(url)
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	// no client certificate
+	_, err = newTestClient(roots).Get(url)
+	assert.Error(t, err)
+
+	// client certificate of another CA
+	_, err = newTestClient(roots, newTestCert(t, "other", ca, false).tlsCert()).Get(url)
+	assert.Error(t, err)
+}
+
+func TestListenTLSInvalidFiles(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+
+	_, _, err := listen("127.0.0.1:0", &TLSConfig{CertFile: certFile, KeyFile: keyFile})
+	assert.Error(t, err)
+
+	newTestCert(t, "server", nil, false).write(t, certFile, keyFile)
+	caFile := filepath.Join(dir, "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, []byte("no certificate"), 0o600))
+	_, _, err = listen("127.0.0.1:0", &TLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: caFile})
+	assert.ErrorContains(t, err, "no certificate found")
+}
@@ -0,0 +1,227 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/mattn/go-isatty"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/wallet"
+	"gopkg.in/urfave/cli.v1"
+)
+
+// secretReader reads secrets from the terminal without echo, or line by line from stdin when it's not a terminal,
+// so that several secrets can be piped in.
+type secretReader struct {
+	stdin *bufio.Reader
+}
+
+func newSecretReader() *secretReader {
+	return &secretReader{stdin: bufio.NewReader(os.Stdin)}
+}
+
+func (r *secretReader) read(prompt string) (string, error) {
+	if isatty.IsTerminal(os.Stdin.Fd()) {
+		return readPasswordFromNewTTY(prompt)
+	}
+	line, err := r.stdin.ReadString('\n')
+	if err != nil && !(err == io.EOF && line != "") {
+		return "", errors.Wrap(err, "read stdin")
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// readNew reads a new secret, which must be confirmed when typed in the terminal.
+func (r *secretReader) readNew(prompt string) (string, error) {
+	secret, err := r.read(prompt)
+	if err != nil {
+		return "", err
+	}
+	if secret == "" {
+		return "", errors.New("non-empty passphrase required")
+	}
+	if isatty.IsTerminal(os.Stdin.Fd()) {
+		confirm, err := r.read("Confirm passphrase: ")
+		if err != nil {
+			return "", err
+		}
+		if secret != confirm {
+			return "", errors.New("passphrase confirmation mismatch")
+		}
+	}
+	return secret, nil
+}
+
+func (r *secretReader) readWallet(ctx *cli.Context) (*wallet.Wallet, error) {
+	mnemonic, err := r.read("Enter mnemonic: ")
+	if err != nil {
+		return nil, err
+	}
+	var passphrase string
+	if ctx.Bool(keysBIP39PassphraseFlag.Name) {
+		if passphrase, err = r.read("Enter BIP-39 passphrase: "); err != nil {
+			return nil, err
+		}
+	}
+	return wallet.New(mnemonic, passphrase)
+}
+
+// keyIndexes returns the range of indexes to derive, set by the from and count flags.
+func keyIndexes(ctx *cli.Context) (uint32, uint32, error) {
+	from, count := ctx.Uint64(keysFromFlag.Name), ctx.Uint64(keysCountFlag.Name)
+	if count == 0 {
+		return 0, 0, errors.Errorf("flag %v must be positive", keysCountFlag.Name)
+	}
+	if from+count > wallet.HardenedOffset {
+		return 0, 0, errors.Errorf("indexes exceed the non-hardened range [0, %v)", uint32(wallet.HardenedOffset))
+	}
+	return uint32(from), uint32(count), nil
+}
+
+func openKeyStore(ctx *cli.Context) (*wallet.KeyStore, error) {
+	dir := ctx.String(keysKeystoreDirFlag.Name)
+	if dir == "" {
+		configDir, err := makeConfigDir(ctx)
+		if err != nil {
+			return nil, err
+		}
+		dir = filepath.Join(configDir, "keystore")
+	}
+	return wallet.NewKeyStore(dir)
+}
+
+func keysMnemonicAction(ctx *cli.Context) error {
+	mnemonic, err := wallet.NewMnemonic(ctx.Int(keysWordsFlag.Name))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Println(mnemonic)
+	return err
+}
+
+func keysDeriveAction(ctx *cli.Context) error {
+	from, count, err := keyIndexes(ctx)
+	if err != nil {
+		return err
+	}
+	w, err := newSecretReader().readWallet(ctx)
+	if err != nil {
+		return err
+	}
+	private := ctx.Bool(keysPrivateFlag.Name)
+	for i := from; i < from+count; i++ {
+		key, err := w.Key(i)
+		if err != nil {
+			return err
+		}
+		line := fmt.Sprintf("%v\t%v", wallet.VeChainPath(i), thor.Address(crypto.PubkeyToAddress(key.PublicKey)))
+		if private {
+			line += "\t" + hexutil.Encode(crypto.FromECDSA(key))
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
+
+func keysFindAction(ctx *cli.Context) error {
+	addr, err := thor.ParseAddress(ctx.String(keysAddressFlag.Name))
+	if err != nil {
+		return errors.Wrap(err, "parse address")
+	}
+	limit := ctx.Uint64(keysMaxFlag.Name)
+	if limit > wallet.HardenedOffset {
+		limit = wallet.HardenedOffset
+	}
+	w, err := newSecretReader().readWallet(ctx)
+	if err != nil {
+		return err
+	}
+	index, found, err := w.Find(addr, uint32(limit))
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errors.Errorf("address %v not found in the first %v indexes", addr, limit)
+	}
+	fmt.Println(wallet.VeChainPath(index))
+	return nil
+}
+
+func keysImportAction(ctx *cli.Context) error {
+	ks, err := openKeyStore(ctx)
+	if err != nil {
+		return err
+	}
+	reader := newSecretReader()
+
+	if files := ctx.StringSlice(keysKeystoreFileFlag.Name); len(files) > 0 {
+		for _, file := range files {
+			keyjson, err := os.ReadFile(file)
+			if err != nil {
+				return err
+			}
+			passphrase, err := reader.read(fmt.Sprintf("Enter passphrase of %v: ", filepath.Base(file)))
+			if err != nil {
+				return err
+			}
+			addr, err := ks.ImportJSON(keyjson, passphrase)
+			if err != nil {
+				return errors.WithMessage(err, file)
+			}
+			fmt.Println("Key imported:", addr)
+		}
+		return nil
+	}
+
+	from, count, err := keyIndexes(ctx)
+	if err != nil {
+		return err
+	}
+	w, err := reader.readWallet(ctx)
+	if err != nil {
+		return err
+	}
+	passphrase, err := reader.readNew("Enter passphrase of the keystore files: ")
+	if err != nil {
+		return err
+	}
+	for i := from; i < from+count; i++ {
+		key, err := w.Key(i)
+		if err != nil {
+			return err
+		}
+		addr, err := ks.Import(key, passphrase)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Key imported: %v\t%v\n", wallet.VeChainPath(i), addr)
+	}
+	return nil
+}
+
+func keysListAction(ctx *cli.Context) error {
+	ks, err := openKeyStore(ctx)
+	if err != nil {
+		return err
+	}
+	addrs, err := ks.List()
+	if err != nil {
+		return err
+	}
+	for _, addr := range addrs {
+		fmt.Println(addr)
+	}
+	return nil
+}
@@ -6,7 +6,7 @@
 package main
 
 import (
-	"encoding/json"
+	"crypto/ecdsa"
 	"fmt"
 	"io"
 	"os"
@@ -14,11 +14,10 @@ import (
 	"sync/atomic"
 	"time"
 
-	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/mattn/go-isatty"
-	"github.com/pborman/uuid"
 	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/api/admin/webhooks"
 	"github.com/vechain/thor/v2/bft"
 	"github.com/vechain/thor/v2/cmd/thor/httpserver"
 	"github.com/vechain/thor/v2/cmd/thor/node"
@@ -32,6 +31,7 @@ import (
 	"github.com/vechain/thor/v2/state"
 	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/txpool"
+	"github.com/vechain/thor/v2/wallet"
 	"gopkg.in/urfave/cli.v1"
 
 	// Force-load the tracer engines to trigger registration
@@ -60,91 +60,142 @@ func fullVersion() string {
 	return fmt.Sprintf("%s-%s-%s", version, gitCommit, versionMeta)
 }
 
+// flags of the node, which can be set by environment variables and the config file as well.
+var nodeFlags = []cli.Flag{
+	configFileFlag,
+	networkFlag,
+	apiTxpoolFlag,
+	configDirFlag,
+	masterKeyStdinFlag,
+	masterKeyPassphraseFileFlag,
+	masterKeyRequireEncryptedFlag,
+	masterSignerFlag,
+	dataDirFlag,
+	cacheFlag,
+	beneficiaryFlag,
+	targetGasLimitFlag,
+	apiAddrFlag,
+	apiTLSCertFlag,
+	apiTLSKeyFlag,
+	apiCorsFlag,
+	apiTimeoutFlag,
+	apiCallGasLimitFlag,
+	apiBacktraceLimitFlag,
+	apiAllowCustomTracerFlag,
+	apiEnableDeprecatedFlag,
+	enableAPILogsFlag,
+	apiLogsLimitFlag,
+	apiLogsMaxCostFlag,
+	apiLogsDowngradeFlag,
+	apiLogsQueryTimeoutFlag,
+	apiLogsSlowQueryFlag,
+	apiCacheSizeFlag,
+	apiPriorityFeesPercentageFlag,
+	apiCertDomainsFlag,
+	apiCertMaxAgeFlag,
+	apiKeysFlag,
+	delegatorPolicyFlag,
+	delegatorSignerFlag,
+	verbosityFlag,
+	jsonLogsFlag,
+	maxPeersFlag,
+	p2pPortFlag,
+	natFlag,
+	bootNodeFlag,
+	allowedPeersFlag,
+	skipLogsFlag,
+	pprofFlag,
+	verifyLogsFlag,
+	disablePrunerFlag,
+	enableMetricsFlag,
+	metricsAddrFlag,
+	metricsTLSCertFlag,
+	metricsTLSKeyFlag,
+	adminAddrFlag,
+	adminTLSCertFlag,
+	adminTLSKeyFlag,
+	adminTLSClientCAFlag,
+	enableAdminFlag,
+	txPoolLimitPerAccountFlag,
+	allowedTracersFlag,
+	minEffectivePriorityFeeFlag,
+}
+
+// flags of the solo mode, which can be set by environment variables and the config file as well.
+var soloFlags = []cli.Flag{
+	configFileFlag,
+	genesisFlag,
+	dataDirFlag,
+	cacheFlag,
+	apiTxpoolFlag,
+	apiAddrFlag,
+	apiTLSCertFlag,
+	apiTLSKeyFlag,
+	apiCorsFlag,
+	apiTimeoutFlag,
+	apiCallGasLimitFlag,
+	apiBacktraceLimitFlag,
+	apiAllowCustomTracerFlag,
+	apiEnableDeprecatedFlag,
+	enableAPILogsFlag,
+	apiLogsLimitFlag,
+	apiLogsMaxCostFlag,
+	apiLogsDowngradeFlag,
+	apiLogsQueryTimeoutFlag,
+	apiLogsSlowQueryFlag,
+	apiCacheSizeFlag,
+	apiPriorityFeesPercentageFlag,
+	apiCertDomainsFlag,
+	apiCertMaxAgeFlag,
+	apiKeysFlag,
+	delegatorPolicyFlag,
+	delegatorSignerFlag,
+	onDemandFlag,
+	blockInterval,
+	persistFlag,
+	gasLimitFlag,
+	verbosityFlag,
+	jsonLogsFlag,
+	pprofFlag,
+	verifyLogsFlag,
+	skipLogsFlag,
+	txPoolLimitFlag,
+	txPoolLimitPerAccountFlag,
+	disablePrunerFlag,
+	enableMetricsFlag,
+	metricsAddrFlag,
+	metricsTLSCertFlag,
+	metricsTLSKeyFlag,
+	adminAddrFlag,
+	adminTLSCertFlag,
+	adminTLSKeyFlag,
+	adminTLSClientCAFlag,
+	enableAdminFlag,
+	allowedTracersFlag,
+	minEffectivePriorityFeeFlag,
+}
+
 func main() {
 	app := cli.App{
 		Version:   fullVersion(),
 		Name:      "Thor",
 		Usage:     "Node of VeChain Thor Network",
 		Copyright: fmt.Sprintf("2018-%s VeChain Foundation <https://vechain.org/>", copyrightYear),
-		Flags: []cli.Flag{
-			networkFlag,
-			apiTxpoolFlag,
-			configDirFlag,
-			masterKeyStdinFlag,
-			dataDirFlag,
-			cacheFlag,
-			beneficiaryFlag,
-			targetGasLimitFlag,
-			apiAddrFlag,
-			apiCorsFlag,
-			apiTimeoutFlag,
-			apiCallGasLimitFlag,
-			apiBacktraceLimitFlag,
-			apiAllowCustomTracerFlag,
-			apiEnableDeprecatedFlag,
-			enableAPILogsFlag,
-			apiLogsLimitFlag,
-			apiPriorityFeesPercentageFlag,
-			verbosityFlag,
-			jsonLogsFlag,
-			maxPeersFlag,
-			p2pPortFlag,
-			natFlag,
-			bootNodeFlag,
-			allowedPeersFlag,
-			skipLogsFlag,
-			pprofFlag,
-			verifyLogsFlag,
-			disablePrunerFlag,
-			enableMetricsFlag,
-			metricsAddrFlag,
-			adminAddrFlag,
-			enableAdminFlag,
-			txPoolLimitPerAccountFlag,
-			allowedTracersFlag,
-			minEffectivePriorityFeeFlag,
-		},
-		Action: defaultAction,
+		Flags:     withEnvVars(nodeFlags),
+		Action:    defaultAction,
 		Commands: []cli.Command{
 			{
-				Name:  "solo",
-				Usage: "client runs in solo mode for test & dev",
-				Flags: []cli.Flag{
-					genesisFlag,
-					dataDirFlag,
-					cacheFlag,
-					apiTxpoolFlag,
-					apiAddrFlag,
-					apiCorsFlag,
-					apiTimeoutFlag,
-					apiCallGasLimitFlag,
-					apiBacktraceLimitFlag,
-					apiAllowCustomTracerFlag,
-					apiEnableDeprecatedFlag,
-					enableAPILogsFlag,
-					apiLogsLimitFlag,
-					apiPriorityFeesPercentageFlag,
-					onDemandFlag,
-					blockInterval,
-					persistFlag,
-					gasLimitFlag,
-					verbosityFlag,
-					jsonLogsFlag,
-					pprofFlag,
-					verifyLogsFlag,
-					skipLogsFlag,
-					txPoolLimitFlag,
-					txPoolLimitPerAccountFlag,
-					disablePrunerFlag,
-					enableMetricsFlag,
-					metricsAddrFlag,
-					adminAddrFlag,
-					enableAdminFlag,
-					allowedTracersFlag,
-					minEffectivePriorityFeeFlag,
-				},
+				Name:   "solo",
+				Usage:  "client runs in solo mode for test & dev",
+				Flags:  withEnvVars(soloFlags),
 				Action: soloAction,
 			},
+			{
+				Name:   "dumpconfig",
+				Usage:  "print the effective configuration of the node, or of solo mode, as a TOML config file",
+				Flags:  append(withEnvVars(mergeFlags(nodeFlags, soloFlags)), dumpConfigSoloFlag),
+				Action: dumpConfigAction,
+			},
 			{
 				Name:  "master-key",
 				Usage: "master key management",
@@ -152,9 +203,226 @@ func main() {
 					configDirFlag,
 					importMasterKeyFlag,
 					exportMasterKeyFlag,
+					encryptMasterKeyFlag,
+					masterKeyPassphraseFileFlag,
 				},
 				Action: masterKeyAction,
 			},
+			{
+				Name:  "keys",
+				Usage: "HD wallet and keystore management, on the path m/44'/818'/0'/0",
+				Subcommands: []cli.Command{
+					{
+						Name:   "mnemonic",
+						Usage:  "generate a new BIP-39 mnemonic",
+						Flags:  []cli.Flag{keysWordsFlag},
+						Action: keysMnemonicAction,
+					},
+					{
+						Name:  "derive",
+						Usage: "print the addresses derived from a mnemonic",
+						Flags: []cli.Flag{
+							keysBIP39PassphraseFlag,
+							keysFromFlag,
+							keysCountFlag,
+							keysPrivateFlag,
+						},
+						Action: keysDeriveAction,
+					},
+					{
+						Name:  "find",
+						Usage: "find the derivation path of an address of a mnemonic",
+						Flags: []cli.Flag{
+							keysBIP39PassphraseFlag,
+							keysAddressFlag,
+							keysMaxFlag,
+						},
+						Action: keysFindAction,
+					},
+					{
+						Name:  "import",
+						Usage: "import keys derived from a mnemonic, or keystore v3 files, into the keystore",
+						Flags: []cli.Flag{
+							configDirFlag,
+							keysKeystoreDirFlag,
+							keysBIP39PassphraseFlag,
+							keysFromFlag,
+							keysCountFlag,
+							keysKeystoreFileFlag,
+						},
+						Action: keysImportAction,
+					},
+					{
+						Name:  "list",
+						Usage: "list the addresses of the keys in the keystore",
+						Flags: []cli.Flag{
+							configDirFlag,
+							keysKeystoreDirFlag,
+						},
+						Action: keysListAction,
+					},
+				},
+			},
+			{
+				Name:  "signer",
+				Usage: "serve the master key as a remote signer of blocks, gas payer delegations and VRF proofs",
+				Flags: []cli.Flag{
+					configDirFlag,
+					masterKeyStdinFlag,
+					masterKeyPassphraseFileFlag,
+					masterKeyRequireEncryptedFlag,
+					signerListenFlag,
+					signerAllowFlag,
+					signerAuditLogFlag,
+					verbosityFlag,
+					jsonLogsFlag,
+				},
+				Action: signerAction,
+			},
+			{
+				Name:  "export",
+				Usage: "export blocks of the chain to a file",
+				Flags: []cli.Flag{
+					networkFlag,
+					soloChainFlag,
+					genesisFlag,
+					dataDirFlag,
+					cacheFlag,
+					disablePrunerFlag,
+					exportFromFlag,
+					exportToFlag,
+					exportOutFlag,
+					exportReceiptsFlag,
+				},
+				Action: exportAction,
+			},
+			{
+				Name:  "import",
+				Usage: "import blocks of the chain from a file",
+				Flags: []cli.Flag{
+					networkFlag,
+					soloChainFlag,
+					genesisFlag,
+					dataDirFlag,
+					cacheFlag,
+					disablePrunerFlag,
+					skipLogsFlag,
+					importInFlag,
+				},
+				Action: importAction,
+			},
+			{
+				Name:  "replay",
+				Usage: "re-execute blocks of the chain and verify their state and receipts roots",
+				Flags: []cli.Flag{
+					networkFlag,
+					soloChainFlag,
+					genesisFlag,
+					dataDirFlag,
+					cacheFlag,
+					disablePrunerFlag,
+					replayFromFlag,
+					replayToFlag,
+				},
+				Action: replayAction,
+			},
+			{
+				Name:  "state",
+				Usage: "state dump & restore",
+				Subcommands: []cli.Command{
+					{
+						Name:  "dump",
+						Usage: "dump the accounts of the state at a block",
+						Flags: []cli.Flag{
+							networkFlag,
+							soloChainFlag,
+							genesisFlag,
+							dataDirFlag,
+							cacheFlag,
+							disablePrunerFlag,
+							stateRevisionFlag,
+							stateAddressFlag,
+							stateNDJSONFlag,
+							stateOutFlag,
+						},
+						Action: stateDumpAction,
+					},
+					{
+						Name:  "load",
+						Usage: "turn a state dump into a custom genesis",
+						Flags: []cli.Flag{
+							stateInFlag,
+							stateTemplateFlag,
+							stateOutFlag,
+						},
+						Action: stateLoadAction,
+					},
+				},
+			},
+			{
+				Name:  "genesis",
+				Usage: "custom genesis authoring",
+				Subcommands: []cli.Command{
+					{
+						Name:  "new",
+						Usage: "create a custom genesis with fresh authority nodes",
+						Flags: []cli.Flag{
+							genesisTemplateFlag,
+							genesisAuthoritiesFlag,
+							genesisEndorsorFlag,
+							genesisAllocFlag,
+							genesisKeysDirFlag,
+							genesisOutFlag,
+						},
+						Action: genesisNewAction,
+					},
+					{
+						Name:   "validate",
+						Usage:  "check a custom genesis file and report all errors",
+						Flags:  []cli.Flag{genesisFlag},
+						Action: genesisValidateAction,
+					},
+					{
+						Name:   "id",
+						Usage:  "print the genesis block ID and chain tag of a custom genesis file",
+						Flags:  []cli.Flag{genesisFlag},
+						Action: genesisIDAction,
+					},
+				},
+			},
+			{
+				Name:      "devnet",
+				Usage:     "run a local network of authority nodes, as child processes wired together over localhost",
+				ArgsUsage: "[-- flags passed to every node]",
+				Flags: []cli.Flag{
+					devnetNodesFlag,
+					devnetDirFlag,
+					devnetAPIPortFlag,
+					p2pPortFlag,
+					genesisTemplateFlag,
+					genesisAllocFlag,
+				},
+				Action: devnetAction,
+			},
+			{
+				Name:  "db",
+				Usage: "database maintenance",
+				Subcommands: []cli.Command{
+					{
+						Name:  "inspect",
+						Usage: "report the space used by each part of the databases",
+						Flags: []cli.Flag{
+							networkFlag,
+							soloChainFlag,
+							genesisFlag,
+							dataDirFlag,
+							cacheFlag,
+							disablePrunerFlag,
+						},
+						Action: dbInspectAction,
+					},
+				},
+			},
 		},
 	}
 
@@ -165,6 +433,9 @@ func main() {
 }
 
 func defaultAction(ctx *cli.Context) error {
+	if err := loadConfigFile(ctx, nodeFlags, "solo", soloFlags); err != nil {
+		return err
+	}
 	exitSignal := handleExitSignal()
 
 	defer func() { log.Info("exited") }()
@@ -180,7 +451,11 @@ func defaultAction(ctx *cli.Context) error {
 	metricsURL := ""
 	if enableMetrics {
 		metrics.InitializePrometheusMetrics()
-		url, closeFunc, err := httpserver.StartMetricsServer(ctx.String(metricsAddrFlag.Name))
+		tlsConfig, err := makeTLSConfig(ctx, metricsTLSCertFlag, metricsTLSKeyFlag, "")
+		if err != nil {
+			return err
+		}
+		url, closeFunc, err := httpserver.StartMetricsServer(ctx.String(metricsAddrFlag.Name), tlsConfig)
 		if err != nil {
 			return fmt.Errorf("unable to start metrics server - %w", err)
 		}
@@ -247,13 +522,30 @@ func defaultAction(ctx *cli.Context) error {
 	adminURL := ""
 	logAPIRequests := &atomic.Bool{}
 	logAPIRequests.Store(ctx.Bool(enableAPILogsFlag.Name))
+	apiKeys, err := loadAPIKeys(ctx)
+	if err != nil {
+		return err
+	}
 	if ctx.Bool(enableAdminFlag.Name) {
+		hooks, err := webhooks.New(repo, mainDB)
+		if err != nil {
+			return errors.Wrap(err, "init webhooks")
+		}
+		defer func() { log.Info("stopping webhooks..."); hooks.Close() }()
+
+		tlsConfig, err := makeTLSConfig(ctx, adminTLSCertFlag, adminTLSKeyFlag, ctx.String(adminTLSClientCAFlag.Name))
+		if err != nil {
+			return err
+		}
 		url, closeFunc, err := httpserver.StartAdminServer(
 			ctx.String(adminAddrFlag.Name),
+			tlsConfig,
 			logLevel,
 			repo,
 			p2pCommunicator.Communicator(),
 			logAPIRequests,
+			hooks,
+			apiKeys,
 		)
 		if err != nil {
 			return fmt.Errorf("unable to start admin server - %w", err)
@@ -267,6 +559,21 @@ func defaultAction(ctx *cli.Context) error {
 		return errors.Wrap(err, "init bft engine")
 	}
 
+	apiConfig := makeAPIConfig(ctx, logAPIRequests, apiKeys, false)
+	if apiConfig.TLS, err = makeTLSConfig(ctx, apiTLSCertFlag, apiTLSKeyFlag, ""); err != nil {
+		return err
+	}
+	apiConfig.Delegator, err = newDelegator(ctx, repo, mainDB, forkConfig, func() (*ecdsa.PrivateKey, error) {
+		configDir, err := makeConfigDir(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return loadOrGeneratePrivateKey(filepath.Join(configDir, "delegator.key"))
+	})
+	if err != nil {
+		return err
+	}
+
 	apiURL, srvCloser, err := httpserver.StartAPIServer(
 		ctx.String(apiAddrFlag.Name),
 		repo,
@@ -276,7 +583,7 @@ func defaultAction(ctx *cli.Context) error {
 		bftEngine,
 		p2pCommunicator.Communicator(),
 		forkConfig,
-		makeAPIConfig(ctx, logAPIRequests, false),
+		apiConfig,
 	)
 	if err != nil {
 		return err
@@ -321,6 +628,12 @@ func defaultAction(ctx *cli.Context) error {
 }
 
 func soloAction(ctx *cli.Context) error {
+	if err := loadConfigFile(ctx, soloFlags, "node", nodeFlags); err != nil {
+		return err
+	}
+	if err := validateSoloFlags(ctx); err != nil {
+		return err
+	}
 	exitSignal := handleExitSignal()
 	defer func() { log.Info("exited") }()
 
@@ -331,18 +644,23 @@ func soloAction(ctx *cli.Context) error {
 
 	logLevel := initLogger(lvl, ctx.Bool(jsonLogsFlag.Name))
 
+	if ctx.IsSet(dataDirFlag.Name) && !ctx.Bool(persistFlag.Name) {
+		log.Warn("data-dir is only used with persist")
+	}
+
 	onDemandBlockProduction := ctx.Bool(onDemandFlag.Name)
 	blockProductionInterval := ctx.Uint64(blockInterval.Name)
-	if blockProductionInterval == 0 {
-		return errors.New("block-interval cannot be zero")
-	}
 
 	// enable metrics as soon as possible
 	enableMetrics := ctx.Bool(enableMetricsFlag.Name)
 	metricsURL := ""
 	if enableMetrics {
 		metrics.InitializePrometheusMetrics()
-		url, closeFunc, err := httpserver.StartMetricsServer(ctx.String(metricsAddrFlag.Name))
+		tlsConfig, err := makeTLSConfig(ctx, metricsTLSCertFlag, metricsTLSKeyFlag, "")
+		if err != nil {
+			return err
+		}
+		url, closeFunc, err := httpserver.StartMetricsServer(ctx.String(metricsAddrFlag.Name), tlsConfig)
 		if err != nil {
 			return fmt.Errorf("unable to start metrics server - %w", err)
 		}
@@ -400,13 +718,30 @@ func soloAction(ctx *cli.Context) error {
 	adminURL := ""
 	logAPIRequests := &atomic.Bool{}
 	logAPIRequests.Store(ctx.Bool(enableAPILogsFlag.Name))
+	apiKeys, err := loadAPIKeys(ctx)
+	if err != nil {
+		return err
+	}
 	if ctx.Bool(enableAdminFlag.Name) {
+		hooks, err := webhooks.New(repo, mainDB)
+		if err != nil {
+			return errors.Wrap(err, "init webhooks")
+		}
+		defer func() { log.Info("stopping webhooks..."); hooks.Close() }()
+
+		tlsConfig, err := makeTLSConfig(ctx, adminTLSCertFlag, adminTLSKeyFlag, ctx.String(adminTLSClientCAFlag.Name))
+		if err != nil {
+			return err
+		}
 		url, closeFunc, err := httpserver.StartAdminServer(
 			ctx.String(adminAddrFlag.Name),
+			tlsConfig,
 			logLevel,
 			repo,
 			nil,
 			logAPIRequests,
+			hooks,
+			apiKeys,
 		)
 		if err != nil {
 			return fmt.Errorf("unable to start admin server - %w", err)
@@ -437,6 +772,18 @@ func soloAction(ctx *cli.Context) error {
 	txPool := txpool.New(repo, state.NewStater(mainDB), txPoolOption, forkConfig)
 	defer func() { log.Info("closing tx pool..."); txPool.Close() }()
 
+	apiConfig := makeAPIConfig(ctx, logAPIRequests, apiKeys, true)
+	if apiConfig.TLS, err = makeTLSConfig(ctx, apiTLSCertFlag, apiTLSKeyFlag, ""); err != nil {
+		return err
+	}
+	// the first dev account pays the gas in solo mode, unless a remote signer is set
+	apiConfig.Delegator, err = newDelegator(ctx, repo, mainDB, forkConfig, func() (*ecdsa.PrivateKey, error) {
+		return genesis.DevAccounts()[0].PrivateKey, nil
+	})
+	if err != nil {
+		return err
+	}
+
 	apiURL, srvCloser, err := httpserver.StartAPIServer(
 		ctx.String(apiAddrFlag.Name),
 		repo,
@@ -446,18 +793,13 @@ func soloAction(ctx *cli.Context) error {
 		bft.NewMockedEngine(repo.GenesisBlock().Header().ID()),
 		&solo.Communicator{},
 		forkConfig,
-		makeAPIConfig(ctx, logAPIRequests, true),
+		apiConfig,
 	)
 	if err != nil {
 		return err
 	}
 	defer func() { log.Info("stopping API server..."); srvCloser() }()
 
-	blockInterval := ctx.Uint64(blockInterval.Name)
-	if blockInterval == 0 {
-		return errors.New("block-interval cannot be zero")
-	}
-
 	printStartupMessage2(gene, apiURL, "", metricsURL, adminURL)
 
 	if !ctx.Bool(disablePrunerFlag.Name) {
@@ -489,22 +831,21 @@ func soloAction(ctx *cli.Context) error {
 func masterKeyAction(ctx *cli.Context) error {
 	hasImportFlag := ctx.Bool(importMasterKeyFlag.Name)
 	hasExportFlag := ctx.Bool(exportMasterKeyFlag.Name)
+	hasEncryptFlag := ctx.Bool(encryptMasterKeyFlag.Name)
 	if hasImportFlag && hasExportFlag {
 		return fmt.Errorf("flag %s and %s are exclusive", importMasterKeyFlag.Name, exportMasterKeyFlag.Name)
 	}
+	if hasExportFlag && hasEncryptFlag {
+		return fmt.Errorf("flag %s and %s are exclusive", exportMasterKeyFlag.Name, encryptMasterKeyFlag.Name)
+	}
 
 	keyPath, err := masterKeyPath(ctx)
 	if err != nil {
 		return err
 	}
-
-	if !hasImportFlag && !hasExportFlag {
-		masterKey, err := loadOrGeneratePrivateKey(keyPath)
-		if err != nil {
-			return err
-		}
-		fmt.Println("Master:", thor.Address(crypto.PubkeyToAddress(masterKey.PublicKey)))
-		return nil
+	keystorePath, err := masterKeystorePath(ctx)
+	if err != nil {
+		return err
 	}
 
 	if hasImportFlag {
@@ -516,61 +857,136 @@ func masterKeyAction(ctx *cli.Context) error {
 			return err
 		}
 
-		if err := json.Unmarshal(keyjson, &map[string]any{}); err != nil {
-			return errors.WithMessage(err, "unmarshal")
-		}
 		password, err := readPasswordFromNewTTY("Enter passphrase: ")
 		if err != nil {
 			return err
 		}
 
-		key, err := keystore.DecryptKey(keyjson, password)
+		key, err := wallet.DecryptKey(keyjson, password)
 		if err != nil {
-			return errors.WithMessage(err, "decrypt")
+			return err
 		}
 
-		if err := crypto.SaveECDSA(keyPath, key.PrivateKey); err != nil {
-			return err
+		if hasEncryptFlag {
+			// the keystore is kept as the encrypted master key, unlocked by the same passphrase
+			if err := os.WriteFile(keystorePath, keyjson, 0o600); err != nil {
+				return err
+			}
+			if err := os.Remove(keyPath); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		} else {
+			if _, err := os.Stat(keystorePath); err == nil {
+				return errors.Errorf("%v exists, use --%v to replace it", keystorePath, encryptMasterKeyFlag.Name)
+			}
+			if err := crypto.SaveECDSA(keyPath, key); err != nil {
+				return err
+			}
 		}
-		fmt.Println("Master key imported:", thor.Address(key.Address))
+		fmt.Println("Master key imported:", thor.Address(crypto.PubkeyToAddress(key.PublicKey)))
 		return nil
 	}
 
-	if hasExportFlag {
-		masterKey, err := loadOrGeneratePrivateKey(keyPath)
-		if err != nil {
-			return err
-		}
+	if hasEncryptFlag {
+		return encryptMasterKey(ctx, keyPath, keystorePath)
+	}
 
-		password, err := readPasswordFromNewTTY("Enter passphrase: ")
-		if err != nil {
+	if !hasExportFlag {
+		if keyjson, err := os.ReadFile(keystorePath); err == nil {
+			addr, err := wallet.KeyAddress(keyjson)
+			if err != nil {
+				return errors.Wrap(err, "read encrypted master key")
+			}
+			fmt.Println("Master:", addr, "(encrypted)")
+			return nil
+		} else if !os.IsNotExist(err) {
 			return err
 		}
-		if password == "" {
-			return errors.New("non-empty passphrase required")
-		}
-		confirm, err := readPasswordFromNewTTY("Confirm passphrase: ")
+		masterKey, err := loadOrGeneratePrivateKey(keyPath)
 		if err != nil {
 			return err
 		}
+		fmt.Println("Master:", thor.Address(crypto.PubkeyToAddress(masterKey.PublicKey)))
+		return nil
+	}
 
-		if password != confirm {
-			return errors.New("passphrase confirmation mismatch")
-		}
+	masterKey, err := loadMasterKeyFile(ctx)
+	if err != nil {
+		return err
+	}
 
-		keyjson, err := keystore.EncryptKey(&keystore.Key{
-			PrivateKey: masterKey,
-			Address:    crypto.PubkeyToAddress(masterKey.PublicKey),
-			Id:         uuid.NewRandom()},
-			password, keystore.StandardScryptN, keystore.StandardScryptP)
-		if err != nil {
+	password, err := readPasswordFromNewTTY("Enter passphrase: ")
+	if err != nil {
+		return err
+	}
+	if password == "" {
+		return errors.New("non-empty passphrase required")
+	}
+	confirm, err := readPasswordFromNewTTY("Confirm passphrase: ")
+	if err != nil {
+		return err
+	}
+
+	if password != confirm {
+		return errors.New("passphrase confirmation mismatch")
+	}
+
+	keyjson, err := wallet.EncryptKey(masterKey, password)
+	if err != nil {
+		return err
+	}
+	if isatty.IsTerminal(os.Stdout.Fd()) {
+		fmt.Println("=== JSON keystore ===")
+	}
+	_, err = fmt.Println(string(keyjson))
+	return err
+}
+
+// encryptMasterKey replaces the plaintext master key with an encrypted one, or generates an encrypted one if missing.
+func encryptMasterKey(ctx *cli.Context, keyPath, keystorePath string) error {
+	if _, err := os.Stat(keystorePath); err == nil {
+		return errors.Errorf("%v already exists", keystorePath)
+	}
+	key, err := crypto.LoadECDSA(keyPath)
+	generated := false
+	if err != nil {
+		if !os.IsNotExist(err) {
 			return err
 		}
-		if isatty.IsTerminal(os.Stdout.Fd()) {
-			fmt.Println("=== JSON keystore ===")
+		if key, err = crypto.GenerateKey(); err != nil {
+			return err
 		}
-		_, err = fmt.Println(string(keyjson))
+		generated = true
+	}
+
+	var passphrase string
+	if _, ok := os.LookupEnv(masterKeyPassphraseEnv); ok || ctx.String(masterKeyPassphraseFileFlag.Name) != "" {
+		passphrase, err = readMasterKeyPassphrase(ctx, "")
+	} else {
+		passphrase, err = newSecretReader().readNew("Enter passphrase of the master key: ")
+	}
+	if err != nil {
+		return err
+	}
+	if passphrase == "" {
+		return errors.New("non-empty passphrase required")
+	}
+
+	keyjson, err := wallet.EncryptKey(key, passphrase)
+	if err != nil {
 		return err
 	}
+	if err := os.WriteFile(keystorePath, keyjson, 0o600); err != nil {
+		return err
+	}
+	addr := thor.Address(crypto.PubkeyToAddress(key.PublicKey))
+	if generated {
+		fmt.Println("Encrypted master key generated:", addr)
+		return nil
+	}
+	if err := os.Remove(keyPath); err != nil {
+		return errors.Wrap(err, "remove plaintext master key")
+	}
+	fmt.Println("Master key encrypted:", addr)
 	return nil
 }
@@ -0,0 +1,159 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"crypto/ecdsa"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/wallet"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var masterKeyFlags = []cli.Flag{configDirFlag, masterKeyPassphraseFileFlag, masterKeyRequireEncryptedFlag}
+
+// loadMasterKeyFileWith loads the master key of the config dir with the command line args.
+func loadMasterKeyFileWith(t *testing.T, configDir string, args ...string) (*ecdsa.PrivateKey, error) {
+	var (
+		key *ecdsa.PrivateKey
+		err error
+	)
+	args = append([]string{"--config-dir", configDir}, args...)
+	require.NoError(t, runApp(masterKeyFlags, args, func(ctx *cli.Context) error {
+		key, err = loadMasterKeyFile(ctx)
+		return nil
+	}))
+	return key, err
+}
+
+// writeKeystore writes the key encrypted with light scrypt parameters, to be decrypted quickly.
+func writeKeystore(t *testing.T, path string, key *ecdsa.PrivateKey, passphrase string) {
+	keyjson, err := keystore.EncryptKey(&keystore.Key{
+		PrivateKey: key,
+		Address:    crypto.PubkeyToAddress(key.PublicKey),
+	}, passphrase, keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, keyjson, 0o600))
+}
+
+func TestLoadMasterKeyFile_Plaintext(t *testing.T) {
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "master.key")
+
+	// encrypted keys are required, the plaintext one is not generated
+	_, err := loadMasterKeyFileWith(t, dir, "--master-key-require-encrypted")
+	assert.ErrorContains(t, err, "encrypted master key required")
+	assert.NoFileExists(t, keyPath)
+
+	key, err := loadMasterKeyFileWith(t, dir)
+	require.NoError(t, err)
+	assert.FileExists(t, keyPath)
+
+	loaded, err := loadMasterKeyFileWith(t, dir)
+	require.NoError(t, err)
+	assert.Equal(t, key.D, loaded.D)
+
+	// an existing plaintext key is refused as well
+	_, err = loadMasterKeyFileWith(t, dir, "--master-key-require-encrypted")
+	assert.ErrorContains(t, err, "encrypted master key required")
+}
+
+func TestLoadMasterKeyFile_Encrypted(t *testing.T) {
+	dir := t.TempDir()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	keystorePath := filepath.Join(dir, "master.keystore")
+	writeKeystore(t, keystorePath, key, "secret")
+
+	passphraseFile := filepath.Join(dir, "passphrase")
+	require.NoError(t, os.WriteFile(passphraseFile, []byte("secret\r\nignored\n"), 0o600))
+	wrongFile := filepath.Join(dir, "wrong")
+	require.NoError(t, os.WriteFile(wrongFile, []byte("wrong"), 0o600))
+
+	t.Run("passphrase file", func(t *testing.T) {
+		loaded, err := loadMasterKeyFileWith(t, dir, "--master-key-passphrase-file", passphraseFile, "--master-key-require-encrypted")
+		require.NoError(t, err)
+		assert.Equal(t, key.D, loaded.D)
+	})
+	t.Run("env", func(t *testing.T) {
+		t.Setenv(masterKeyPassphraseEnv, "secret")
+		loaded, err := loadMasterKeyFileWith(t, dir)
+		require.NoError(t, err)
+		assert.Equal(t, key.D, loaded.D)
+	})
+	t.Run("passphrase file over env", func(t *testing.T) {
+		t.Setenv(masterKeyPassphraseEnv, "secret")
+		_, err := loadMasterKeyFileWith(t, dir, "--master-key-passphrase-file", wrongFile)
+		assert.ErrorContains(t, err, "unlock master key")
+
+		t.Setenv(masterKeyPassphraseEnv, "wrong")
+		loaded, err := loadMasterKeyFileWith(t, dir, "--master-key-passphrase-file", passphraseFile)
+		require.NoError(t, err)
+		assert.Equal(t, key.D, loaded.D)
+	})
+	t.Run("missing passphrase file", func(t *testing.T) {
+		_, err := loadMasterKeyFileWith(t, dir, "--master-key-passphrase-file", filepath.Join(dir, "missing"))
+		assert.ErrorContains(t, err, "passphrase file")
+	})
+	t.Run("both files", func(t *testing.T) {
+		t.Setenv(masterKeyPassphraseEnv, "secret")
+		keyPath := filepath.Join(dir, "master.key")
+		require.NoError(t, crypto.SaveECDSA(keyPath, key))
+		defer os.Remove(keyPath)
+
+		_, err := loadMasterKeyFileWith(t, dir)
+		assert.ErrorContains(t, err, "remove the plaintext one")
+	})
+}
+
+func TestEncryptMasterKey(t *testing.T) {
+	encrypt := func(t *testing.T, dir string) error {
+		return runApp(masterKeyFlags, []string{"--config-dir", dir}, func(ctx *cli.Context) error {
+			return encryptMasterKey(ctx, filepath.Join(dir, "master.key"), filepath.Join(dir, "master.keystore"))
+		})
+	}
+
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "master.key")
+	keystorePath := filepath.Join(dir, "master.keystore")
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	require.NoError(t, crypto.SaveECDSA(keyPath, key))
+
+	// the plaintext key is kept if the encryption fails
+	t.Setenv(masterKeyPassphraseEnv, "")
+	assert.EqualError(t, encrypt(t, dir), "non-empty passphrase required")
+	assert.FileExists(t, keyPath)
+	assert.NoFileExists(t, keystorePath)
+
+	t.Setenv(masterKeyPassphraseEnv, "secret")
+	require.NoError(t, encrypt(t, dir))
+	assert.NoFileExists(t, keyPath)
+	info, err := os.Stat(keystorePath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	keyjson, err := os.ReadFile(keystorePath)
+	require.NoError(t, err)
+	decrypted, err := wallet.DecryptKey(keyjson, "secret")
+	require.NoError(t, err)
+	assert.Equal(t, key.D, decrypted.D)
+
+	loaded, err := loadMasterKeyFileWith(t, dir, "--master-key-require-encrypted")
+	require.NoError(t, err)
+	assert.Equal(t, key.D, loaded.D)
+
+	// the encrypted key is never replaced
+	require.NoError(t, crypto.SaveECDSA(keyPath, key))
+	assert.ErrorContains(t, encrypt(t, dir), "already exists")
+	assert.FileExists(t, keyPath)
+}
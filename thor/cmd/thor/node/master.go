@@ -6,17 +6,15 @@
 package node
 
 import (
-	"crypto/ecdsa"
-
-	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/vechain/thor/v2/signer"
 	"github.com/vechain/thor/v2/thor"
 )
 
 type Master struct {
-	PrivateKey  *ecdsa.PrivateKey
+	Signer      signer.Signer
 	Beneficiary *thor.Address
 }
 
 func (m *Master) Address() thor.Address {
-	return thor.Address(crypto.PubkeyToAddress(m.PrivateKey.PublicKey))
+	return m.Signer.Address()
 }
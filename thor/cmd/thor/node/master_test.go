@@ -10,6 +10,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/v2/signer"
 	"github.com/vechain/thor/v2/thor"
 )
 
@@ -22,7 +23,7 @@ func TestAddress(t *testing.T) {
 
 	// Create a new Master instance
 	master := &Master{
-		PrivateKey: privateKey,
+		Signer: signer.NewLocal(privateKey),
 	}
 
 	// Compute the expected address
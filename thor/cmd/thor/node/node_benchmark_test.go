@@ -28,6 +28,7 @@ import (
 	"github.com/vechain/thor/v2/logdb"
 	"github.com/vechain/thor/v2/muxdb"
 	"github.com/vechain/thor/v2/packer"
+	"github.com/vechain/thor/v2/signer"
 	"github.com/vechain/thor/v2/state"
 	"github.com/vechain/thor/v2/test/datagen"
 	"github.com/vechain/thor/v2/test/testchain"
@@ -197,7 +198,7 @@ func benchmarkBlockProcess(b *testing.B, db *muxdb.MuxDB, accounts []genesis.Dev
 
 	node := New(
 		&Master{
-			PrivateKey: proposer.PrivateKey,
+			Signer: signer.NewLocal(proposer.PrivateKey),
 		},
 		thorChain.Repo(),
 		engine,
@@ -149,7 +149,7 @@ func (n *Node) pack(flow *packer.Flow) (err error) {
 		}
 
 		// pack the new block
-		newBlock, stage, receipts, err := flow.Pack(n.master.PrivateKey, conflicts, shouldVote)
+		newBlock, stage, receipts, err := flow.PackWithSigner(n.master.Signer, conflicts, shouldVote)
 		if err != nil {
 			return errors.Wrap(err, "failed to pack block")
 		}
@@ -101,6 +101,19 @@ func TestStatus(t *testing.T) {
 	assert.Equal(t, uint32(1), s.Base)
 }
 
+func TestLoadBase(t *testing.T) {
+	db := muxdb.NewMem()
+
+	base, err := LoadBase(db)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(0), base)
+
+	assert.Nil(t, (&status{Base: 8192}).Save(db.NewStore(propsStoreName)))
+	base, err = LoadBase(db)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(8192), base)
+}
+
 func TestNewPruner(t *testing.T) {
 	db := muxdb.NewMem()
 	stater := state.NewStater(db)
@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 
 	"github.com/vechain/thor/v2/kv"
+	"github.com/vechain/thor/v2/muxdb"
 )
 
 type status struct {
@@ -33,3 +34,12 @@ func (s *status) Save(putter kv.Putter) error {
 	}
 	return putter.Put([]byte(statusKey), data)
 }
+
+// LoadBase returns the block number below which the history of tries has been pruned, 0 if never pruned.
+func LoadBase(db *muxdb.MuxDB) (uint32, error) {
+	var s status
+	if err := s.Load(db.NewStore(propsStoreName)); err != nil {
+		return 0, err
+	}
+	return s.Base, nil
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/cmd/thor/pruner"
+	"github.com/vechain/thor/v2/cmd/thor/replay"
+	"github.com/vechain/thor/v2/state"
+	"gopkg.in/cheggaaa/pb.v1"
+	"gopkg.in/urfave/cli.v1"
+)
+
+func replayAction(ctx *cli.Context) error {
+	exitSignal := handleExitSignal()
+
+	from, err := readBlockNumFlag(ctx, replayFromFlag.Name)
+	if err != nil {
+		return err
+	}
+	to, err := readBlockNumFlag(ctx, replayToFlag.Name)
+	if err != nil {
+		return err
+	}
+
+	gene, forkConfig, err := selectChainGenesis(ctx)
+	if err != nil {
+		return err
+	}
+	mainDB, logDB, repo, err := openChain(ctx, gene)
+	if err != nil {
+		return err
+	}
+	defer mainDB.Close()
+	defer logDB.Close()
+
+	best := repo.BestBlockSummary().Header.Number()
+	if !ctx.IsSet(replayToFlag.Name) {
+		to = best
+	}
+	if from == 0 || to < from || to > best {
+		return fmt.Errorf("invalid range [%v, %v], best block %v", from, to, best)
+	}
+	if !ctx.Bool(disablePrunerFlag.Name) {
+		base, err := pruner.LoadBase(mainDB)
+		if err != nil {
+			return errors.Wrap(err, "load pruner status")
+		}
+		// the state of the parent block is required
+		if from-1 < base {
+			return fmt.Errorf("state history pruned below block %v, replay from block %v or use an instance with -%v", base, base+1, disablePrunerFlag.Name)
+		}
+	}
+
+	r := replay.New(repo, state.NewStater(mainDB), forkConfig, ctx.Bool(soloChainFlag.Name))
+
+	fmt.Printf(">> Replaying blocks [%v, %v] <<\n", from, to)
+	bar := pb.New64(int64(to-from) + 1).SetMaxWidth(90).Start()
+	defer func() { bar.NotPrint = true }()
+
+	stats, div, err := r.Range(exitSignal, from, to, func(uint32, *replay.Result) { bar.Increment() })
+	bar.Finish()
+	fmt.Println("Replayed", stats)
+	if err != nil {
+		return err
+	}
+	if div != nil {
+		fmt.Print(div)
+		return fmt.Errorf("block %v diverged", div.Number)
+	}
+	fmt.Println("State and receipts roots verified")
+	return nil
+}
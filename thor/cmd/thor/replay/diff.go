@@ -0,0 +1,120 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package replay
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/vechain/thor/v2/tx"
+)
+
+// diffReceipts returns the differences between the stored receipts and the replayed ones, a line per field.
+func diffReceipts(txs tx.Transactions, want, have tx.Receipts) []string {
+	var diffs []string
+	if len(want) != len(have) {
+		diffs = append(diffs, fmt.Sprintf("receipts: want %v, have %v", len(want), len(have)))
+	}
+	for i := 0; i < len(want) && i < len(have); i++ {
+		prefix := fmt.Sprintf("tx #%v", i)
+		if i < len(txs) {
+			prefix = fmt.Sprintf("tx #%v %v", i, txs[i].ID())
+		}
+		for _, diff := range diffReceipt(want[i], have[i]) {
+			diffs = append(diffs, prefix+" "+diff)
+		}
+	}
+	return diffs
+}
+
+func diffReceipt(want, have *tx.Receipt) []string {
+	var diffs []string
+	add := func(field string, want, have any) {
+		diffs = append(diffs, fmt.Sprintf("%v: want %v, have %v", field, want, have))
+	}
+
+	if want.GasUsed != have.GasUsed {
+		add("gas used", want.GasUsed, have.GasUsed)
+	}
+	if want.GasPayer != have.GasPayer {
+		add("gas payer", want.GasPayer, have.GasPayer)
+	}
+	if !bigEqual(want.Paid, have.Paid) {
+		add("paid", want.Paid, have.Paid)
+	}
+	if !bigEqual(want.Reward, have.Reward) {
+		add("reward", want.Reward, have.Reward)
+	}
+	if want.Reverted != have.Reverted {
+		add("reverted", want.Reverted, have.Reverted)
+	}
+	if len(want.Outputs) != len(have.Outputs) {
+		add("outputs", len(want.Outputs), len(have.Outputs))
+		return diffs
+	}
+	for i := range want.Outputs {
+		diffs = append(diffs, diffOutput(i, want.Outputs[i], have.Outputs[i])...)
+	}
+	return diffs
+}
+
+func diffOutput(clause int, want, have *tx.Output) []string {
+	var diffs []string
+	if len(want.Events) != len(have.Events) {
+		diffs = append(diffs, fmt.Sprintf("clause #%v events: want %v, have %v", clause, len(want.Events), len(have.Events)))
+	} else {
+		for i := range want.Events {
+			if !eventEqual(want.Events[i], have.Events[i]) {
+				diffs = append(diffs, fmt.Sprintf("clause #%v event #%v: want %v, have %v",
+					clause, i, formatEvent(want.Events[i]), formatEvent(have.Events[i])))
+			}
+		}
+	}
+	if len(want.Transfers) != len(have.Transfers) {
+		diffs = append(diffs, fmt.Sprintf("clause #%v transfers: want %v, have %v", clause, len(want.Transfers), len(have.Transfers)))
+	} else {
+		for i := range want.Transfers {
+			if !transferEqual(want.Transfers[i], have.Transfers[i]) {
+				diffs = append(diffs, fmt.Sprintf("clause #%v transfer #%v: want %v, have %v",
+					clause, i, formatTransfer(want.Transfers[i]), formatTransfer(have.Transfers[i])))
+			}
+		}
+	}
+	return diffs
+}
+
+func eventEqual(a, b *tx.Event) bool {
+	if a.Address != b.Address || len(a.Topics) != len(b.Topics) || !bytes.Equal(a.Data, b.Data) {
+		return false
+	}
+	for i := range a.Topics {
+		if a.Topics[i] != b.Topics[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func transferEqual(a, b *tx.Transfer) bool {
+	return a.Sender == b.Sender && a.Recipient == b.Recipient && bigEqual(a.Amount, b.Amount)
+}
+
+func bigEqual(a, b *big.Int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Cmp(b) == 0
+}
+
+func formatEvent(ev *tx.Event) string {
+	return fmt.Sprintf("{address %v, topics %v, data %v}", ev.Address, ev.Topics, hexutil.Encode(ev.Data))
+}
+
+func formatTransfer(tr *tx.Transfer) string {
+	return fmt.Sprintf("{sender %v, recipient %v, amount %v}", tr.Sender, tr.Recipient, tr.Amount)
+}
@@ -0,0 +1,206 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package replay re-executes blocks of the chain against the state of their parents, and verifies the
+// resulting state and receipts roots against the ones of the blocks.
+package replay
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/consensus"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
+	"github.com/vechain/thor/v2/tx"
+)
+
+// Result is the result of replaying a block.
+type Result struct {
+	Receipts     tx.Receipts
+	GasUsed      uint64
+	StateRoot    thor.Bytes32
+	ReceiptsRoot thor.Bytes32
+	Elapsed      time.Duration
+}
+
+// Stats is the throughput of replayed blocks.
+type Stats struct {
+	Blocks  int
+	Txs     int
+	Gas     uint64
+	Elapsed time.Duration // time spent on execution, excluding loading blocks and verification
+}
+
+// Add adds the result of a block.
+func (s *Stats) Add(res *Result) {
+	s.Blocks++
+	s.Txs += len(res.Receipts)
+	s.Gas += res.GasUsed
+	s.Elapsed += res.Elapsed
+}
+
+// String returns the throughput as blocks, txs and gas per second.
+func (s *Stats) String() string {
+	secs := s.Elapsed.Seconds()
+	if secs == 0 {
+		return fmt.Sprintf("%v blocks, %v txs, %v gas", s.Blocks, s.Txs, s.Gas)
+	}
+	return fmt.Sprintf("%v blocks, %v txs, %v gas in %v: %.2f blocks/s, %.2f txs/s, %.2f Mgas/s",
+		s.Blocks, s.Txs, s.Gas, s.Elapsed.Round(time.Millisecond),
+		float64(s.Blocks)/secs, float64(s.Txs)/secs, float64(s.Gas)/1e6/secs)
+}
+
+// Divergence is the difference between a replayed block and the block of the chain.
+type Divergence struct {
+	Number  uint32
+	ID      thor.Bytes32
+	Diffs   []string // differences of the roots and the gas used
+	TxDiffs []string // differences of the receipts
+}
+
+// String returns the report of the divergence.
+func (d *Divergence) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "block %v %v diverged:\n", d.Number, d.ID)
+	for _, diff := range d.Diffs {
+		fmt.Fprintf(&b, "  %v\n", diff)
+	}
+	for _, diff := range d.TxDiffs {
+		fmt.Fprintf(&b, "  %v\n", diff)
+	}
+	return b.String()
+}
+
+// Replayer replays blocks of a chain.
+type Replayer struct {
+	repo                 *chain.Repository
+	cons                 *consensus.Consensus
+	skipPoA              bool
+	correctReceiptsRoots map[string]string
+}
+
+// New creates a replayer. The proposer checks, which update the authority state, are skipped if skipPoA is set,
+// as for blocks packed in solo mode.
+func New(repo *chain.Repository, stater *state.Stater, forkConfig *thor.ForkConfig, skipPoA bool) *Replayer {
+	return &Replayer{
+		repo:                 repo,
+		cons:                 consensus.New(repo, stater, forkConfig),
+		skipPoA:              skipPoA,
+		correctReceiptsRoots: thor.LoadCorrectReceiptsRoots(),
+	}
+}
+
+// Replay executes the block against the state of its parent. The state is not committed.
+func (r *Replayer) Replay(blk *block.Block, conflicts uint32) (*Result, error) {
+	header := blk.Header()
+	startTime := time.Now()
+
+	rt, err := r.cons.NewRuntimeForReplay(header, r.skipPoA)
+	if err != nil {
+		return nil, errors.Wrap(err, "new runtime")
+	}
+
+	res := &Result{Receipts: make(tx.Receipts, 0, len(blk.Transactions()))}
+	for _, trx := range blk.Transactions() {
+		receipt, err := rt.ExecuteTransaction(trx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "execute tx %v", trx.ID())
+		}
+		res.GasUsed += receipt.GasUsed
+		res.Receipts = append(res.Receipts, receipt)
+	}
+
+	stage, err := rt.State().Stage(trie.Version{Major: header.Number(), Minor: conflicts})
+	if err != nil {
+		return nil, errors.Wrap(err, "stage state")
+	}
+	res.StateRoot = stage.Hash()
+	res.ReceiptsRoot = res.Receipts.RootHash()
+	res.Elapsed = time.Since(startTime)
+	return res, nil
+}
+
+// Verify compares the result of a replayed block with the block and its receipts stored in the chain. It
+// returns nil if they match.
+func (r *Replayer) Verify(blk *block.Block, res *Result) (*Divergence, error) {
+	receipts, err := r.repo.GetBlockReceipts(blk.Header().ID())
+	if err != nil {
+		return nil, errors.Wrap(err, "get receipts")
+	}
+	return compare(blk, receipts, res, r.correctReceiptsRoots), nil
+}
+
+// Range replays and verifies the blocks of the best chain in [from, to], and stops at the first divergence.
+// The progress callback, if not nil, is called after each block.
+func (r *Replayer) Range(ctx context.Context, from, to uint32, progress func(num uint32, res *Result)) (*Stats, *Divergence, error) {
+	var (
+		stats Stats
+		ch    = r.repo.NewBestChain()
+	)
+	for num := from; num <= to; num++ {
+		if err := ctx.Err(); err != nil {
+			return &stats, nil, err
+		}
+		id, err := ch.GetBlockID(num)
+		if err != nil {
+			return &stats, nil, errors.Wrapf(err, "get block %v", num)
+		}
+		summary, err := r.repo.GetBlockSummary(id)
+		if err != nil {
+			return &stats, nil, errors.Wrapf(err, "get block %v", num)
+		}
+		blk, err := r.repo.GetBlock(id)
+		if err != nil {
+			return &stats, nil, errors.Wrapf(err, "get block %v", num)
+		}
+
+		res, err := r.Replay(blk, summary.Conflicts)
+		if err != nil {
+			return &stats, nil, errors.WithMessage(err, fmt.Sprintf("replay block %v", num))
+		}
+		stats.Add(res)
+
+		div, err := r.Verify(blk, res)
+		if err != nil {
+			return &stats, nil, errors.WithMessage(err, fmt.Sprintf("verify block %v", num))
+		}
+		if div != nil {
+			return &stats, div, nil
+		}
+		if progress != nil {
+			progress(num, res)
+		}
+	}
+	return &stats, nil, nil
+}
+
+// compare returns the divergence of a replayed block, or nil if it matches the block and its stored receipts.
+func compare(blk *block.Block, stored tx.Receipts, res *Result, correctReceiptsRoots map[string]string) *Divergence {
+	header := blk.Header()
+	div := &Divergence{Number: header.Number(), ID: header.ID()}
+
+	if header.StateRoot() != res.StateRoot {
+		div.Diffs = append(div.Diffs, fmt.Sprintf("state root: want %v, have %v", header.StateRoot(), res.StateRoot))
+	}
+	if header.ReceiptsRoot() != res.ReceiptsRoot && correctReceiptsRoots[header.ID().String()] != res.ReceiptsRoot.String() {
+		div.Diffs = append(div.Diffs, fmt.Sprintf("receipts root: want %v, have %v", header.ReceiptsRoot(), res.ReceiptsRoot))
+	}
+	if header.GasUsed() != res.GasUsed {
+		div.Diffs = append(div.Diffs, fmt.Sprintf("gas used: want %v, have %v", header.GasUsed(), res.GasUsed))
+	}
+	div.TxDiffs = diffReceipts(blk.Transactions(), stored, res.Receipts)
+
+	if len(div.Diffs) == 0 && len(div.TxDiffs) == 0 {
+		return nil
+	}
+	return div
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package replay
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/test/testchain"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+func newChain(t *testing.T, blocks int) *testchain.Chain {
+	chain, err := testchain.NewDefault()
+	require.NoError(t, err)
+
+	accounts := genesis.DevAccounts()
+	for i := range blocks {
+		to := accounts[(i+1)%len(accounts)].Address
+		require.NoError(t, chain.MintClauses(accounts[0], []*tx.Clause{
+			tx.NewClause(&to).WithValue(big.NewInt(int64(i + 1))),
+		}))
+	}
+	return chain
+}
+
+func TestRange(t *testing.T) {
+	chain := newChain(t, 5)
+	r := New(chain.Repo(), chain.Stater(), chain.GetForkConfig(), true)
+
+	var replayed []uint32
+	stats, div, err := r.Range(context.Background(), 1, 5, func(num uint32, _ *Result) {
+		replayed = append(replayed, num)
+	})
+	require.NoError(t, err)
+	assert.Nil(t, div)
+	assert.Equal(t, []uint32{1, 2, 3, 4, 5}, replayed)
+	assert.Equal(t, 5, stats.Blocks)
+	assert.Equal(t, 5, stats.Txs)
+	assert.NotZero(t, stats.Gas)
+
+	_, _, err = r.Range(context.Background(), 5, 6, nil)
+	assert.Error(t, err)
+}
+
+func TestDivergence(t *testing.T) {
+	chain := newChain(t, 1)
+	r := New(chain.Repo(), chain.Stater(), chain.GetForkConfig(), true)
+
+	blk, err := chain.BestBlock()
+	require.NoError(t, err)
+	res, err := r.Replay(blk, 0)
+	require.NoError(t, err)
+	div, err := r.Verify(blk, res)
+	require.NoError(t, err)
+	assert.Nil(t, div)
+
+	// tamper the replayed receipt
+	res.StateRoot = thor.Bytes32{1}
+	res.Receipts[0].Reverted = true
+	res.Receipts[0].Outputs[0].Transfers[0].Amount = big.NewInt(100)
+	res.ReceiptsRoot = res.Receipts.RootHash()
+
+	div, err = r.Verify(blk, res)
+	require.NoError(t, err)
+	require.NotNil(t, div)
+	assert.Equal(t, blk.Header().ID(), div.ID)
+	assert.Len(t, div.Diffs, 2)
+	assert.Contains(t, div.Diffs[0], "state root")
+	assert.Contains(t, div.Diffs[1], "receipts root")
+	assert.Len(t, div.TxDiffs, 2)
+	assert.Contains(t, div.TxDiffs[0], "reverted: want false, have true")
+	assert.Contains(t, div.TxDiffs[1], "clause #0 transfer #0")
+
+	// a patched receipts root is accepted
+	div = compare(blk, tx.Receipts{res.Receipts[0]}, res, map[string]string{
+		blk.Header().ID().String(): res.ReceiptsRoot.String(),
+	})
+	require.NotNil(t, div)
+	assert.Len(t, div.Diffs, 1)
+	assert.Empty(t, div.TxDiffs)
+}
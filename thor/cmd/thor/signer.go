@@ -0,0 +1,78 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"crypto/ecdsa"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/cmd/thor/httpserver"
+	"github.com/vechain/thor/v2/log"
+	"github.com/vechain/thor/v2/signer"
+	"gopkg.in/urfave/cli.v1"
+)
+
+func signerAction(ctx *cli.Context) error {
+	exitSignal := handleExitSignal()
+
+	defer func() { log.Info("exited") }()
+
+	lvl, err := readIntFromUInt64Flag(ctx.Uint64(verbosityFlag.Name))
+	if err != nil {
+		return errors.Wrap(err, "parse verbosity flag")
+	}
+	initLogger(lvl, ctx.Bool(jsonLogsFlag.Name))
+
+	listen := ctx.String(signerListenFlag.Name)
+	if listen == "" {
+		return errors.New("listen address required")
+	}
+	allow, err := signer.ParseAllowlist(strings.Split(ctx.String(signerAllowFlag.Name), ","))
+	if err != nil {
+		return err
+	}
+
+	var audit io.Writer = os.Stderr
+	if path := ctx.String(signerAuditLogFlag.Name); path != "" {
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return errors.Wrap(err, "open audit log")
+		}
+		defer f.Close()
+		audit = f
+	}
+
+	key, err := loadMasterKey(ctx)
+	if err != nil {
+		return err
+	}
+	s := signer.NewLocal(key)
+
+	url, srvCloser, err := httpserver.StartSignerServer(listen, signer.NewServer(s, allow, audit))
+	if err != nil {
+		return err
+	}
+	defer srvCloser()
+
+	log.Info("signer started", "url", url, "master", s.Address())
+	<-exitSignal.Done()
+	return nil
+}
+
+// loadMasterKey loads the master key from stdin, or the config dir where it is generated if missing.
+func loadMasterKey(ctx *cli.Context) (*ecdsa.PrivateKey, error) {
+	if ctx.Bool(masterKeyStdinFlag.Name) {
+		key, err := loadNodeMasterFromStdin()
+		if err != nil {
+			return nil, errors.Wrap(err, "read master key from stdin")
+		}
+		return key, nil
+	}
+	return loadMasterKeyFile(ctx)
+}
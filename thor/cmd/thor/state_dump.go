@@ -0,0 +1,180 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/api/utils"
+	"github.com/vechain/thor/v2/bft"
+	"github.com/vechain/thor/v2/cmd/thor/statedump"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+	"gopkg.in/cheggaaa/pb.v1"
+	"gopkg.in/urfave/cli.v1"
+)
+
+func stateDumpAction(ctx *cli.Context) error {
+	exitSignal := handleExitSignal()
+
+	rev, err := utils.ParseRevision(ctx.String(stateRevisionFlag.Name), false)
+	if err != nil {
+		return errors.Wrap(err, "parse revision")
+	}
+	var addrs []thor.Address
+	if list := ctx.String(stateAddressFlag.Name); list != "" {
+		for _, s := range strings.Split(list, ",") {
+			addr, err := thor.ParseAddress(strings.TrimSpace(s))
+			if err != nil {
+				return errors.Wrap(err, "parse address")
+			}
+			addrs = append(addrs, addr)
+		}
+	}
+
+	gene, forkConfig, err := selectChainGenesis(ctx)
+	if err != nil {
+		return err
+	}
+	mainDB, logDB, repo, err := openChain(ctx, gene)
+	if err != nil {
+		return err
+	}
+	defer mainDB.Close()
+	defer logDB.Close()
+
+	var committer bft.Committer
+	if ctx.Bool(soloChainFlag.Name) {
+		committer = bft.NewMockedEngine(repo.GenesisBlock().Header().ID())
+	} else if committer, err = bft.NewEngine(repo, mainDB, forkConfig, thor.Address{}); err != nil {
+		return errors.Wrap(err, "init bft engine")
+	}
+	summary, err := utils.GetSummary(rev, repo, committer)
+	if err != nil {
+		return errors.Wrap(err, "get block")
+	}
+	header := summary.Header
+
+	out := os.Stdout
+	if path := ctx.String(stateOutFlag.Name); path != "" {
+		if out, err = os.Create(path); err != nil {
+			return errors.Wrap(err, "create dump file")
+		}
+		defer out.Close()
+	}
+	w, err := statedump.NewWriter(out, &statedump.Block{
+		ID:        header.ID(),
+		Number:    header.Number(),
+		Timestamp: header.Timestamp(),
+		GasLimit:  header.GasLimit(),
+		StateRoot: header.StateRoot(),
+	}, ctx.Bool(stateNDJSONFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	st := state.NewStater(mainDB).NewState(summary.Root())
+	if len(addrs) > 0 {
+		n, err := statedump.DumpAddresses(exitSignal, st, header, addrs, w)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Dumped %v accounts at block %v %v\n", n, header.Number(), header.ID())
+		return w.Close()
+	}
+
+	// resolve the addresses of the account trie with the addresses seen on the chain
+	fmt.Fprintf(os.Stderr, ">> Collecting addresses of blocks [0, %v] <<\n", header.Number())
+	bar := pb.New64(int64(header.Number())).SetMaxWidth(90)
+	bar.Output = os.Stderr
+	bar.Start()
+	defer func() { bar.NotPrint = true }()
+
+	preimages := statedump.NewPreimages()
+	_, genesisEvents, genesisTransfers, err := gene.Build(state.NewStater(muxdb.NewMem()))
+	if err != nil {
+		return errors.Wrap(err, "build genesis block")
+	}
+	preimages.AddOutput(genesisEvents, genesisTransfers)
+	if err := preimages.Collect(exitSignal, repo, header.ID(), func(uint32) { bar.Increment() }); err != nil {
+		return errors.Wrap(err, "collect addresses")
+	}
+	bar.Finish()
+
+	fmt.Fprintf(os.Stderr, ">> Dumping state of block %v %v <<\n", header.Number(), header.ID())
+	unresolved, err := statedump.Dump(exitSignal, st, header, preimages, w)
+	if err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	if unresolved > 0 {
+		fmt.Fprintf(os.Stderr, "%v accounts dumped without address, as none seen on the chain matches their key hash\n", unresolved)
+	}
+	return nil
+}
+
+func stateLoadAction(ctx *cli.Context) error {
+	var in io.Reader = os.Stdin
+	if path := ctx.String(stateInFlag.Name); path != "" {
+		file, err := os.Open(path)
+		if err != nil {
+			return errors.Wrap(err, "open dump file")
+		}
+		defer file.Close()
+		in = file
+	}
+	blk, accounts, err := statedump.Read(in)
+	if err != nil {
+		return errors.Wrap(err, "read dump")
+	}
+
+	template := statedump.DefaultTemplate(blk)
+	if path := ctx.String(stateTemplateFlag.Name); path != "" {
+		if template, err = readGenesisFile(path); err != nil {
+			return err
+		}
+	}
+
+	gen, stats := statedump.ToGenesis(accounts, template)
+	// check the genesis can be built
+	if errs := gen.Validate(); len(errs) > 0 {
+		return errors.Wrap(joinErrors(errs), "invalid genesis")
+	}
+	if _, err := genesis.NewCustomNet(gen); err != nil {
+		return errors.Wrap(err, "build genesis")
+	}
+
+	data, err := json.MarshalIndent(gen, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if path := ctx.String(stateOutFlag.Name); path != "" {
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return errors.Wrap(err, "write genesis file")
+		}
+	} else if _, err := os.Stdout.Write(data); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Restored %v accounts of block %v %v\n", stats.Accounts, blk.Number, blk.ID)
+	if stats.Builtin > 0 {
+		fmt.Fprintf(os.Stderr, "Skipped %v builtin contracts, set up by the genesis\n", stats.Builtin)
+	}
+	if stats.Unresolved > 0 {
+		fmt.Fprintf(os.Stderr, "Skipped %v accounts without address\n", stats.Unresolved)
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package statedump dumps the accounts of the state at a block, and turns dumps into custom genesis.
+//
+// A dump is either a JSON object:
+//
+//	{"block": {...}, "accounts": [{...}, ...]}
+//
+// or NDJSON, with the block on the first line followed by an account per line:
+//
+//	{"block": {...}}
+//	{...}
+//
+// The account trie is keyed by the hashes of the addresses, which are resolved with the addresses seen on the
+// chain. Accounts whose address is not resolved are dumped with their key hash only.
+package statedump
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+)
+
+var errMissingBlock = errors.New("missing block in dump")
+
+// Block is the block of the dumped state.
+type Block struct {
+	ID        thor.Bytes32 `json:"id"`
+	Number    uint32       `json:"number"`
+	Timestamp uint64       `json:"timestamp"`
+	GasLimit  uint64       `json:"gasLimit"`
+	StateRoot thor.Bytes32 `json:"stateRoot"`
+}
+
+// Account is a dumped account.
+type Account struct {
+	Address    *thor.Address            `json:"address,omitempty"` // nil if not resolved
+	KeyHash    thor.Bytes32             `json:"keyHash"`
+	Balance    *genesis.HexOrDecimal256 `json:"balance"`
+	Energy     *genesis.HexOrDecimal256 `json:"energy"` // energy at the block time
+	Master     *thor.Address            `json:"master,omitempty"`
+	Code       hexutil.Bytes            `json:"code,omitempty"`
+	Storage    map[string]thor.Bytes32  `json:"storage,omitempty"`
+	RawStorage map[string]hexutil.Bytes `json:"rawStorage,omitempty"` // RLP values which are not restored as words, such as the ones of builtin contracts
+}
+
+// Writer writes a dump.
+type Writer struct {
+	w      *bufio.Writer
+	ndjson bool
+	count  int
+}
+
+// NewWriter writes the block of the dump, as JSON or NDJSON.
+func NewWriter(w io.Writer, blk *Block, ndjson bool) (*Writer, error) {
+	bw := bufio.NewWriter(w)
+	data, err := json.Marshal(blk)
+	if err != nil {
+		return nil, err
+	}
+	bw.WriteString(`{"block":`)
+	bw.Write(data)
+	if ndjson {
+		bw.WriteString("}\n")
+	} else {
+		bw.WriteString(`,"accounts":[`)
+	}
+	return &Writer{w: bw, ndjson: ndjson}, nil
+}
+
+// Write writes an account.
+func (w *Writer) Write(acc *Account) error {
+	data, err := json.Marshal(acc)
+	if err != nil {
+		return err
+	}
+	if !w.ndjson {
+		if w.count > 0 {
+			w.w.WriteByte(',')
+		}
+		w.w.WriteByte('\n')
+	}
+	w.w.Write(data)
+	if w.ndjson {
+		w.w.WriteByte('\n')
+	}
+	w.count++
+	return nil
+}
+
+// Close ends the dump, and flushes it.
+func (w *Writer) Close() error {
+	if !w.ndjson {
+		w.w.WriteString("\n]}\n")
+	}
+	return w.w.Flush()
+}
+
+// Read reads a dump, either JSON or NDJSON.
+func Read(r io.Reader) (*Block, []*Account, error) {
+	dec := json.NewDecoder(bufio.NewReader(r))
+	var head struct {
+		Block    *Block     `json:"block"`
+		Accounts []*Account `json:"accounts"`
+	}
+	if err := dec.Decode(&head); err != nil {
+		return nil, nil, err
+	}
+	if head.Block == nil {
+		return nil, nil, errMissingBlock
+	}
+	for dec.More() {
+		var acc Account
+		if err := dec.Decode(&acc); err != nil {
+			return nil, nil, err
+		}
+		head.Accounts = append(head.Accounts, &acc)
+	}
+	return head.Block, head.Accounts, nil
+}
+
+// Dump writes all the accounts of the state at the given block, resolving their addresses with the preimages.
+// It returns the number of accounts whose address is not resolved.
+func Dump(ctx context.Context, st *state.State, header *block.Header, preimages Preimages, w *Writer) (unresolved int, err error) {
+	err = st.DumpAccounts(func(keyHash thor.Bytes32, acc *state.DumpedAccount) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		var addr *thor.Address
+		if a, ok := preimages[keyHash]; ok {
+			addr = &a
+		} else {
+			unresolved++
+		}
+		dumped, err := newAccount(addr, keyHash, acc, header.Timestamp())
+		if err != nil {
+			return err
+		}
+		return w.Write(dumped)
+	})
+	return unresolved, err
+}
+
+// DumpAddresses writes the accounts at the given addresses of the state at the given block, skipping the empty
+// ones. It returns the number of written accounts.
+func DumpAddresses(ctx context.Context, st *state.State, header *block.Header, addrs []thor.Address, w *Writer) (int, error) {
+	n := 0
+	for _, addr := range addrs {
+		if err := ctx.Err(); err != nil {
+			return n, err
+		}
+		acc, err := st.DumpAccount(addr)
+		if err != nil {
+			return n, err
+		}
+		if acc == nil {
+			continue
+		}
+		dumped, err := newAccount(&addr, state.AccountKeyHash(addr), acc, header.Timestamp())
+		if err != nil {
+			return n, err
+		}
+		if err := w.Write(dumped); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+func newAccount(addr *thor.Address, keyHash thor.Bytes32, acc *state.DumpedAccount, blockTime uint64) (*Account, error) {
+	dumped := &Account{
+		Address: addr,
+		KeyHash: keyHash,
+		Balance: (*genesis.HexOrDecimal256)(new(big.Int).Set(acc.Balance)),
+		Energy:  (*genesis.HexOrDecimal256)(acc.CalcEnergy(blockTime)),
+	}
+	if len(acc.Master) > 0 {
+		master := thor.BytesToAddress(acc.Master)
+		dumped.Master = &master
+	}
+	code, err := acc.Code()
+	if err != nil {
+		return nil, err
+	}
+	dumped.Code = code
+
+	err = acc.IterateStorage(func(key thor.Bytes32, value rlp.RawValue) error {
+		if word, ok := storageWord(value); ok {
+			if dumped.Storage == nil {
+				dumped.Storage = make(map[string]thor.Bytes32)
+			}
+			dumped.Storage[key.String()] = word
+			return nil
+		}
+		if dumped.RawStorage == nil {
+			dumped.RawStorage = make(map[string]hexutil.Bytes)
+		}
+		dumped.RawStorage[key.String()] = hexutil.Bytes(value)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dumped, nil
+}
+
+// storageWord returns the word of a storage value, if restoring the word with SetStorage gives back the same
+// bytes. Byte strings with leading zeros, or not canonically encoded, are to be kept raw.
+func storageWord(value rlp.RawValue) (thor.Bytes32, bool) {
+	kind, content, _, err := rlp.Split(value)
+	if err != nil || kind == rlp.List || len(content) > 32 {
+		return thor.Bytes32{}, false
+	}
+	word := thor.BytesToBytes32(content)
+	if word.IsZero() {
+		return thor.Bytes32{}, false
+	}
+	encoded, err := rlp.EncodeToBytes(bytes.TrimLeft(word[:], "\x00"))
+	if err != nil || !bytes.Equal(encoded, value) {
+		return thor.Bytes32{}, false
+	}
+	return word, true
+}
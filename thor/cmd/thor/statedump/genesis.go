@@ -0,0 +1,124 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package statedump
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/vechain/thor/v2/builtin"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/vm"
+)
+
+// LoadStats counts the dumped accounts and storage entries not restored by a genesis.
+type LoadStats struct {
+	Accounts   int // restored accounts
+	Unresolved int // accounts whose address is not resolved
+	Builtin    int // builtin contracts, which are set up by the genesis
+}
+
+// DefaultTemplate returns the template of a solo chain launched at the block time. The dev accounts are funded
+// as in the devnet, and the first one is the authority node and the executor.
+func DefaultTemplate(blk *Block) *genesis.CustomGenesis {
+	forkConfig := thor.SoloFork
+	master := genesis.DevAccounts()[0].Address
+
+	gen := &genesis.CustomGenesis{
+		LaunchTime: blk.Timestamp,
+		GasLimit:   blk.GasLimit,
+		Authority: []genesis.Authority{{
+			MasterAddress:   master,
+			EndorsorAddress: master,
+			Identity:        thor.BytesToBytes32([]byte("Solo Block Signer")),
+		}},
+		Params:     genesis.Params{ExecutorAddress: &master},
+		ForkConfig: &forkConfig,
+	}
+	for _, acc := range genesis.DevAccounts() {
+		bal, _ := new(big.Int).SetString("1000000000000000000000000000", 10)
+		gen.Accounts = append(gen.Accounts, genesis.Account{
+			Address: acc.Address,
+			Balance: (*genesis.HexOrDecimal256)(bal),
+			Energy:  (*genesis.HexOrDecimal256)(new(big.Int).Set(bal)),
+		})
+	}
+	return gen
+}
+
+// ToGenesis adds the dumped accounts to a copy of the template, which provides the network settings. Accounts of
+// the template are replaced by the dumped accounts at the same address. The builtin and precompiled contracts
+// are skipped, as the genesis sets them up, except for the storage of the prototype, which keeps the users,
+// credit plans and sponsors of the contracts.
+func ToGenesis(accounts []*Account, template *genesis.CustomGenesis) (*genesis.CustomGenesis, *LoadStats) {
+	var (
+		gen      = *template
+		stats    LoadStats
+		builtins = builtinAddresses()
+		dumped   = make(map[thor.Address]bool)
+	)
+
+	gen.Accounts = nil
+	for _, acc := range accounts {
+		switch {
+		case acc.Address == nil:
+			stats.Unresolved++
+		case *acc.Address == builtin.Prototype.Address:
+			stats.Builtin++
+			gen.Accounts = append(gen.Accounts, genesis.Account{
+				Address:    *acc.Address,
+				Storage:    acc.Storage,
+				RawStorage: acc.RawStorage,
+			})
+		case builtins[*acc.Address]:
+			stats.Builtin++
+		default:
+			stats.Accounts++
+			dumped[*acc.Address] = true
+			gen.Accounts = append(gen.Accounts, genesisAccount(acc))
+		}
+	}
+	for _, acc := range template.Accounts {
+		if !dumped[acc.Address] {
+			gen.Accounts = append(gen.Accounts, acc)
+		}
+	}
+	return &gen, &stats
+}
+
+func genesisAccount(acc *Account) genesis.Account {
+	res := genesis.Account{
+		Address:    *acc.Address,
+		Balance:    acc.Balance,
+		Energy:     acc.Energy,
+		Master:     acc.Master,
+		Storage:    acc.Storage,
+		RawStorage: acc.RawStorage,
+	}
+	if len(acc.Code) > 0 {
+		res.Code = hexutil.Encode(acc.Code)
+	}
+	return res
+}
+
+// builtinAddresses returns the addresses of the builtin contracts, and of the precompiled contracts whose code
+// is set by the runtime.
+func builtinAddresses() map[thor.Address]bool {
+	addrs := map[thor.Address]bool{
+		builtin.Params.Address:    true,
+		builtin.Authority.Address: true,
+		builtin.Energy.Address:    true,
+		builtin.Executor.Address:  true,
+		builtin.Prototype.Address: true,
+		builtin.Extension.Address: true,
+		builtin.Measure.Address:   true,
+	}
+	for _, addr := range vm.PrecompiledAddressesShanghai {
+		addrs[thor.Address(addr)] = true
+	}
+	return addrs
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package statedump
+
+import (
+	"context"
+
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+// Preimages maps the keys of the account trie to addresses.
+type Preimages map[thor.Bytes32]thor.Address
+
+// NewPreimages creates preimages with the builtin contracts and the dev accounts.
+func NewPreimages() Preimages {
+	p := make(Preimages)
+	for addr := range builtinAddresses() {
+		p.Add(addr)
+	}
+	for _, acc := range genesis.DevAccounts() {
+		p.Add(acc.Address)
+	}
+	return p
+}
+
+// Add adds an address.
+func (p Preimages) Add(addr thor.Address) {
+	p[state.AccountKeyHash(addr)] = addr
+}
+
+// AddReceipts adds the addresses found in the outputs of receipts, including the topics of events which look
+// like addresses.
+func (p Preimages) AddReceipts(receipts tx.Receipts) {
+	for _, r := range receipts {
+		p.Add(r.GasPayer)
+		for _, o := range r.Outputs {
+			p.AddOutput(o.Events, o.Transfers)
+		}
+	}
+}
+
+// AddOutput adds the addresses found in events and transfers.
+func (p Preimages) AddOutput(events tx.Events, transfers tx.Transfers) {
+	for _, ev := range events {
+		p.Add(ev.Address)
+		for _, topic := range ev.Topics {
+			if isAddressWord(topic) {
+				p.Add(thor.BytesToAddress(topic[12:]))
+			}
+		}
+	}
+	for _, tr := range transfers {
+		p.Add(tr.Sender)
+		p.Add(tr.Recipient)
+	}
+}
+
+// Collect adds the addresses found on the chain up to the given block: block signers and beneficiaries,
+// transaction origins, delegators, clause recipients and created contracts, and the outputs of receipts.
+// The genesis block is skipped, as its outputs are not stored. The progress callback, if not nil, is called
+// after each block.
+func (p Preimages) Collect(ctx context.Context, repo *chain.Repository, headID thor.Bytes32, progress func(num uint32)) error {
+	ch := repo.NewChain(headID)
+	for num := uint32(1); num <= block.Number(headID); num++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		id, err := ch.GetBlockID(num)
+		if err != nil {
+			return err
+		}
+		blk, err := repo.GetBlock(id)
+		if err != nil {
+			return err
+		}
+		header := blk.Header()
+		p.Add(header.Beneficiary())
+		if signer, err := header.Signer(); err == nil {
+			p.Add(signer)
+		}
+		for _, trx := range blk.Transactions() {
+			if origin, err := trx.Origin(); err == nil {
+				p.Add(origin)
+			}
+			if delegator, err := trx.Delegator(); err == nil && delegator != nil {
+				p.Add(*delegator)
+			}
+			for i, clause := range trx.Clauses() {
+				if to := clause.To(); to != nil {
+					p.Add(*to)
+				} else {
+					p.Add(thor.CreateContractAddress(trx.ID(), uint32(i), 0))
+				}
+			}
+		}
+		receipts, err := repo.GetBlockReceipts(id)
+		if err != nil {
+			return err
+		}
+		p.AddReceipts(receipts)
+		if progress != nil {
+			progress(num)
+		}
+	}
+	return nil
+}
+
+// isAddressWord returns whether the word looks like a left padded address.
+func isAddressWord(w thor.Bytes32) bool {
+	for _, b := range w[:12] {
+		if b != 0 {
+			return false
+		}
+	}
+	return !thor.BytesToAddress(w[12:]).IsZero()
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package statedump
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/builtin"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/test/testchain"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
+	"github.com/vechain/thor/v2/tx"
+)
+
+// deployCode stores 42 at slot 1, and returns the runtime code 0x6000.
+var deployCode = hexutil.MustDecode("0x602a6001556002601160003960026000f36000")
+
+var devConfig = genesis.DevConfig{
+	ForkConfig: &testchain.DefaultForkConfig,
+	LaunchTime: 1_700_000_000,
+}
+
+func dump(t *testing.T, chain *testchain.Chain, ndjson bool) (*Block, []*Account) {
+	best, err := chain.BestBlock()
+	require.NoError(t, err)
+	header := best.Header()
+
+	preimages := NewPreimages()
+	_, events, transfers, err := genesis.NewDevnetWithConfig(devConfig).Build(state.NewStater(muxdb.NewMem()))
+	require.NoError(t, err)
+	preimages.AddOutput(events, transfers)
+	require.NoError(t, preimages.Collect(context.Background(), chain.Repo(), header.ID(), nil))
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, &Block{
+		ID:        header.ID(),
+		Number:    header.Number(),
+		Timestamp: header.Timestamp(),
+		GasLimit:  header.GasLimit(),
+		StateRoot: header.StateRoot(),
+	}, ndjson)
+	require.NoError(t, err)
+	unresolved, err := Dump(context.Background(), chain.Stater().NewState(chain.Repo().BestBlockSummary().Root()), header, preimages, w)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	assert.Zero(t, unresolved)
+
+	blk, accounts, err := Read(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, header.ID(), blk.ID)
+	return blk, accounts
+}
+
+func TestDumpAndLoad(t *testing.T) {
+	chain, err := testchain.NewIntegrationTestChain(devConfig)
+	require.NoError(t, err)
+
+	accounts := genesis.DevAccounts()
+	recipient := thor.BytesToAddress([]byte("recipient"))
+	require.NoError(t, chain.MintClauses(accounts[0], []*tx.Clause{
+		tx.NewClause(nil).WithData(deployCode),
+		tx.NewClause(&recipient).WithValue(big.NewInt(100)),
+	}))
+	best, err := chain.BestBlock()
+	require.NoError(t, err)
+	contract := thor.CreateContractAddress(best.Transactions()[0].ID(), 0, 0)
+
+	// a user of the contract, kept by the prototype as a RLP list
+	user := thor.BytesToAddress([]byte("user"))
+	addUser, ok := builtin.Prototype.ABI.MethodByName("addUser")
+	require.True(t, ok)
+	data, err := addUser.EncodeInput(contract, user)
+	require.NoError(t, err)
+	require.NoError(t, chain.MintClauses(accounts[0], []*tx.Clause{tx.NewClause(&builtin.Prototype.Address).WithData(data)}))
+
+	blk, dumped := dump(t, chain, false)
+	_, ndjsonDumped := dump(t, chain, true)
+	assert.Equal(t, dumped, ndjsonDumped)
+
+	byAddr := make(map[thor.Address]*Account)
+	for _, acc := range dumped {
+		require.NotNil(t, acc.Address)
+		byAddr[*acc.Address] = acc
+	}
+	require.Contains(t, byAddr, contract)
+	assert.Equal(t, hexutil.Bytes{0x60, 0x00}, byAddr[contract].Code)
+	assert.Equal(t, accounts[0].Address, *byAddr[contract].Master)
+	assert.Equal(t, thor.BytesToBytes32([]byte{42}), byAddr[contract].Storage[thor.BytesToBytes32([]byte{1}).String()])
+	require.Contains(t, byAddr, builtin.Prototype.Address)
+	assert.NotEmpty(t, byAddr[builtin.Prototype.Address].RawStorage)
+	require.Contains(t, byAddr, recipient)
+	assert.Equal(t, big.NewInt(100), (*big.Int)(byAddr[recipient].Balance))
+	require.Contains(t, byAddr, builtin.Energy.Address)
+	assert.NotEmpty(t, byAddr[builtin.Energy.Address].RawStorage)
+
+	gen, stats := ToGenesis(dumped, DefaultTemplate(blk))
+	assert.Equal(t, len(dumped), stats.Accounts+stats.Builtin)
+	assert.Zero(t, stats.Unresolved)
+
+	net, err := genesis.NewCustomNet(gen)
+	require.NoError(t, err)
+	stater := state.NewStater(muxdb.NewMem())
+	genesisBlock, _, _, err := net.Build(stater)
+	require.NoError(t, err)
+
+	st := stater.NewState(trie.Root{Hash: genesisBlock.Header().StateRoot()})
+	code, err := st.GetCode(contract)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x60, 0x00}, code)
+	master, err := st.GetMaster(contract)
+	require.NoError(t, err)
+	assert.Equal(t, accounts[0].Address, master)
+	value, err := st.GetStorage(contract, thor.BytesToBytes32([]byte{1}))
+	require.NoError(t, err)
+	assert.Equal(t, thor.BytesToBytes32([]byte{42}), value)
+	isUser, err := builtin.Prototype.Native(st).Bind(contract).IsUser(user)
+	require.NoError(t, err)
+	assert.True(t, isUser)
+	balance, err := st.GetBalance(recipient)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(100), balance)
+}
+
+func TestStorageWord(t *testing.T) {
+	for _, tt := range []struct {
+		value string
+		word  bool
+	}{
+		{"0x2a", true},     // single byte
+		{"0x822a2b", true}, // short string
+		{"0xa0" + "ff" + strings.Repeat("00", 31), true},
+		{"0x80", false},                            // empty
+		{"0x8200ff", false},                        // leading zero
+		{"0x812a", false},                          // single byte not canonically encoded
+		{"0xc22a2b", false},                        // list
+		{"0xa1" + strings.Repeat("ff", 33), false}, // over 32 bytes
+		{"0x82", false},                            // invalid
+	} {
+		word, ok := storageWord(hexutil.MustDecode(tt.value))
+		assert.Equal(t, tt.word, ok, tt.value)
+		if ok {
+			// the word is restored to the same value
+			encoded, err := rlp.EncodeToBytes(bytes.TrimLeft(word[:], "\x00"))
+			require.NoError(t, err)
+			assert.Equal(t, tt.value, hexutil.Encode(encoded))
+		}
+	}
+}
@@ -37,6 +37,9 @@ import (
 	"github.com/mattn/go-isatty"
 	"github.com/mattn/go-tty"
 	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/api/certificates"
+	"github.com/vechain/thor/v2/api/delegator"
+	"github.com/vechain/thor/v2/api/middleware"
 	"github.com/vechain/thor/v2/chain"
 	"github.com/vechain/thor/v2/cmd/thor/httpserver"
 	"github.com/vechain/thor/v2/cmd/thor/node"
@@ -47,10 +50,12 @@ import (
 	"github.com/vechain/thor/v2/logdb"
 	"github.com/vechain/thor/v2/muxdb"
 	"github.com/vechain/thor/v2/p2psrv"
+	"github.com/vechain/thor/v2/signer"
 	"github.com/vechain/thor/v2/state"
 	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/tx"
 	"github.com/vechain/thor/v2/txpool"
+	"github.com/vechain/thor/v2/wallet"
 	"gopkg.in/urfave/cli.v1"
 )
 
@@ -201,6 +206,42 @@ func selectGenesis(ctx *cli.Context) (*genesis.Genesis, *thor.ForkConfig, error)
 }
 
 func parseGenesisFile(uri string) (*genesis.Genesis, *thor.ForkConfig, error) {
+	gen, err := readGenesisFile(uri)
+	if err != nil {
+		return nil, nil, err
+	}
+	// the rules NewCustomNet doesn't enforce only warn, not to stop the networks of genesis files built before them
+	var errs []error
+	for _, err := range gen.Validate() {
+		if genesis.IsStrict(err) {
+			log.Warn("genesis file: " + err.Error())
+		} else {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, nil, errors.Wrap(joinErrors(errs), "invalid genesis file")
+	}
+
+	customGen, err := genesis.NewCustomNet(gen)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "build genesis")
+	}
+
+	return customGen, gen.ForkConfig, nil
+}
+
+// joinErrors joins the errors into one, a line per error.
+func joinErrors(errs []error) error {
+	msgs := make([]string, 0, len(errs))
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+	return errors.New(strings.Join(msgs, "\n"))
+}
+
+// readGenesisFile reads a custom genesis from a path or URL, the fork config defaults to no fork.
+func readGenesisFile(uri string) (*genesis.CustomGenesis, error) {
 	var (
 		reader io.ReadCloser
 		err    error
@@ -208,13 +249,13 @@ func parseGenesisFile(uri string) (*genesis.Genesis, *thor.ForkConfig, error) {
 	if strings.HasPrefix(uri, "http://") || strings.HasPrefix(uri, "https://") {
 		res, err := http.Get(uri) // #nosec
 		if err != nil {
-			return nil, nil, errors.Wrap(err, "http get genesis file")
+			return nil, errors.Wrap(err, "http get genesis file")
 		}
 		reader = res.Body
 	} else {
 		reader, err = os.Open(uri)
 		if err != nil {
-			return nil, nil, errors.Wrap(err, "open genesis file")
+			return nil, errors.Wrap(err, "open genesis file")
 		}
 	}
 	defer reader.Close()
@@ -227,18 +268,12 @@ func parseGenesisFile(uri string) (*genesis.Genesis, *thor.ForkConfig, error) {
 	gen.ForkConfig = &forkConfig
 
 	if err := decoder.Decode(&gen); err != nil {
-		return nil, nil, errors.Wrap(err, "decode genesis file")
-	}
-
-	customGen, err := genesis.NewCustomNet(&gen)
-	if err != nil {
-		return nil, nil, errors.Wrap(err, "build genesis")
+		return nil, errors.Wrap(err, "decode genesis file")
 	}
-
-	return customGen, &forkConfig, nil
+	return &gen, nil
 }
 
-func makeAPIConfig(ctx *cli.Context, logAPIRequests *atomic.Bool, soloMode bool) httpserver.APIConfig {
+func makeAPIConfig(ctx *cli.Context, logAPIRequests *atomic.Bool, apiKeys *middleware.APIKeys, soloMode bool) httpserver.APIConfig {
 	return httpserver.APIConfig{
 		AllowedOrigins:             ctx.String(apiCorsFlag.Name),
 		BacktraceLimit:             uint32(ctx.Uint64(apiBacktraceLimitFlag.Name)),
@@ -251,12 +286,93 @@ func makeAPIConfig(ctx *cli.Context, logAPIRequests *atomic.Bool, soloMode bool)
 		EnableReqLogger:            logAPIRequests,
 		EnableMetrics:              ctx.Bool(enableMetricsFlag.Name),
 		LogsLimit:                  ctx.Uint64(apiLogsLimitFlag.Name),
-		AllowedTracers:             parseTracerList(strings.TrimSpace(ctx.String(allowedTracersFlag.Name))),
-		EnableDeprecated:           ctx.Bool(apiEnableDeprecatedFlag.Name),
-		SoloMode:                   soloMode,
-		EnableTxPool:               ctx.Bool(apiTxpoolFlag.Name),
-		Timeout:                    ctx.Int(apiTimeoutFlag.Name),
+		LogsBudget: logdb.QueryBudget{
+			MaxCost:   ctx.Uint64(apiLogsMaxCostFlag.Name),
+			Downgrade: ctx.Bool(apiLogsDowngradeFlag.Name),
+			Timeout:   time.Duration(ctx.Uint64(apiLogsQueryTimeoutFlag.Name)) * time.Millisecond,
+			SlowQuery: time.Duration(ctx.Uint64(apiLogsSlowQueryFlag.Name)) * time.Millisecond,
+		},
+		AllowedTracers:   parseTracerList(strings.TrimSpace(ctx.String(allowedTracersFlag.Name))),
+		EnableDeprecated: ctx.Bool(apiEnableDeprecatedFlag.Name),
+		SoloMode:         soloMode,
+		EnableTxPool:     ctx.Bool(apiTxpoolFlag.Name),
+		Timeout:          ctx.Int(apiTimeoutFlag.Name),
+		APIKeys:          apiKeys,
+		Certificates: certificates.Config{
+			Domains: strings.Split(ctx.String(apiCertDomainsFlag.Name), ","),
+			MaxAge:  time.Duration(ctx.Uint64(apiCertMaxAgeFlag.Name)) * time.Second,
+		},
+	}
+}
+
+// makeTLSConfig returns the TLS config of a service from its certificate and key flags, or nil if they are not set.
+func makeTLSConfig(ctx *cli.Context, certFlag, keyFlag cli.StringFlag, clientCAFile string) (*httpserver.TLSConfig, error) {
+	certFile, keyFile := ctx.String(certFlag.Name), ctx.String(keyFlag.Name)
+	if (certFile == "") != (keyFile == "") {
+		return nil, fmt.Errorf("--%v and --%v must be set together", certFlag.Name, keyFlag.Name)
+	}
+	if certFile == "" {
+		if clientCAFile != "" {
+			return nil, fmt.Errorf("client CA requires --%v and --%v", certFlag.Name, keyFlag.Name)
+		}
+		return nil, nil
+	}
+	return &httpserver.TLSConfig{
+		CertFile:     certFile,
+		KeyFile:      keyFile,
+		ClientCAFile: clientCAFile,
+	}, nil
+}
+
+func loadAPIKeys(ctx *cli.Context) (*middleware.APIKeys, error) {
+	path := ctx.String(apiKeysFlag.Name)
+	if path == "" {
+		return nil, nil
+	}
+	keys, err := middleware.NewAPIKeys(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "load API keys [%v]", path)
+	}
+	return keys, nil
+}
+
+// newDelegator creates the gas payer service if the delegator policy is set. The gas payer key is held by the
+// delegator signer, or is the default key if not set.
+func newDelegator(
+	ctx *cli.Context,
+	repo *chain.Repository,
+	db *muxdb.MuxDB,
+	forkConfig *thor.ForkConfig,
+	defaultKey func() (*ecdsa.PrivateKey, error),
+) (*delegator.Delegator, error) {
+	path := ctx.String(delegatorPolicyFlag.Name)
+	if path == "" {
+		return nil, nil
+	}
+	policy, err := delegator.LoadPolicy(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var gasPayer signer.Signer
+	if url := ctx.String(delegatorSignerFlag.Name); url != "" {
+		if gasPayer, err = signer.NewRemote(url); err != nil {
+			return nil, errors.Wrap(err, "connect delegator signer")
+		}
+	} else {
+		key, err := defaultKey()
+		if err != nil {
+			return nil, errors.Wrap(err, "load delegator key")
+		}
+		gasPayer = signer.NewLocal(key)
+	}
+
+	d, err := delegator.New(repo, state.NewStater(db), forkConfig, gasPayer, policy, db)
+	if err != nil {
+		return nil, err
 	}
+	log.Info("delegator enabled", "gasPayer", d.GasPayer())
+	return d, nil
 }
 
 func makeConfigDir(ctx *cli.Context) (string, error) {
@@ -420,6 +536,82 @@ func masterKeyPath(ctx *cli.Context) (string, error) {
 	return filepath.Join(configDir, "master.key"), nil
 }
 
+func masterKeystorePath(ctx *cli.Context) (string, error) {
+	configDir, err := makeConfigDir(ctx)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "master.keystore"), nil
+}
+
+// masterKeyPassphraseEnv is the environment variable of the passphrase of the encrypted master key.
+const masterKeyPassphraseEnv = "THOR_MASTER_KEY_PASSPHRASE"
+
+// readMasterKeyPassphrase reads the passphrase of the encrypted master key from the passphrase file, the
+// environment variable, or else the terminal or stdin.
+func readMasterKeyPassphrase(ctx *cli.Context, prompt string) (string, error) {
+	if path := ctx.String(masterKeyPassphraseFileFlag.Name); path != "" {
+		info, err := os.Stat(path)
+		if err != nil {
+			return "", errors.Wrap(err, "passphrase file")
+		}
+		if info.Mode().Perm()&0o077 != 0 {
+			log.Warn("passphrase file is accessible by other users", "path", path, "mode", info.Mode().Perm())
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", errors.Wrap(err, "passphrase file")
+		}
+		passphrase, _, _ := strings.Cut(string(data), "\n")
+		return strings.TrimSuffix(passphrase, "\r"), nil
+	}
+	if passphrase, ok := os.LookupEnv(masterKeyPassphraseEnv); ok {
+		return passphrase, nil
+	}
+	return newSecretReader().read(prompt)
+}
+
+// loadMasterKeyFile loads the master key from the config dir, decrypting the encrypted one, or generates a plaintext
+// one if neither exists. Plaintext keys are refused if encrypted ones are required.
+func loadMasterKeyFile(ctx *cli.Context) (*ecdsa.PrivateKey, error) {
+	keyPath, err := masterKeyPath(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keystorePath, err := masterKeystorePath(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keyjson, err := os.ReadFile(keystorePath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+		if ctx.Bool(masterKeyRequireEncryptedFlag.Name) {
+			return nil, errors.Errorf("encrypted master key required, run 'thor master-key --encrypt' to create %v", keystorePath)
+		}
+		key, err := loadOrGeneratePrivateKey(keyPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "load or generate master key")
+		}
+		return key, nil
+	}
+
+	if _, err := os.Stat(keyPath); err == nil {
+		return nil, errors.Errorf("both %v and %v exist, remove the plaintext one", keyPath, keystorePath)
+	}
+	passphrase, err := readMasterKeyPassphrase(ctx, "Enter passphrase of the master key: ")
+	if err != nil {
+		return nil, err
+	}
+	key, err := wallet.DecryptKey(keyjson, passphrase)
+	if err != nil {
+		return nil, errors.Wrap(err, "unlock master key")
+	}
+	return key, nil
+}
+
 func loadNodeMasterFromStdin() (*ecdsa.PrivateKey, error) {
 	var (
 		input string
@@ -442,27 +634,25 @@ func loadNodeMasterFromStdin() (*ecdsa.PrivateKey, error) {
 }
 
 func loadNodeMaster(ctx *cli.Context) (*node.Master, error) {
-	var key *ecdsa.PrivateKey
-	var err error
-
-	useStdin := ctx.Bool(masterKeyStdinFlag.Name)
-	if useStdin {
-		key, err = loadNodeMasterFromStdin()
+	master := &node.Master{}
+	if url := ctx.String(masterSignerFlag.Name); url != "" {
+		if ctx.Bool(masterKeyStdinFlag.Name) {
+			return nil, errors.Errorf("flag %v and %v are exclusive", masterSignerFlag.Name, masterKeyStdinFlag.Name)
+		}
+		s, err := signer.NewRemote(url)
 		if err != nil {
-			return nil, errors.Wrap(err, "read master key from stdin")
+			return nil, errors.Wrap(err, "connect master signer")
 		}
+		master.Signer = s
 	} else {
-		path, err := masterKeyPath(ctx)
+		key, err := loadMasterKey(ctx)
 		if err != nil {
 			return nil, err
 		}
-		key, err = loadOrGeneratePrivateKey(path)
-		if err != nil {
-			return nil, errors.Wrap(err, "load or generate master key")
-		}
+		master.Signer = signer.NewLocal(key)
 	}
 
-	master := &node.Master{PrivateKey: key}
+	var err error
 	if master.Beneficiary, err = beneficiary(ctx); err != nil {
 		return nil, err
 	}
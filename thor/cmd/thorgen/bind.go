@@ -0,0 +1,378 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"go/token"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// abiField is an entry of a Solidity JSON ABI.
+type abiField struct {
+	Type            string
+	Name            string
+	Constant        bool
+	Payable         bool
+	StateMutability string
+	Anonymous       bool
+	Inputs          []abiArg
+	Outputs         []abiArg
+}
+
+type abiArg struct {
+	Name    string
+	Type    string
+	Indexed bool
+}
+
+// artifact is the subset of a hardhat artifact needed to generate bindings.
+type artifact struct {
+	ContractName string          `json:"contractName"`
+	ABI          json.RawMessage `json:"abi"`
+	Bytecode     string          `json:"bytecode"`
+}
+
+type tmplContract struct {
+	Package     string
+	Type        string
+	ABI         string // Go literal of the ABI
+	Bytecode    string
+	Constructor *tmplMethod
+	Calls       []*tmplMethod
+	Transacts   []*tmplMethod
+	Events      []*tmplEvent
+}
+
+type tmplMethod struct {
+	Original string
+	Name     string
+	Payable  bool
+	Inputs   []*tmplArg
+	Outputs  []*tmplArg
+}
+
+type tmplEvent struct {
+	Original string
+	Name     string
+	Inputs   []*tmplArg
+}
+
+type tmplArg struct {
+	Name    string // parameter name
+	Field   string // struct field name
+	Type    string // Go type
+	Indexed bool
+}
+
+// Indexed returns the indexed inputs of the event.
+func (e *tmplEvent) Indexed() []*tmplArg {
+	var indexed []*tmplArg
+	for _, in := range e.Inputs {
+		if in.Indexed {
+			indexed = append(indexed, in)
+		}
+	}
+	return indexed
+}
+
+// loadContract reads a JSON ABI, or the ABI and bytecode of a hardhat artifact.
+func loadContract(data []byte) (name string, abiJSON []byte, bytecode string, err error) {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		var a artifact
+		if err := json.Unmarshal(data, &a); err != nil {
+			return "", nil, "", fmt.Errorf("decode artifact: %w", err)
+		}
+		if len(a.ABI) == 0 {
+			return "", nil, "", fmt.Errorf("decode artifact: abi not found")
+		}
+		if a.Bytecode == "0x" {
+			a.Bytecode = ""
+		}
+		return a.ContractName, a.ABI, a.Bytecode, nil
+	}
+	return "", data, "", nil
+}
+
+// bind generates the Go binding of a contract.
+func bind(pkg, typ string, abiJSON []byte, bytecode string) ([]byte, error) {
+	var fields []abiField
+	if err := json.Unmarshal(abiJSON, &fields); err != nil {
+		return nil, fmt.Errorf("decode abi: %w", err)
+	}
+	// the ABI is embedded in a compact form
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, abiJSON); err != nil {
+		return nil, fmt.Errorf("decode abi: %w", err)
+	}
+
+	contract := &tmplContract{
+		Package:  pkg,
+		Type:     capitalise(typ),
+		ABI:      quote(compact.String()),
+		Bytecode: bytecode,
+	}
+	// the identifiers declared by the binding, the methods of the contract type prefixed with a dot, so that the
+	// ones made from different Solidity names don't collide
+	t := contract.Type
+	declared := map[string]bool{
+		t: true, t + "ABI": true, t + "Bin": true, "Deploy" + t + "Clause": true, "New" + t: true, ".Address": true,
+	}
+	methods := make(map[string]bool)
+	events := make(map[string]bool)
+	for _, field := range fields {
+		switch field.Type {
+		case "constructor":
+			method, err := newMethod(&field)
+			if err != nil {
+				return nil, fmt.Errorf("constructor: %w", err)
+			}
+			contract.Constructor = method
+		case "function", "":
+			if methods[field.Name] {
+				return nil, fmt.Errorf("method %s: overloaded methods are not supported", field.Name)
+			}
+			methods[field.Name] = true
+			method, err := newMethod(&field)
+			if err != nil {
+				return nil, fmt.Errorf("method %s: %w", field.Name, err)
+			}
+			if field.Constant || field.StateMutability == "view" || field.StateMutability == "pure" {
+				method.Name = declare(declared, method.Name, func(name string) []string {
+					if len(method.Outputs) > 1 {
+						return []string{"." + name, t + name + "Output"}
+					}
+					return []string{"." + name}
+				})
+				contract.Calls = append(contract.Calls, method)
+			} else {
+				method.Name = declare(declared, method.Name, func(name string) []string {
+					return []string{"." + name + "Clause"}
+				})
+				contract.Transacts = append(contract.Transacts, method)
+			}
+		case "event":
+			if events[field.Name] {
+				return nil, fmt.Errorf("event %s: overloaded events are not supported", field.Name)
+			}
+			events[field.Name] = true
+			event, err := newEvent(&field)
+			if err != nil {
+				return nil, fmt.Errorf("event %s: %w", field.Name, err)
+			}
+			event.Name = declare(declared, event.Name, func(name string) []string {
+				return []string{t + name, ".Filter" + name, ".Watch" + name, ".Parse" + name, ".Parse" + name + "Message"}
+			})
+			contract.Events = append(contract.Events, event)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := bindingTemplate.Execute(&buf, contract); err != nil {
+		return nil, err
+	}
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format binding: %w", err)
+	}
+	return code, nil
+}
+
+func newMethod(field *abiField) (*tmplMethod, error) {
+	method := &tmplMethod{
+		Original: field.Name,
+		Name:     capitalise(field.Name),
+		Payable:  field.Payable || field.StateMutability == "payable",
+	}
+	var err error
+	if method.Inputs, err = newArgs(field.Inputs, "arg", false); err != nil {
+		return nil, err
+	}
+	if method.Outputs, err = newArgs(field.Outputs, "out", false); err != nil {
+		return nil, err
+	}
+	return method, nil
+}
+
+// eventFields are the fields of the event types besides the inputs.
+var eventFields = []string{"Meta", "Obsolete"}
+
+func newEvent(field *abiField) (*tmplEvent, error) {
+	inputs, err := newArgs(field.Inputs, "arg", true, eventFields...)
+	if err != nil {
+		return nil, err
+	}
+	return &tmplEvent{
+		Original: field.Name,
+		Name:     capitalise(field.Name),
+		Inputs:   inputs,
+	}, nil
+}
+
+// newArgs converts the arguments, with unique names and fields, the reserved fields besides.
+func newArgs(args []abiArg, prefix string, event bool, reservedFields ...string) ([]*tmplArg, error) {
+	list := make([]*tmplArg, 0, len(args))
+	names := make(map[string]bool)
+	fields := make(map[string]bool)
+	for _, f := range reservedFields {
+		fields[f] = true
+	}
+	for i, arg := range args {
+		typ, err := bindType(arg.Type)
+		if err != nil {
+			return nil, err
+		}
+		// indexed inputs of dynamic types are only logged as their hash
+		if event && arg.Indexed && isHashedTopic(arg.Type) {
+			typ = "thor.Bytes32"
+		}
+		name := identifier(arg.Name)
+		if name == "" {
+			name = fmt.Sprintf("%s%d", prefix, i)
+		}
+		list = append(list, &tmplArg{
+			Name:    unique(paramName(name), names),
+			Field:   unique(capitalise(name), fields),
+			Type:    typ,
+			Indexed: arg.Indexed,
+		})
+	}
+	return list, nil
+}
+
+var (
+	intTypeRe   = regexp.MustCompile(`^(u?)int([0-9]*)$`)
+	bytesTypeRe = regexp.MustCompile(`^bytes([0-9]+)$`)
+	arrayTypeRe = regexp.MustCompile(`^(.*)\[([0-9]*)\]$`)
+)
+
+// bindType converts a Solidity type to the Go type used by the abi package.
+func bindType(typ string) (string, error) {
+	if m := arrayTypeRe.FindStringSubmatch(typ); m != nil {
+		elem, err := bindType(m[1])
+		if err != nil {
+			return "", err
+		}
+		return "[" + m[2] + "]" + elem, nil
+	}
+	switch {
+	case typ == "address":
+		return "common.Address", nil
+	case typ == "bool":
+		return "bool", nil
+	case typ == "string":
+		return "string", nil
+	case typ == "bytes":
+		return "[]byte", nil
+	case bytesTypeRe.MatchString(typ):
+		return "[" + bytesTypeRe.FindStringSubmatch(typ)[1] + "]byte", nil
+	case intTypeRe.MatchString(typ):
+		m := intTypeRe.FindStringSubmatch(typ)
+		switch m[2] {
+		case "8", "16", "32", "64":
+			return m[1] + "int" + m[2], nil
+		}
+		return "*big.Int", nil
+	}
+	return "", fmt.Errorf("type %s is not supported", typ)
+}
+
+// quote returns the Go literal of s, as a raw string when possible.
+func quote(s string) string {
+	if strings.Contains(s, "`") {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
+
+func isHashedTopic(typ string) bool {
+	return typ == "string" || typ == "bytes" || strings.HasSuffix(typ, "]")
+}
+
+// identifier keeps the characters of a Solidity name allowed in Go identifiers, dropping the others such as the $
+// of the events of the builtin contracts, and the leading underscores.
+func identifier(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			b.WriteRune(r)
+		}
+	}
+	return strings.TrimLeft(b.String(), "_")
+}
+
+// capitalise makes an exported Go identifier from a Solidity name.
+func capitalise(name string) string {
+	name = identifier(name)
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		name = "X" + name
+	}
+	runes := []rune(name)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// reservedParams are the names used by the generated code besides the parameters: its variables, the parameters
+// of the generated methods, the imported packages and the builtin functions called.
+var reservedParams = map[string]bool{
+	"opts": true, "out": true, "err": true, "value": true, "events": true, "list": true,
+	"sub": true, "ev": true, "contract": true, "address": true, "client": true,
+	"filter": true, "pos": true,
+	"big": true, "common": true, "api": true, "thor": true, "thorclient": true, "bind": true, "tx": true, "tccommon": true,
+	"new": true, "len": true, "make": true, "append": true, "any": true, "nil": true,
+}
+
+// paramName makes a Go parameter name from a Solidity name.
+func paramName(name string) string {
+	name = identifier(name)
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		return "_" + name
+	}
+	runes := []rune(name)
+	runes[0] = unicode.ToLower(runes[0])
+	name = string(runes)
+	if token.IsKeyword(name) || reservedParams[name] {
+		return "_" + name
+	}
+	return name
+}
+
+// unique returns the name, suffixed with the first number making it unused if used already, and marks it used.
+func unique(name string, used map[string]bool) string {
+	candidate := name
+	for i := 0; used[candidate]; i++ {
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+	used[candidate] = true
+	return candidate
+}
+
+// declare returns the name, or the name suffixed with the first number, whose identifiers are not declared yet, and
+// declares them.
+func declare(declared map[string]bool, name string, identifiers func(name string) []string) string {
+	candidate := name
+	for i := 0; ; i++ {
+		ids := identifiers(candidate)
+		if !slices.ContainsFunc(ids, func(id string) bool { return declared[id] }) {
+			for _, id := range ids {
+				declared[id] = true
+			}
+			return candidate
+		}
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+}
+
+var bindingTemplate = template.Must(template.New("binding").Parse(bindingSource))
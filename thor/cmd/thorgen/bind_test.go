@@ -0,0 +1,161 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/builtin/gen"
+)
+
+func TestBind(t *testing.T) {
+	data, err := os.ReadFile("testdata/Registry.json")
+	require.NoError(t, err)
+
+	name, abiJSON, bytecode, err := loadContract(data)
+	require.NoError(t, err)
+	assert.Equal(t, "Registry", name)
+	assert.Equal(t, "0x6080", bytecode)
+
+	code, err := bind("registry", name, abiJSON, bytecode)
+	require.NoError(t, err)
+
+	for _, expected := range []string{
+		"package registry",
+		"func DeployRegistryClause(value *big.Int, owner common.Address, fee *big.Int) (*tx.Clause, error)",
+		"func NewRegistry(address thor.Address, client *thorclient.Client) (*Registry, error)",
+		"type RegistryInfoOutput struct",
+		"func (_Registry *Registry) Info(id [32]byte, opts ...thorclient.Option) (*RegistryInfoOutput, error)",
+		"func (_Registry *Registry) Ping(opts ...thorclient.Option) error",
+		"func (_Registry *Registry) RegisterClause(value *big.Int, _type string, _value [2]uint8) (*tx.Clause, error)",
+		"Name  thor.Bytes32",
+		"func (_Registry *Registry) FilterRegistered(filter *bind.FilterOpts, name []thor.Bytes32, owner []common.Address) ([]*RegistryRegistered, error)",
+		"func (_Registry *Registry) WatchRegistered(pos string, name []thor.Bytes32, owner []common.Address) (*tccommon.Subscription[*RegistryRegistered], error)",
+		"func (_Registry *Registry) ParseRegistered(ev *api.FilteredEvent) (*RegistryRegistered, error)",
+		"func (_Registry *Registry) ParseRegisteredMessage(ev *api.EventMessage) (*RegistryRegistered, error)",
+	} {
+		assert.Contains(t, string(code), expected)
+	}
+
+	// plain ABI, without bytecode
+	name, abiJSON, bytecode, err = loadContract(gen.MustAsset("compiled/Energy.abi"))
+	require.NoError(t, err)
+	assert.Empty(t, name)
+	assert.Empty(t, bytecode)
+
+	code, err = bind("energy", "energy", abiJSON, bytecode)
+	require.NoError(t, err)
+	assert.Contains(t, string(code), "func (_Energy *Energy) BalanceOf(owner common.Address, opts ...thorclient.Option) (*big.Int, error)")
+	assert.Contains(t, string(code), "func (_Energy *Energy) TransferClause(to common.Address, amount *big.Int) (*tx.Clause, error)")
+	assert.NotContains(t, string(code), "DeployEnergyClause")
+}
+
+func TestBind_Identifiers(t *testing.T) {
+	// names not allowed in Go, or colliding with the parameters, fields and methods of the generated code
+	abiJSON := `[
+		{"type":"function","name":"$get","constant":true,"inputs":[{"name":"filter","type":"uint256"},{"name":"_filter","type":"uint256"},{"name":"1st","type":"uint8"}],"outputs":[{"name":"a","type":"uint256"},{"name":"A","type":"uint256"}]},
+		{"type":"function","name":"get","constant":true,"inputs":[{"name":"pos","type":"string"}],"outputs":[]},
+		{"type":"function","name":"address","constant":true,"inputs":[],"outputs":[]},
+		{"type":"event","name":"$Changed","inputs":[{"name":"meta","type":"uint256","indexed":true},{"name":"Obsolete","type":"bool","indexed":false},{"name":"$len","type":"uint8","indexed":false}]},
+		{"type":"event","name":"Changed","inputs":[]}
+	]`
+	code, err := bind("p", "T", []byte(abiJSON), "")
+	require.NoError(t, err)
+
+	for _, expected := range []string{
+		"func (_T *T) Get(_filter *big.Int, _filter0 *big.Int, _1st uint8, opts ...thorclient.Option) (*TGetOutput, error)",
+		"func (_T *T) Get0(_pos string, opts ...thorclient.Option) error",
+		"func (_T *T) Address0(opts ...thorclient.Option) error",
+		"Meta0     *big.Int",
+		"Obsolete0 bool",
+		"Len       uint8",
+		"func (_T *T) FilterChanged(filter *bind.FilterOpts, meta []*big.Int) ([]*TChanged, error)",
+		"func (_T *T) FilterChanged0(filter *bind.FilterOpts) ([]*TChanged0, error)",
+	} {
+		assert.Contains(t, string(code), expected)
+	}
+}
+
+// TestBind_Compile builds the bindings generated for the test contract, the builtin ones and the ones with names
+// to be sanitised.
+func TestBind_Compile(t *testing.T) {
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go not found")
+	}
+
+	data, err := os.ReadFile("testdata/Registry.json")
+	require.NoError(t, err)
+	_, registry, bytecode, err := loadContract(data)
+	require.NoError(t, err)
+
+	contracts := map[string]struct {
+		abi      []byte
+		bytecode string
+	}{
+		"registry":       {registry, bytecode},
+		"energy":         {gen.MustAsset("compiled/Energy.abi"), ""},
+		"prototypeevent": {gen.MustAsset("compiled/PrototypeEvent.abi"), ""},
+		"identifiers": {[]byte(`[
+			{"type":"function","name":"$get","constant":true,"inputs":[{"name":"filter","type":"uint256"},{"name":"_filter","type":"uint256"}],"outputs":[{"name":"a","type":"uint256"},{"name":"A","type":"uint256"}]},
+			{"type":"function","name":"get","inputs":[{"name":"pos","type":"string"},{"name":"new","type":"address[]"}],"outputs":[]},
+			{"type":"event","name":"$Changed","inputs":[{"name":"meta","type":"uint256","indexed":true},{"name":"Obsolete","type":"bool","indexed":false}]},
+			{"type":"event","name":"Changed","inputs":[{"name":"len","type":"bytes32","indexed":true}]}
+		]`), ""},
+	}
+
+	// a directory of the module, for the generated code to import its packages, ignored by ./...
+	dir, err := os.MkdirTemp("testdata", "bind")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	for pkg, contract := range contracts {
+		code, err := bind(pkg, pkg, contract.abi, contract.bytecode)
+		require.NoError(t, err, pkg)
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, pkg), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, pkg, pkg+".go"), code, 0o644))
+	}
+
+	cmd := exec.Command(goBin, "vet", "./"+filepath.ToSlash(dir)+"/...")
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+func TestBind_Unsupported(t *testing.T) {
+	for name, abiJSON := range map[string]string{
+		"tuple":    `[{"type":"function","name":"f","inputs":[{"name":"t","type":"tuple"}],"outputs":[]}]`,
+		"overload": `[{"type":"function","name":"f","inputs":[]},{"type":"function","name":"f","inputs":[{"name":"a","type":"uint256"}]}]`,
+		"invalid":  `{}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := bind("p", "T", []byte(abiJSON), "")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestBindType(t *testing.T) {
+	for typ, expected := range map[string]string{
+		"address":      "common.Address",
+		"uint8":        "uint8",
+		"int64":        "int64",
+		"uint256":      "*big.Int",
+		"int":          "*big.Int",
+		"bytes4":       "[4]byte",
+		"bytes":        "[]byte",
+		"string[]":     "[]string",
+		"uint256[2][]": "[][2]*big.Int",
+	} {
+		actual, err := bindType(typ)
+		require.NoError(t, err, typ)
+		assert.Equal(t, expected, actual, typ)
+	}
+}
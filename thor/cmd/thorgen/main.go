@@ -0,0 +1,125 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// thorgen generates Go bindings of Solidity contracts on top of thorclient.
+//
+// It takes a JSON ABI or a hardhat artifact, and generates a contract type with methods for
+// constant calls, clause builders for transactions, and typed event filters, subscriptions and decoders:
+//
+//	thorgen --abi artifacts/B3TR.json --pkg b3tr --out b3tr/b3tr.go
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	cli "gopkg.in/urfave/cli.v1"
+)
+
+var (
+	version       string
+	gitCommit     string
+	gitTag        string
+	copyrightYear string
+
+	abiFlag = cli.StringFlag{
+		Name:  "abi",
+		Usage: "path to the Solidity JSON ABI or hardhat artifact to bind",
+	}
+	binFlag = cli.StringFlag{
+		Name:  "bin",
+		Usage: "path to the contract bytecode, to generate a deploy clause builder (not needed with artifacts)",
+	}
+	pkgFlag = cli.StringFlag{
+		Name:  "pkg",
+		Usage: "package name of the generated binding",
+	}
+	typeFlag = cli.StringFlag{
+		Name:  "type",
+		Usage: "name of the contract type (default: the contract name of the artifact, or the ABI file name)",
+	}
+	outFlag = cli.StringFlag{
+		Name:  "out",
+		Usage: "output file of the generated binding (default: stdout)",
+	}
+
+	flags = []cli.Flag{
+		abiFlag,
+		binFlag,
+		pkgFlag,
+		typeFlag,
+		outFlag,
+	}
+)
+
+func run(ctx *cli.Context) error {
+	abiPath := ctx.String(abiFlag.Name)
+	if abiPath == "" {
+		return errors.New("--abi is required")
+	}
+	pkg := ctx.String(pkgFlag.Name)
+	if pkg == "" {
+		return errors.New("--pkg is required")
+	}
+
+	data, err := os.ReadFile(abiPath)
+	if err != nil {
+		return err
+	}
+	name, abiJSON, bytecode, err := loadContract(data)
+	if err != nil {
+		return err
+	}
+	if binPath := ctx.String(binFlag.Name); binPath != "" {
+		bin, err := os.ReadFile(binPath)
+		if err != nil {
+			return err
+		}
+		bytecode = strings.TrimSpace(string(bin))
+		if !strings.HasPrefix(bytecode, "0x") {
+			bytecode = "0x" + bytecode
+		}
+	}
+
+	typ := ctx.String(typeFlag.Name)
+	if typ == "" {
+		typ = name
+	}
+	if typ == "" {
+		typ = strings.TrimSuffix(filepath.Base(abiPath), filepath.Ext(abiPath))
+	}
+
+	code, err := bind(pkg, typ, abiJSON, bytecode)
+	if err != nil {
+		return err
+	}
+	if out := ctx.String(outFlag.Name); out != "" {
+		return os.WriteFile(out, code, 0o644)
+	}
+	_, err = os.Stdout.Write(code)
+	return err
+}
+
+func main() {
+	versionMeta := "release"
+	if gitTag == "" {
+		versionMeta = "dev"
+	}
+	app := cli.App{
+		Version:   fmt.Sprintf("%s-%s-%s", version, gitCommit, versionMeta),
+		Name:      "Thorgen",
+		Usage:     "VeChain Thor contract binding generator",
+		Copyright: fmt.Sprintf("2018-%s VeChain Foundation <https://vechain.org/>", copyrightYear),
+		Flags:     flags,
+		Action:    run,
+	}
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
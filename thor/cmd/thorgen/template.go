@@ -0,0 +1,161 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+const bindingSource = `// Code generated by thorgen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/thorclient"
+	"github.com/vechain/thor/v2/thorclient/bind"
+	"github.com/vechain/thor/v2/tx"
+
+	tccommon "github.com/vechain/thor/v2/thorclient/common"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = common.Address{}
+	_ = api.FilteredEvent{}
+	_ = thor.Bytes32{}
+	_ = tx.Clause{}
+	_ = tccommon.Subscription[any]{}
+)
+
+{{$type := .Type}}
+// {{$type}}ABI is the input ABI used to generate the binding from.
+const {{$type}}ABI = {{.ABI}}
+
+{{if .Bytecode}}
+// {{$type}}Bin is the compiled bytecode used for deploying new contracts.
+const {{$type}}Bin = {{printf "%q" .Bytecode}}
+
+// Deploy{{$type}}Clause returns a clause deploying a new {{$type}} contract.
+func Deploy{{$type}}Clause({{if and .Constructor .Constructor.Payable}}value *big.Int, {{end}}{{if .Constructor}}{{range .Constructor.Inputs}}{{.Name}} {{.Type}}, {{end}}{{end}}) (*tx.Clause, error) {
+	return bind.DeployClause({{$type}}ABI, {{$type}}Bin, {{if and .Constructor .Constructor.Payable}}value{{else}}nil{{end}}{{if .Constructor}}{{range .Constructor.Inputs}}, {{.Name}}{{end}}{{end}})
+}
+{{end}}
+
+// {{$type}} is a Go binding of the contract.
+type {{$type}} struct {
+	contract *bind.Contract
+}
+
+// New{{$type}} creates a binding of the contract deployed at address.
+func New{{$type}}(address thor.Address, client *thorclient.Client) (*{{$type}}, error) {
+	contract, err := bind.NewContract(client, address, {{$type}}ABI)
+	if err != nil {
+		return nil, err
+	}
+	return &{{$type}}{contract: contract}, nil
+}
+
+// Address returns the address of the contract.
+func (_{{$type}} *{{$type}}) Address() thor.Address {
+	return _{{$type}}.contract.Address()
+}
+
+{{range .Calls}}
+{{if gt (len .Outputs) 1}}
+// {{$type}}{{.Name}}Output holds the outputs of the {{.Original}} method.
+type {{$type}}{{.Name}}Output struct {
+	{{range .Outputs}}{{.Field}} {{.Type}}
+	{{end}}
+}
+
+// {{.Name}} calls the constant method {{.Original}}.
+func (_{{$type}} *{{$type}}) {{.Name}}({{range .Inputs}}{{.Name}} {{.Type}}, {{end}}opts ...thorclient.Option) (*{{$type}}{{.Name}}Output, error) {
+	out := new({{$type}}{{.Name}}Output)
+	if err := _{{$type}}.contract.Call("{{.Original}}", []any{ {{range $i, $o := .Outputs}}{{if $i}}, {{end}}&out.{{$o.Field}}{{end}} }, opts{{range .Inputs}}, {{.Name}}{{end}}); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+{{else if .Outputs}}
+// {{.Name}} calls the constant method {{.Original}}.
+func (_{{$type}} *{{$type}}) {{.Name}}({{range .Inputs}}{{.Name}} {{.Type}}, {{end}}opts ...thorclient.Option) ({{(index .Outputs 0).Type}}, error) {
+	var out {{(index .Outputs 0).Type}}
+	err := _{{$type}}.contract.Call("{{.Original}}", []any{&out}, opts{{range .Inputs}}, {{.Name}}{{end}})
+	return out, err
+}
+{{else}}
+// {{.Name}} calls the constant method {{.Original}}.
+func (_{{$type}} *{{$type}}) {{.Name}}({{range .Inputs}}{{.Name}} {{.Type}}, {{end}}opts ...thorclient.Option) error {
+	return _{{$type}}.contract.Call("{{.Original}}", nil, opts{{range .Inputs}}, {{.Name}}{{end}})
+}
+{{end}}
+{{end}}
+
+{{range .Transacts}}
+// {{.Name}}Clause returns a clause calling the method {{.Original}}.
+func (_{{$type}} *{{$type}}) {{.Name}}Clause({{if .Payable}}value *big.Int, {{end}}{{range .Inputs}}{{.Name}} {{.Type}}, {{end}}) (*tx.Clause, error) {
+	return _{{$type}}.contract.Clause("{{.Original}}", {{if .Payable}}value{{else}}nil{{end}}{{range .Inputs}}, {{.Name}}{{end}})
+}
+{{end}}
+
+{{range .Events}}
+// {{$type}}{{.Name}} represents a {{.Original}} event of the contract.
+type {{$type}}{{.Name}} struct {
+	{{range .Inputs}}{{.Field}} {{.Type}}
+	{{end}}
+	Meta     api.LogMeta
+	Obsolete bool // set on events of subscriptions only
+}
+
+// Filter{{.Name}} queries the {{.Original}} events matching the given values of indexed inputs,
+// an empty list of values matches any value.
+func (_{{$type}} *{{$type}}) Filter{{.Name}}(filter *bind.FilterOpts{{range .Indexed}}, {{.Name}} []{{.Type}}{{end}}) ([]*{{$type}}{{.Name}}, error) {
+	events, err := _{{$type}}.contract.FilterEvents("{{.Original}}", filter{{range .Indexed}}, bind.Topics({{.Name}}){{end}})
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*{{$type}}{{.Name}}, 0, len(events))
+	for i := range events {
+		ev, err := _{{$type}}.Parse{{.Name}}(&events[i])
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, ev)
+	}
+	return list, nil
+}
+
+// Watch{{.Name}} subscribes to the {{.Original}} events matching the given values of indexed inputs,
+// starting from the block pos.
+func (_{{$type}} *{{$type}}) Watch{{.Name}}(pos string{{range .Indexed}}, {{.Name}} []{{.Type}}{{end}}) (*tccommon.Subscription[*{{$type}}{{.Name}}], error) {
+	sub, err := _{{$type}}.contract.WatchEvents("{{.Original}}", pos{{range .Indexed}}, bind.Topics({{.Name}}){{end}})
+	if err != nil {
+		return nil, err
+	}
+	return bind.Watch(sub, _{{$type}}.Parse{{.Name}}Message), nil
+}
+
+// Parse{{.Name}} decodes a {{.Original}} event returned by the logs API.
+func (_{{$type}} *{{$type}}) Parse{{.Name}}(ev *api.FilteredEvent) (*{{$type}}{{.Name}}, error) {
+	out := &{{$type}}{{.Name}}{Meta: ev.Meta}
+	if err := _{{$type}}.contract.UnpackEvent("{{.Original}}", bind.FilteredTopics(ev.Topics), ev.Data{{range .Inputs}}, &out.{{.Field}}{{end}}); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Parse{{.Name}}Message decodes a {{.Original}} event received from a subscription.
+func (_{{$type}} *{{$type}}) Parse{{.Name}}Message(ev *api.EventMessage) (*{{$type}}{{.Name}}, error) {
+	out := &{{$type}}{{.Name}}{Meta: ev.Meta, Obsolete: ev.Obsolete}
+	if err := _{{$type}}.contract.UnpackEvent("{{.Original}}", ev.Topics, ev.Data{{range .Inputs}}, &out.{{.Field}}{{end}}); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+{{end}}
+`
@@ -67,6 +67,18 @@ func (c *Consensus) Process(parentSummary *chain.BlockSummary, blk *block.Block,
 	return stage, receipts, nil
 }
 
+// ProcessSolo process a block packed in solo mode, which is neither scheduled nor aligned to the block interval.
+// The header checks are skipped, while the block body is validated and executed as in Process.
+func (c *Consensus) ProcessSolo(parentSummary *chain.BlockSummary, blk *block.Block, blockConflicts uint32) (*state.Stage, tx.Receipts, error) {
+	if blk.Header().Timestamp() <= parentSummary.Header.Timestamp() {
+		return nil, nil, consensusError(fmt.Sprintf("block timestamp behind parents: parent %v, current %v", parentSummary.Header.Timestamp(), blk.Header().Timestamp()))
+	}
+	if err := c.validateBlockBody(blk); err != nil {
+		return nil, nil, err
+	}
+	return c.verifyBlock(blk, c.stater.NewState(parentSummary.Root()), blockConflicts)
+}
+
 func (c *Consensus) NewRuntimeForReplay(header *block.Header, skipPoA bool) (*runtime.Runtime, error) {
 	signer, err := header.Signer()
 	if err != nil {
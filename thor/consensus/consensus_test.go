@@ -267,6 +267,47 @@ func TestNewRuntimeForReplayWithError(t *testing.T) {
 	assert.Nil(t, runtime)
 }
 
+func TestProcessSolo(t *testing.T) {
+	tc, err := newTestConsensus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parentSum, err := tc.con.repo.GetBlockSummary(tc.parent.Header().ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// blocks packed in solo mode are neither scheduled nor aligned to the block interval
+	proposer := genesis.DevAccounts()[0]
+	flow, err := packer.New(tc.con.repo, tc.con.stater, proposer.Address, &proposer.Address, tc.forkConfig, 0).
+		Mock(parentSum, tc.parent.Header().Timestamp()+1, tc.parent.Header().GasLimit())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := flow.Adopt(txSign(txBuilder(tc.tag, tx.TypeLegacy))); err != nil {
+		t.Fatal(err)
+	}
+	blk, _, _, err := flow.Pack(proposer.PrivateKey, 0, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = tc.con.Process(parentSum, blk, flow.When(), 0)
+	assert.True(t, IsCritical(err))
+
+	_, receipts, err := tc.con.ProcessSolo(parentSum, blk, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, blk.Header().ReceiptsRoot(), receipts.RootHash())
+
+	// the block body is still validated
+	invalid, err := tc.sign(tc.builder(blk.Header()).Transaction(txSign(txBuilder(tc.tag+1, tx.TypeLegacy))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = tc.con.ProcessSolo(parentSum, invalid, 0)
+	assert.True(t, IsCritical(err))
+}
+
 func TestValidateBlockHeader(t *testing.T) {
 	tc, err := newTestConsensus()
 	if err != nil {
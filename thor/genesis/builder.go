@@ -33,8 +33,9 @@ type Builder struct {
 }
 
 type call struct {
-	clause *tx.Clause
-	caller thor.Address
+	clause   *tx.Clause
+	caller   thor.Address
+	deployAt *thor.Address // the contract address if it's a deployment
 }
 
 // Timestamp set timestamp.
@@ -57,7 +58,13 @@ func (b *Builder) State(proc func(state *state.State) error) *Builder {
 
 // Call add a contract call.
 func (b *Builder) Call(clause *tx.Clause, caller thor.Address) *Builder {
-	b.calls = append(b.calls, call{clause, caller})
+	b.calls = append(b.calls, call{clause, caller, nil})
+	return b
+}
+
+// Deploy add a contract deployment, which runs the creation code and puts the contract at the address.
+func (b *Builder) Deploy(addr thor.Address, code []byte, deployer thor.Address) *Builder {
+	b.calls = append(b.calls, call{tx.NewClause(nil).WithData(code), deployer, &addr})
 	return b
 }
 
@@ -98,14 +105,25 @@ func (b *Builder) Build(stater *state.Stater) (blk *block.Block, events tx.Event
 	}, b.forkConfig)
 
 	for _, call := range b.calls {
-		exec, _ := rt.PrepareClause(call.clause, 0, math.MaxUint64, &xenv.TransactionContext{
-			Origin: call.caller,
-		})
+		var (
+			txCtx = &xenv.TransactionContext{Origin: call.caller}
+			exec  func() (*runtime.Output, bool, error)
+		)
+		if call.deployAt != nil {
+			// contracts created by the constructor get addresses derived from the deployed one
+			txCtx.ID = thor.BytesToBytes32(call.deployAt.Bytes())
+			exec, _ = rt.PrepareDeployment(call.clause.Data(), *call.deployAt, math.MaxUint64, txCtx)
+		} else {
+			exec, _ = rt.PrepareClause(call.clause, 0, math.MaxUint64, txCtx)
+		}
 		out, _, err := exec()
 		if err != nil {
 			return nil, nil, nil, errors.Wrap(err, "call")
 		}
 		if out.VMErr != nil {
+			if call.deployAt != nil {
+				return nil, nil, nil, errors.Wrapf(out.VMErr, "deploy %v: vm (output %x)", call.deployAt, out.Data)
+			}
 			return nil, nil, nil, errors.Wrap(out.VMErr, "vm")
 		}
 		events = append(events, out.Events...)
@@ -13,6 +13,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/vechain/thor/v2/builtin"
 	"github.com/vechain/thor/v2/state"
 	"github.com/vechain/thor/v2/thor"
@@ -45,6 +46,21 @@ func NewCustomNet(gen *CustomGenesis) (*Genesis, error) {
 		executor = builtin.Executor.Address
 	}
 
+	deployments := make(map[thor.Address][]byte)
+	for _, a := range gen.Accounts {
+		if a.Deploy == nil {
+			continue
+		}
+		if len(a.Code) > 0 || len(a.Storage) > 0 || len(a.RawStorage) > 0 {
+			return nil, fmt.Errorf("%s: code and storage are set by the deployment", a.Address)
+		}
+		code, err := a.Deploy.creationCode()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", a.Address, err)
+		}
+		deployments[a.Address] = code
+	}
+
 	builder := new(Builder).
 		Timestamp(launchTime).
 		GasLimit(gen.GasLimit).
@@ -97,6 +113,12 @@ func NewCustomNet(gen *CustomGenesis) (*Genesis, error) {
 						return err
 					}
 				}
+				// the master of a deployed contract is set after the deployment
+				if a.Master != nil && a.Deploy == nil {
+					if err := state.SetMaster(a.Address, *a.Master); err != nil {
+						return err
+					}
+				}
 				if len(a.Code) > 0 {
 					code, err := hexutil.Decode(a.Code)
 					if err != nil {
@@ -111,6 +133,12 @@ func NewCustomNet(gen *CustomGenesis) (*Genesis, error) {
 						state.SetStorage(a.Address, thor.MustParseBytes32(k), v)
 					}
 				}
+				for k, v := range a.RawStorage {
+					if _, _, rest, err := rlp.Split(v); err != nil || len(rest) > 0 {
+						return fmt.Errorf("invalid raw storage value for address: %s", a.Address)
+					}
+					state.SetRawStorage(a.Address, thor.MustParseBytes32(k), rlp.RawValue(v))
+				}
 			}
 
 			return builtin.Energy.Native(state, launchTime).SetInitialSupply(tokenSupply, energySupply)
@@ -183,6 +211,18 @@ func NewCustomNet(gen *CustomGenesis) (*Genesis, error) {
 		}
 	}
 
+	// deploy contracts, after the builtin contracts are initialized
+	for _, a := range gen.Accounts {
+		if a.Deploy == nil {
+			continue
+		}
+		builder.Deploy(a.Address, deployments[a.Address], a.Deploy.Deployer)
+		if a.Master != nil {
+			data := mustEncodeInput(builtin.Prototype.ABI, "setMaster", a.Address, *a.Master)
+			builder.Call(tx.NewClause(&builtin.Prototype.Address).WithData(data), a.Deploy.Deployer)
+		}
+	}
+
 	if len(gen.ExtraData) > 0 {
 		var extra [28]byte
 		copy(extra[:], gen.ExtraData)
@@ -201,8 +241,41 @@ type Account struct {
 	Address thor.Address            `json:"address"`
 	Balance *HexOrDecimal256        `json:"balance"`
 	Energy  *HexOrDecimal256        `json:"energy"`
+	Master  *thor.Address           `json:"master,omitempty"`
 	Code    string                  `json:"code"`
 	Storage map[string]thor.Bytes32 `json:"storage"`
+	// RawStorage are RLP encoded storage values which are not words, such as the lists kept by the builtin
+	// prototype contract on the accounts.
+	RawStorage map[string]hexutil.Bytes `json:"rawStorage,omitempty"`
+	Deploy     *Deployment              `json:"deploy,omitempty"`
+}
+
+// Deployment is the contract deployment of an account, which runs the creation code instead of setting the code
+// and storage. The deployer is the sender of the deployment, and the master of the contract unless the account
+// sets one.
+type Deployment struct {
+	Bytecode string       `json:"bytecode"`
+	Args     string       `json:"args,omitempty"` // ABI encoded constructor arguments
+	Deployer thor.Address `json:"deployer"`
+}
+
+// creationCode returns the creation code with the constructor arguments appended.
+func (d *Deployment) creationCode() ([]byte, error) {
+	code, err := hexutil.Decode(d.Bytecode)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bytecode: %w", err)
+	}
+	if len(code) == 0 {
+		return nil, errors.New("empty bytecode")
+	}
+	if d.Args != "" {
+		args, err := hexutil.Decode(d.Args)
+		if err != nil {
+			return nil, fmt.Errorf("invalid constructor args: %w", err)
+		}
+		code = append(code, args...)
+	}
+	return code, nil
 }
 
 // Authority is the authority node info
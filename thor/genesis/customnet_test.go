@@ -6,6 +6,7 @@
 package genesis_test
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"math"
 	"math/big"
@@ -13,7 +14,10 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/state"
 	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
 )
 
 func CustomNetWithParams(t *testing.T, executor genesis.Executor, baseGasPrice genesis.HexOrDecimal256, rewardRatio genesis.HexOrDecimal256, proposerEndorsement genesis.HexOrDecimal256) genesis.CustomGenesis {
@@ -183,3 +187,85 @@ func TestHexOrDecimal256MarshalUnmarshalWithNilError(t *testing.T) {
 	err := unmarshaledValue.UnmarshalJSON([]byte(originalHex))
 	assert.NoError(t, err, "Unmarshaling should not produce an error")
 }
+
+func TestNewCustomNetDeploy(t *testing.T) {
+	customGenesis := CustomNetWithParams(t, genesis.Executor{}, genesis.HexOrDecimal256{}, genesis.HexOrDecimal256{}, genesis.HexOrDecimal256{})
+
+	var (
+		token    = thor.BytesToAddress([]byte("token"))
+		registry = thor.BytesToAddress([]byte("registry"))
+		deployer = genesis.DevAccounts()[0].Address
+		master   = genesis.DevAccounts()[1].Address
+	)
+	// the constructor stores the argument at slot 0 and the caller at slot 1, the runtime code is 0x6000
+	bytecode := "0x60206020380360003960005160005533600155600260" + "1f" + "60003960026000f36000"
+	customGenesis.Accounts = []genesis.Account{
+		{
+			Address: token,
+			Balance: (*genesis.HexOrDecimal256)(big.NewInt(100)),
+			Master:  &master,
+			Deploy: &genesis.Deployment{
+				Bytecode: bytecode,
+				Args:     "0x000000000000000000000000000000000000000000000000000000000000002a",
+				Deployer: deployer,
+			},
+		},
+		{
+			Address: registry,
+			Deploy: &genesis.Deployment{
+				Bytecode: bytecode,
+				Args:     "0x" + hex.EncodeToString(thor.BytesToBytes32(token.Bytes()).Bytes()),
+				Deployer: deployer,
+			},
+		},
+	}
+	assert.Empty(t, customGenesis.Validate())
+
+	gene, err := genesis.NewCustomNet(&customGenesis)
+	assert.NoError(t, err)
+
+	stater := state.NewStater(muxdb.NewMem())
+	blk, _, _, err := gene.Build(stater)
+	assert.NoError(t, err)
+	st := stater.NewState(trie.Root{Hash: blk.Header().StateRoot()})
+
+	for _, c := range []struct {
+		addr    thor.Address
+		arg     thor.Bytes32
+		master  thor.Address
+		balance *big.Int
+	}{
+		{token, thor.BytesToBytes32([]byte{42}), master, big.NewInt(100)},
+		{registry, thor.BytesToBytes32(token.Bytes()), deployer, big.NewInt(0)},
+	} {
+		code, err := st.GetCode(c.addr)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x60, 0x00}, code)
+
+		arg, err := st.GetStorage(c.addr, thor.Bytes32{})
+		assert.NoError(t, err)
+		assert.Equal(t, c.arg, arg)
+
+		caller, err := st.GetStorage(c.addr, thor.BytesToBytes32([]byte{1}))
+		assert.NoError(t, err)
+		assert.Equal(t, thor.BytesToBytes32(deployer.Bytes()), caller)
+
+		m, err := st.GetMaster(c.addr)
+		assert.NoError(t, err)
+		assert.Equal(t, c.master, m)
+
+		balance, err := st.GetBalance(c.addr)
+		assert.NoError(t, err)
+		assert.Equal(t, c.balance, balance)
+	}
+
+	// code and storage are set by the deployment
+	customGenesis.Accounts[0].Code = "0x6000"
+	_, err = genesis.NewCustomNet(&customGenesis)
+	assert.Error(t, err)
+
+	// a reverted constructor fails the genesis
+	customGenesis.Accounts[0].Code = ""
+	customGenesis.Accounts[0].Deploy.Bytecode = "0x60006000fd"
+	assert.Panics(t, func() { _, _ = genesis.NewCustomNet(&customGenesis) })
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package genesis
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/vechain/thor/v2/builtin"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/vm"
+)
+
+// maxApprovers is the number of approvers the executor contract accepts.
+const maxApprovers = 255
+
+// StrictError is a violation of a rule of Validate which NewCustomNet doesn't enforce: the genesis builds, though
+// likely not as intended, e.g. with its extra data truncated.
+type StrictError struct {
+	error
+}
+
+// IsStrict returns whether the error is a StrictError, which genesis files built by earlier versions may have.
+func IsStrict(err error) bool {
+	_, ok := err.(*StrictError)
+	return ok
+}
+
+// Validate checks the custom genesis against the rules enforced by NewCustomNet, including the ones of the
+// builtin contracts called to build the genesis block, which otherwise cause NewCustomNet to panic, and against
+// stricter rules, whose violations are StrictErrors. All the violations are returned, nil if the genesis is valid.
+func (gen *CustomGenesis) Validate() []error {
+	var errs []error
+	add := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+	addStrict := func(format string, args ...any) {
+		errs = append(errs, &StrictError{fmt.Errorf(format, args...)})
+	}
+
+	if gen.ForkConfig == nil {
+		add("forkConfig: required")
+	}
+	if len(gen.ExtraData) > 28 {
+		addStrict("extraData: %v bytes exceeds the limit of 28 bytes", len(gen.ExtraData))
+	}
+
+	accounts := make(map[thor.Address]bool)
+	for i, a := range gen.Accounts {
+		field := fmt.Sprintf("accounts[%v] %v", i, a.Address)
+		if accounts[a.Address] {
+			addStrict("%v: duplicated address", field)
+		}
+		accounts[a.Address] = true
+
+		if b := (*big.Int)(a.Balance); b != nil && b.Sign() < 0 {
+			add("%v: balance must be a non-negative integer", field)
+		}
+		if e := (*big.Int)(a.Energy); e != nil && e.Sign() < 0 {
+			add("%v: energy must be a non-negative integer", field)
+		}
+		if len(a.Code) > 0 {
+			if _, err := hexutil.Decode(a.Code); err != nil {
+				add("%v: invalid code: %v", field, err)
+			}
+		}
+		for k := range a.Storage {
+			if _, err := thor.ParseBytes32(k); err != nil {
+				add("%v: invalid storage key %q: %v", field, k, err)
+			}
+		}
+		for k, v := range a.RawStorage {
+			if _, err := thor.ParseBytes32(k); err != nil {
+				add("%v: invalid raw storage key %q: %v", field, k, err)
+			}
+			if _, _, rest, err := rlp.Split(v); err != nil || len(rest) > 0 {
+				add("%v: invalid raw storage value of %q: not a RLP value", field, k)
+			}
+		}
+
+		if d := a.Deploy; d != nil {
+			if len(a.Code) > 0 || len(a.Storage) > 0 || len(a.RawStorage) > 0 {
+				add("%v: code and storage are set by the deployment", field)
+			}
+			if _, err := d.creationCode(); err != nil {
+				add("%v: deploy: %v", field, err)
+			}
+			if d.Deployer.IsZero() {
+				add("%v: deploy: invalid deployer", field)
+			}
+			if isReservedAddress(a.Address) {
+				add("%v: deploy: address of a builtin or precompiled contract", field)
+			}
+		}
+	}
+
+	for _, p := range []struct {
+		name  string
+		value *HexOrDecimal256
+	}{
+		{"rewardRatio", gen.Params.RewardRatio},
+		{"baseGasPrice", gen.Params.BaseGasPrice},
+		{"proposerEndorsement", gen.Params.ProposerEndorsement},
+	} {
+		if v := (*big.Int)(p.value); v != nil && v.Sign() < 0 {
+			add("params.%v: must be a non-negative integer", p.name)
+		}
+	}
+	if m := gen.Params.MaxBlockProposers; m != nil && *m == 0 {
+		add("params.maxBlockProposers: must be a positive integer")
+	}
+
+	if len(gen.Authority) == 0 {
+		add("authority: at least one authority node required")
+	}
+	masters := make(map[thor.Address]bool)
+	for i, node := range gen.Authority {
+		field := fmt.Sprintf("authority[%v] %v", i, node.MasterAddress)
+		if node.MasterAddress.IsZero() {
+			add("%v: invalid master address", field)
+		} else if masters[node.MasterAddress] {
+			add("%v: duplicated master address", field)
+		}
+		masters[node.MasterAddress] = true
+
+		if node.EndorsorAddress.IsZero() {
+			add("%v: invalid endorsor address", field)
+		}
+		if node.Identity.IsZero() {
+			add("%v: invalid identity", field)
+		}
+	}
+
+	if approvers := gen.Executor.Approvers; len(approvers) > 0 {
+		if addr := gen.Params.ExecutorAddress; addr != nil && *addr != builtin.Executor.Address {
+			add("executor.approvers: approvers require the builtin executor, but params.executorAddress is %v", addr)
+		}
+		if len(approvers) > maxApprovers {
+			add("executor.approvers: %v approvers exceeds the limit of %v", len(approvers), maxApprovers)
+		}
+		seen := make(map[thor.Address]bool)
+		for i, approver := range approvers {
+			field := fmt.Sprintf("executor.approvers[%v] %v", i, approver.Address)
+			if approver.Address.IsZero() {
+				add("%v: invalid address", field)
+			} else if seen[approver.Address] {
+				add("%v: duplicated address", field)
+			}
+			seen[approver.Address] = true
+
+			if approver.Identity.IsZero() {
+				add("%v: invalid identity", field)
+			}
+		}
+	}
+	return errs
+}
+
+// isReservedAddress returns whether the address is of a builtin or precompiled contract, where contracts can't be
+// deployed.
+func isReservedAddress(addr thor.Address) bool {
+	switch addr {
+	case builtin.Params.Address,
+		builtin.Authority.Address,
+		builtin.Energy.Address,
+		builtin.Executor.Address,
+		builtin.Prototype.Address,
+		builtin.Extension.Address,
+		builtin.Measure.Address:
+		return true
+	}
+	for _, precompiled := range vm.PrecompiledAddressesShanghai {
+		if addr == thor.Address(precompiled) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package genesis_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/builtin"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/thor"
+)
+
+func TestValidateExample(t *testing.T) {
+	data, err := os.ReadFile("example.json")
+	require.NoError(t, err)
+
+	forkConfig := thor.NoFork
+	gen := genesis.CustomGenesis{ForkConfig: &forkConfig}
+	require.NoError(t, json.Unmarshal(data, &gen))
+	assert.Empty(t, gen.Validate())
+
+	gen.ForkConfig = nil
+	assert.Len(t, gen.Validate(), 1)
+}
+
+func TestValidate(t *testing.T) {
+	customGenesis := CustomNetWithParams(t, genesis.Executor{}, genesis.HexOrDecimal256{}, genesis.HexOrDecimal256{}, genesis.HexOrDecimal256{})
+	customGenesis.Accounts[1].Address = thor.BytesToAddress([]byte("account"))
+	assert.Empty(t, customGenesis.Validate())
+
+	negative := (*genesis.HexOrDecimal256)(big.NewInt(-1))
+	zero := uint64(0)
+	other := thor.BytesToAddress([]byte("other"))
+
+	customGenesis.ExtraData = "an extra data longer than 28 bytes"
+	customGenesis.Accounts[0].Balance = negative
+	customGenesis.Accounts[0].Code = "0xzz"
+	customGenesis.Accounts[0].Storage = map[string]thor.Bytes32{"0x01": {}}
+	customGenesis.Accounts[0].RawStorage = map[string]hexutil.Bytes{"0x02": {0xc2, 0x01}}
+	customGenesis.Accounts[1].Energy = negative
+	customGenesis.Accounts = append(customGenesis.Accounts, genesis.Account{Address: customGenesis.Accounts[1].Address})
+	customGenesis.Params = genesis.Params{
+		RewardRatio:         negative,
+		BaseGasPrice:        negative,
+		ProposerEndorsement: negative,
+		ExecutorAddress:     &other,
+		MaxBlockProposers:   &zero,
+	}
+	customGenesis.Authority[0].MasterAddress = thor.Address{}
+	customGenesis.Authority[1].EndorsorAddress = thor.Address{}
+	customGenesis.Authority[1].Identity = thor.Bytes32{}
+	customGenesis.Authority[2].MasterAddress = customGenesis.Authority[3].MasterAddress
+	customGenesis.Executor.Approvers = []genesis.Approver{
+		{Address: other},
+		{Address: other, Identity: thor.BytesToBytes32([]byte("approver"))},
+	}
+
+	errs := customGenesis.Validate()
+	msgs := make([]string, 0, len(errs))
+	var strict []string
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+		if genesis.IsStrict(err) {
+			strict = append(strict, err.Error())
+		}
+	}
+	// the extra data is truncated and the accounts merged by NewCustomNet
+	assert.Equal(t, []string{
+		"extraData: 34 bytes exceeds the limit of 28 bytes",
+		"accounts[2] " + customGenesis.Accounts[1].Address.String() + ": duplicated address",
+	}, strict)
+	assert.Equal(t, []string{
+		"extraData: 34 bytes exceeds the limit of 28 bytes",
+		"accounts[0] 0x0000000000000000000000000000000000000000: balance must be a non-negative integer",
+		"accounts[0] 0x0000000000000000000000000000000000000000: invalid code: invalid hex string",
+		"accounts[0] 0x0000000000000000000000000000000000000000: invalid storage key \"0x01\": invalid length",
+		"accounts[0] 0x0000000000000000000000000000000000000000: invalid raw storage key \"0x02\": invalid length",
+		"accounts[0] 0x0000000000000000000000000000000000000000: invalid raw storage value of \"0x02\": not a RLP value",
+		"accounts[1] " + customGenesis.Accounts[1].Address.String() + ": energy must be a non-negative integer",
+		"accounts[2] " + customGenesis.Accounts[1].Address.String() + ": duplicated address",
+		"params.rewardRatio: must be a non-negative integer",
+		"params.baseGasPrice: must be a non-negative integer",
+		"params.proposerEndorsement: must be a non-negative integer",
+		"params.maxBlockProposers: must be a positive integer",
+		"authority[0] 0x0000000000000000000000000000000000000000: invalid master address",
+		"authority[1] " + customGenesis.Authority[1].MasterAddress.String() + ": invalid endorsor address",
+		"authority[1] " + customGenesis.Authority[1].MasterAddress.String() + ": invalid identity",
+		"authority[3] " + customGenesis.Authority[3].MasterAddress.String() + ": duplicated master address",
+		"executor.approvers: approvers require the builtin executor, but params.executorAddress is " + other.String(),
+		"executor.approvers[0] " + other.String() + ": invalid identity",
+		"executor.approvers[1] " + other.String() + ": duplicated address",
+	}, msgs)
+
+	customGenesis.Params.ExecutorAddress = &builtin.Executor.Address
+	customGenesis.Authority = nil
+	customGenesis.Executor.Approvers = make([]genesis.Approver, 256)
+	errs = customGenesis.Validate()
+	assert.Contains(t, errs, errors.New("authority: at least one authority node required"))
+	assert.Contains(t, errs, errors.New("executor.approvers: 256 approvers exceeds the limit of 255"))
+
+	customGenesis.Accounts = []genesis.Account{{
+		Address: builtin.Energy.Address,
+		Code:    "0x6000",
+		Deploy:  &genesis.Deployment{Bytecode: "0x6000", Args: "0xzz"},
+	}}
+	errs = customGenesis.Validate()
+	field := "accounts[0] " + builtin.Energy.Address.String()
+	assert.Contains(t, errs, errors.New(field+": code and storage are set by the deployment"))
+	assert.Contains(t, errs, errors.New(field+": deploy: invalid constructor args: invalid hex string"))
+	assert.Contains(t, errs, errors.New(field+": deploy: invalid deployer"))
+	assert.Contains(t, errs, errors.New(field+": deploy: address of a builtin or precompiled contract"))
+}
@@ -3,6 +3,7 @@ module github.com/vechain/thor/v2
 go 1.24
 
 require (
+	github.com/BurntSushi/toml v1.4.0
 	github.com/beevik/ntp v0.2.0
 	github.com/davecgh/go-spew v1.1.1
 	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.0.1
@@ -27,6 +28,7 @@ require (
 	github.com/qianbin/drlp v0.0.0-20240102101024-e0e02518b5f9
 	github.com/stretchr/testify v1.8.4
 	github.com/syndtr/goleveldb v1.0.1-0.20220614013038-64ee5596c38a
+	github.com/tyler-smith/go-bip39 v1.0.2
 	github.com/vechain/go-ecvrf v0.0.0-20220525125849-96fa0442e765
 	golang.org/x/crypto v0.36.0
 	gopkg.in/cheggaaa/pb.v1 v1.0.28
@@ -0,0 +1,269 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package logdb
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vechain/thor/v2/log"
+)
+
+var logger = log.WithContext("pkg", "logdb")
+
+// The cost of a filter query is estimated in abstract units:
+//   - 1 for the query itself
+//   - per criterion, or once for an empty criteria set, 1 plus 1 per 100k blocks of range when it can use an index,
+//     or 1 per 1k blocks of range when it has to scan (no indexed field is set)
+//   - 1 per 1k rows of offset
+const (
+	indexedBlocksPerUnit = 100_000
+	scanBlocksPerUnit    = 1_000
+	offsetPerUnit        = 1_000
+)
+
+// QueryBudget bounds the cost and the duration of filter queries.
+type QueryBudget struct {
+	MaxCost   uint64        // max estimated cost of a query, 0 means unlimited
+	Downgrade bool          // narrow the block range of queries above MaxCost instead of rejecting them
+	Timeout   time.Duration // max duration of a query, 0 means no timeout
+	SlowQuery time.Duration // queries taking longer are logged, 0 disables the log
+}
+
+// CostFilter is a filter whose query cost can be estimated and bounded.
+type CostFilter interface {
+	Cost(head uint32) uint64
+	Downgrade(head uint32, maxCost uint64) bool
+	Shape() string
+}
+
+// Apply checks the cost of the filter against the budget. Filters above the budget are
+// narrowed when downgrading is enabled, otherwise rejected.
+// It returns whether the filter was downgraded.
+func (b *QueryBudget) Apply(filter CostFilter, head uint32) (bool, error) {
+	if b.MaxCost == 0 {
+		return false, nil
+	}
+	cost := filter.Cost(head)
+	if cost <= b.MaxCost {
+		return false, nil
+	}
+	if b.Downgrade && filter.Downgrade(head, b.MaxCost) {
+		logger.Debug("logs query downgraded", "shape", filter.Shape(), "cost", cost)
+		return true, nil
+	}
+	return false, fmt.Errorf("query cost %d exceeds the maximum allowed value of %d, please narrow the range or criteria", cost, b.MaxCost)
+}
+
+// LogIfSlow logs the shape of the filter if the query took longer than the slow query threshold.
+func (b *QueryBudget) LogIfSlow(filter CostFilter, head uint32, elapsed time.Duration) {
+	if b.SlowQuery > 0 && elapsed >= b.SlowQuery {
+		logger.Warn("slow logs query", "shape", filter.Shape(), "cost", filter.Cost(head), "elapsed", elapsed)
+	}
+}
+
+// Cost returns the estimated cost of the filter, with head as the upper bound of its range.
+func (f *EventFilter) Cost(head uint32) uint64 {
+	cost := uint64(1) + offsetCost(f.Options)
+	width := rangeWidth(f.Range, head)
+	// no criteria scans the range as a single unindexed criterion does
+	if len(f.CriteriaSet) == 0 {
+		return cost + criterionCost(false, width)
+	}
+	for _, c := range f.CriteriaSet {
+		cost += criterionCost(c.indexed(), width)
+	}
+	return cost
+}
+
+// Cost returns the estimated cost of the filter, with head as the upper bound of its range.
+func (f *TransferFilter) Cost(head uint32) uint64 {
+	cost := uint64(1) + offsetCost(f.Options)
+	width := rangeWidth(f.Range, head)
+	// no criteria scans the range as a single unindexed criterion does
+	if len(f.CriteriaSet) == 0 {
+		return cost + criterionCost(false, width)
+	}
+	for _, c := range f.CriteriaSet {
+		cost += criterionCost(c.indexed(), width)
+	}
+	return cost
+}
+
+// Downgrade narrows the range of the filter until its cost fits in maxCost, keeping the
+// blocks seen first in the filter order. It returns false if the filter can't fit.
+func (f *EventFilter) Downgrade(head uint32, maxCost uint64) bool {
+	rng, ok := downgradeRange(f.Range, f.Order, head, maxCost, func(r *Range) uint64 {
+		cpy := *f
+		cpy.Range = r
+		return cpy.Cost(head)
+	})
+	if ok {
+		f.Range = rng
+	}
+	return ok
+}
+
+// Downgrade narrows the range of the filter until its cost fits in maxCost, keeping the
+// blocks seen first in the filter order. It returns false if the filter can't fit.
+func (f *TransferFilter) Downgrade(head uint32, maxCost uint64) bool {
+	rng, ok := downgradeRange(f.Range, f.Order, head, maxCost, func(r *Range) uint64 {
+		cpy := *f
+		cpy.Range = r
+		return cpy.Cost(head)
+	})
+	if ok {
+		f.Range = rng
+	}
+	return ok
+}
+
+// Shape describes the filter without its values, for logging.
+func (f *EventFilter) Shape() string {
+	criteria := make([]string, 0, len(f.CriteriaSet))
+	for _, c := range f.CriteriaSet {
+		criteria = append(criteria, strings.Join(c.params(), ","))
+	}
+	return filterShape("event", f.Range, criteria, f.Options, f.Order)
+}
+
+// Shape describes the filter without its values, for logging.
+func (f *TransferFilter) Shape() string {
+	criteria := make([]string, 0, len(f.CriteriaSet))
+	for _, c := range f.CriteriaSet {
+		criteria = append(criteria, strings.Join(c.params(), ","))
+	}
+	return filterShape("transfer", f.Range, criteria, f.Options, f.Order)
+}
+
+// indexed returns whether the criterion can be looked up through an index.
+// Only topic4 has no index of its own.
+func (c *EventCriteria) indexed() bool {
+	if c.Address != nil {
+		return true
+	}
+	for _, topic := range c.Topics[:4] {
+		if topic != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *EventCriteria) params() []string {
+	params := make([]string, 0)
+	if c.Address != nil {
+		params = append(params, "address")
+	}
+	for i, t := range c.Topics {
+		if t != nil {
+			params = append(params, fmt.Sprintf("topic%d", i))
+		}
+	}
+	return params
+}
+
+// indexed returns whether the criterion can be looked up through an index.
+func (c *TransferCriteria) indexed() bool {
+	return c.TxOrigin != nil || c.Sender != nil || c.Recipient != nil
+}
+
+func (c *TransferCriteria) params() []string {
+	params := make([]string, 0)
+	if c.TxOrigin != nil {
+		params = append(params, "txOrigin")
+	}
+	if c.Sender != nil {
+		params = append(params, "sender")
+	}
+	if c.Recipient != nil {
+		params = append(params, "recipient")
+	}
+	return params
+}
+
+// rangeWidth returns the number of blocks in the range, bounded by head.
+func rangeWidth(rng *Range, head uint32) uint64 {
+	from, to := uint32(0), head
+	if rng != nil {
+		from = rng.From
+		if rng.To < to {
+			to = rng.To
+		}
+	}
+	if from > to {
+		return 0
+	}
+	return uint64(to-from) + 1
+}
+
+func criterionCost(indexed bool, width uint64) uint64 {
+	if indexed {
+		return 1 + ceilDiv(width, indexedBlocksPerUnit)
+	}
+	return 1 + ceilDiv(width, scanBlocksPerUnit)
+}
+
+func offsetCost(options *Options) uint64 {
+	if options == nil {
+		return 0
+	}
+	return ceilDiv(options.Offset, offsetPerUnit)
+}
+
+func ceilDiv(a, b uint64) uint64 {
+	return (a + b - 1) / b
+}
+
+// downgradeRange searches the widest range within the given one whose cost fits in maxCost.
+func downgradeRange(rng *Range, order Order, head uint32, maxCost uint64, cost func(*Range) uint64) (*Range, bool) {
+	from, to := uint32(0), head
+	if rng != nil {
+		from = rng.From
+		if rng.To < to {
+			to = rng.To
+		}
+	}
+	if from > to {
+		return rng, cost(rng) <= maxCost
+	}
+
+	narrow := func(width uint32) *Range {
+		if order == DESC {
+			return &Range{From: to - width + 1, To: to}
+		}
+		return &Range{From: from, To: from + width - 1}
+	}
+
+	maxWidth := uint64(to-from) + 1
+	width := sort.Search(int(maxWidth), func(i int) bool {
+		return cost(narrow(uint32(i+1))) > maxCost
+	})
+	if width == 0 {
+		return nil, false
+	}
+	return narrow(uint32(width)), true
+}
+
+func filterShape(kind string, rng *Range, criteria []string, options *Options, order Order) string {
+	var b strings.Builder
+	b.WriteString(kind)
+	if rng != nil {
+		fmt.Fprintf(&b, " range=%d-%d", rng.From, rng.To)
+	}
+	fmt.Fprintf(&b, " criteria=[%s]", strings.Join(criteria, "|"))
+	if options != nil {
+		fmt.Fprintf(&b, " offset=%d limit=%d", options.Offset, options.Limit)
+	}
+	if order == DESC {
+		b.WriteString(" order=desc")
+	} else {
+		b.WriteString(" order=asc")
+	}
+	return b.String()
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package logdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/v2/thor"
+)
+
+func TestEventFilter_Cost(t *testing.T) {
+	addr := thor.BytesToAddress([]byte("addr"))
+	topic := thor.Bytes32{1}
+
+	tests := []struct {
+		name   string
+		filter *EventFilter
+		head   uint32
+		cost   uint64
+	}{
+		{"no criteria", &EventFilter{}, 999_999, 1 + 1 + 1000},
+		{"indexed", &EventFilter{CriteriaSet: []*EventCriteria{{Address: &addr}}}, 999_999, 1 + 1 + 10},
+		{"topic4 only", &EventFilter{CriteriaSet: []*EventCriteria{{Topics: [5]*thor.Bytes32{4: &topic}}}}, 999_999, 1 + 1 + 1000},
+		{"empty criterion", &EventFilter{CriteriaSet: []*EventCriteria{{}}}, 999_999, 1 + 1 + 1000},
+		{"range", &EventFilter{CriteriaSet: []*EventCriteria{{}}, Range: &Range{From: 1000, To: MaxBlockNumber}}, 2999, 1 + 1 + 2},
+		{"empty range", &EventFilter{CriteriaSet: []*EventCriteria{{}}, Range: &Range{From: 10, To: 5}}, 100, 1 + 1},
+		{"offset", &EventFilter{Options: &Options{Offset: 10_001, Limit: 10}}, 100, 1 + 11 + 1 + 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.cost, tt.filter.Cost(tt.head))
+		})
+	}
+}
+
+func TestTransferFilter_Cost(t *testing.T) {
+	addr := thor.BytesToAddress([]byte("addr"))
+	filter := &TransferFilter{CriteriaSet: []*TransferCriteria{{Sender: &addr}, {}}}
+	assert.Equal(t, uint64(1+(1+10)+(1+1000)), filter.Cost(999_999))
+}
+
+func TestFilter_CostEmptyCriteriaSet(t *testing.T) {
+	// an empty criteria set scans the range like a single empty criterion
+	for _, rng := range []*Range{nil, {From: 1000, To: 500_000}} {
+		assert.Equal(t,
+			(&EventFilter{CriteriaSet: []*EventCriteria{{}}, Range: rng}).Cost(999_999),
+			(&EventFilter{Range: rng}).Cost(999_999))
+		assert.Equal(t,
+			(&TransferFilter{CriteriaSet: []*TransferCriteria{{}}, Range: rng}).Cost(999_999),
+			(&TransferFilter{Range: rng}).Cost(999_999))
+	}
+}
+
+func TestFilter_Downgrade(t *testing.T) {
+	budget := &QueryBudget{MaxCost: 12, Downgrade: true}
+
+	// 1 + (1 + 10 per 10k blocks)
+	filter := &EventFilter{CriteriaSet: []*EventCriteria{{}}, Range: &Range{From: 100, To: MaxBlockNumber}}
+	downgraded, err := budget.Apply(filter, 1_000_000)
+	assert.NoError(t, err)
+	assert.True(t, downgraded)
+	assert.Equal(t, &Range{From: 100, To: 10_099}, filter.Range)
+	assert.Equal(t, uint64(12), filter.Cost(1_000_000))
+
+	// desc keeps the newest blocks
+	filter = &EventFilter{CriteriaSet: []*EventCriteria{{}}, Order: DESC}
+	downgraded, err = budget.Apply(filter, 1_000_000)
+	assert.NoError(t, err)
+	assert.True(t, downgraded)
+	assert.Equal(t, &Range{From: 990_001, To: 1_000_000}, filter.Range)
+
+	// within the budget
+	downgraded, err = budget.Apply(filter, 1_000_000)
+	assert.NoError(t, err)
+	assert.False(t, downgraded)
+
+	// the offset alone exceeds the budget
+	transfer := &TransferFilter{CriteriaSet: []*TransferCriteria{{}}, Options: &Options{Offset: 20_000}}
+	_, err = budget.Apply(transfer, 1_000_000)
+	assert.Error(t, err)
+
+	// rejected without downgrade
+	budget.Downgrade = false
+	_, err = budget.Apply(&EventFilter{CriteriaSet: []*EventCriteria{{}}}, 1_000_000)
+	assert.Error(t, err)
+
+	// no limit
+	_, err = (&QueryBudget{}).Apply(&EventFilter{CriteriaSet: []*EventCriteria{{}}}, 1_000_000)
+	assert.NoError(t, err)
+}
+
+func TestFilter_Shape(t *testing.T) {
+	addr := thor.BytesToAddress([]byte("addr"))
+	topic := thor.Bytes32{1}
+	filter := &EventFilter{
+		CriteriaSet: []*EventCriteria{{Address: &addr, Topics: [5]*thor.Bytes32{0: &topic}}, {}},
+		Range:       &Range{From: 1, To: 10},
+		Options:     &Options{Offset: 5, Limit: 10},
+		Order:       DESC,
+	}
+	assert.Equal(t, "event range=1-10 criteria=[address,topic0|] offset=5 limit=10 order=desc", filter.Shape())
+
+	transfer := &TransferFilter{CriteriaSet: []*TransferCriteria{{Sender: &addr, Recipient: &addr}}}
+	assert.Equal(t, "transfer criteria=[sender,recipient] order=asc", transfer.Shape())
+}
@@ -10,6 +10,8 @@ import (
 	"database/sql"
 	"fmt"
 	"math/big"
+	"strings"
+	"sync/atomic"
 
 	sqlite3 "github.com/mattn/go-sqlite3"
 	"github.com/vechain/thor/v2/block"
@@ -32,7 +34,11 @@ type LogDB struct {
 
 // New create or open log db at given path.
 func New(path string) (logDB *LogDB, err error) {
-	db, err := sql.Open("sqlite3", path+"?_journal=wal&cache=shared")
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	db, err := sql.Open("sqlite3", path+sep+"_journal=wal&cache=shared")
 	if err != nil {
 		return nil, err
 	}
@@ -73,9 +79,12 @@ func New(path string) (logDB *LogDB, err error) {
 
 // NewMem create a log db in ram.
 func NewMem() (*LogDB, error) {
-	return New("file::memory:")
+	// in-memory databases are named, to not be shared through the shared cache
+	return New(fmt.Sprintf("file:logdb-mem-%d?mode=memory", memDBSeq.Add(1)))
 }
 
+var memDBSeq atomic.Uint64
+
 // Close close the log db.
 func (db *LogDB) Close() (err error) {
 	err = db.wconn.Close()
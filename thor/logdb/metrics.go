@@ -6,7 +6,6 @@
 package logdb
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/vechain/thor/v2/metrics"
@@ -28,16 +27,7 @@ func metricsHandleEventsFilter(filter *EventFilter) {
 	metricsHandleCommonFilter(filter.Options, filter.Order, len(filter.CriteriaSet), "event")
 
 	for _, c := range filter.CriteriaSet {
-		paramsUsed := make([]string, 0)
-		if c.Address != nil {
-			paramsUsed = append(paramsUsed, "address")
-		}
-		for i, t := range c.Topics {
-			if t != nil {
-				paramsUsed = append(paramsUsed, fmt.Sprintf("topic%d", i))
-			}
-		}
-		metricEventQueryParametersCounter().AddWithLabel(1, map[string]string{"parameters": strings.Join(paramsUsed, ",")})
+		metricEventQueryParametersCounter().AddWithLabel(1, map[string]string{"parameters": strings.Join(c.params(), ",")})
 	}
 }
 
@@ -0,0 +1,232 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package logdb
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// b-tree page types of the sqlite file format, see https://www.sqlite.org/fileformat.html
+const (
+	pageIndexInterior = 2
+	pageTableInterior = 5
+	pageIndexLeaf     = 10
+	pageTableLeaf     = 13
+)
+
+// TableStats is the usage of a table or an index.
+type TableStats struct {
+	Name    string
+	Table   string // the table of an index, the name itself for tables
+	Entries int64  // rows of a table, or entries of an index
+	Pages   int64  // b-tree and overflow pages
+	Size    int64  // size of the pages
+}
+
+// Stats is the usage of the log db file.
+type Stats struct {
+	PageSize  int64
+	Pages     int64 // pages of the file
+	FreePages int64
+	Tables    []*TableStats // tables and indexes, including the schema table
+}
+
+// Stats computes the usage of the tables and indexes, by walking their b-trees in the database file. The
+// write-ahead log is checkpointed first, to have all pages in the database file.
+func (db *LogDB) Stats(ctx context.Context) (*Stats, error) {
+	if _, err := db.db.ExecContext(ctx, "PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		return nil, err
+	}
+
+	var (
+		seq  int
+		name string
+		path string
+	)
+	if err := db.db.QueryRowContext(ctx, "PRAGMA database_list").Scan(&seq, &name, &path); err != nil {
+		return nil, err
+	}
+	if path == "" {
+		return nil, errors.New("in-memory database")
+	}
+
+	var stats Stats
+	if err := db.db.QueryRowContext(ctx, "PRAGMA freelist_count").Scan(&stats.FreePages); err != nil {
+		return nil, err
+	}
+
+	stats.Tables = []*TableStats{{Name: "sqlite_schema", Table: "sqlite_schema"}}
+	roots := []int64{1}
+	rows, err := db.db.QueryContext(ctx, "SELECT name, tbl_name, rootpage FROM sqlite_schema WHERE rootpage > 0 ORDER BY tbl_name, type DESC, name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			ts   TableStats
+			root int64
+		)
+		if err := rows.Scan(&ts.Name, &ts.Table, &root); err != nil {
+			return nil, err
+		}
+		stats.Tables = append(stats.Tables, &ts)
+		roots = append(roots, root)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	w, err := newBTreeWalker(file)
+	if err != nil {
+		return nil, err
+	}
+	stats.PageSize = w.pageSize
+	stats.Pages = w.pages
+	for i, ts := range stats.Tables {
+		if err := w.walk(ctx, roots[i], ts); err != nil {
+			return nil, fmt.Errorf("walk %v: %w", ts.Name, err)
+		}
+		ts.Size = ts.Pages * w.pageSize
+	}
+	return &stats, nil
+}
+
+// bTreeWalker counts the pages and entries of b-trees in a sqlite database file.
+type bTreeWalker struct {
+	r        io.ReaderAt
+	pageSize int64
+	usable   int64 // page size without the reserved space
+	pages    int64
+	page     []byte
+}
+
+func newBTreeWalker(file *os.File) (*bTreeWalker, error) {
+	var header [100]byte
+	if _, err := file.ReadAt(header[:], 0); err != nil {
+		return nil, err
+	}
+	if string(header[:16]) != "SQLite format 3\x00" {
+		return nil, errors.New("not a sqlite database file")
+	}
+	pageSize := int64(binary.BigEndian.Uint16(header[16:]))
+	if pageSize == 1 {
+		pageSize = 65536
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return &bTreeWalker{
+		r:        file,
+		pageSize: pageSize,
+		usable:   pageSize - int64(header[20]),
+		pages:    info.Size() / pageSize,
+		page:     make([]byte, pageSize),
+	}, nil
+}
+
+func (w *bTreeWalker) walk(ctx context.Context, root int64, ts *TableStats) error {
+	stack := []int64{root}
+	for len(stack) > 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		pgno := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if pgno < 1 || pgno > w.pages {
+			return fmt.Errorf("page %v out of range", pgno)
+		}
+		if _, err := w.r.ReadAt(w.page, (pgno-1)*w.pageSize); err != nil {
+			return err
+		}
+		ts.Pages++
+
+		hdr := w.page
+		if pgno == 1 {
+			hdr = w.page[100:]
+		}
+		kind := hdr[0]
+		cells := int(binary.BigEndian.Uint16(hdr[3:]))
+		cellPtrs := hdr[8:]
+		if kind == pageIndexInterior || kind == pageTableInterior {
+			stack = append(stack, int64(binary.BigEndian.Uint32(hdr[8:])))
+			cellPtrs = hdr[12:]
+		}
+		if len(cellPtrs) < cells*2 {
+			return fmt.Errorf("page %v is corrupted", pgno)
+		}
+
+		for i := range cells {
+			ptr := int64(binary.BigEndian.Uint16(cellPtrs[i*2:]))
+			if ptr+4 > w.pageSize {
+				return fmt.Errorf("page %v is corrupted", pgno)
+			}
+			cell := w.page[ptr:]
+			switch kind {
+			case pageTableInterior:
+				stack = append(stack, int64(binary.BigEndian.Uint32(cell)))
+			case pageTableLeaf:
+				ts.Entries++
+				size := readVarint(cell)
+				ts.Pages += w.overflowPages(size, w.usable-35)
+			case pageIndexInterior, pageIndexLeaf:
+				if kind == pageIndexInterior {
+					stack = append(stack, int64(binary.BigEndian.Uint32(cell)))
+					cell = cell[4:]
+				}
+				ts.Entries++
+				size := readVarint(cell)
+				ts.Pages += w.overflowPages(size, (w.usable-12)*64/255-23)
+			default:
+				return fmt.Errorf("page %v is not a b-tree page", pgno)
+			}
+		}
+	}
+	return nil
+}
+
+// overflowPages returns the number of overflow pages of a payload, given the max payload kept on the b-tree page.
+func (w *bTreeWalker) overflowPages(size, maxLocal int64) int64 {
+	if size <= maxLocal {
+		return 0
+	}
+	minLocal := (w.usable-12)*32/255 - 23
+	local := minLocal + (size-minLocal)%(w.usable-4)
+	if local > maxLocal {
+		local = minLocal
+	}
+	return (size - local + w.usable - 5) / (w.usable - 4)
+}
+
+// readVarint reads a sqlite varint, which is big-endian, and up to 9 bytes long.
+func readVarint(b []byte) int64 {
+	var v uint64
+	for i := range 8 {
+		if i >= len(b) {
+			return 0
+		}
+		v = v<<7 | uint64(b[i]&0x7f)
+		if b[i] < 0x80 {
+			return int64(v)
+		}
+	}
+	if len(b) < 9 {
+		return 0
+	}
+	return int64(v<<8 | uint64(b[8]))
+}
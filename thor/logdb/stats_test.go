@@ -0,0 +1,66 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package logdb
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/tx"
+)
+
+func TestStats(t *testing.T) {
+	db, err := New(filepath.Join(t.TempDir(), "logs.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	const blocks = 200
+	b := new(block.Builder).Build()
+	for i := range blocks {
+		b = new(block.Builder).
+			ParentID(b.Header().ID()).
+			Transaction(newTx(tx.TypeLegacy)).
+			Build()
+		receipt := newReceipt()
+		if i%10 == 0 {
+			// large enough to overflow
+			receipt.Outputs[0].Events[0].Data = make([]byte, 10000)
+		}
+		w := db.NewWriter()
+		require.NoError(t, w.Write(b, tx.Receipts{receipt}))
+		require.NoError(t, w.Commit())
+	}
+
+	stats, err := db.Stats(context.Background())
+	require.NoError(t, err)
+
+	tables := make(map[string]*TableStats)
+	pages := stats.FreePages
+	for _, ts := range stats.Tables {
+		tables[ts.Name] = ts
+		pages += ts.Pages
+		assert.Equal(t, ts.Pages*stats.PageSize, ts.Size)
+	}
+	assert.Equal(t, stats.Pages, pages)
+
+	assert.Equal(t, int64(blocks), tables["event"].Entries)
+	assert.Equal(t, "event", tables["event_i0"].Table)
+	assert.Equal(t, int64(blocks), tables["event_i0"].Entries)
+	assert.Equal(t, int64(0), tables["event_i2"].Entries)
+	assert.Equal(t, int64(blocks), tables["transfer"].Entries)
+	assert.Equal(t, int64(blocks), tables["transfer_i1"].Entries)
+	assert.Greater(t, tables["event"].Pages, int64(blocks/10*2))
+
+	mem, err := NewMem()
+	require.NoError(t, err)
+	defer mem.Close()
+	_, err = mem.Stats(context.Background())
+	assert.Error(t, err)
+}
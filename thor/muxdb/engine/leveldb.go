@@ -187,3 +187,12 @@ func (ldb *LevelEngine) DeleteRange(ctx context.Context, r kv.Range) error {
 func (ldb *LevelEngine) Stats(s *leveldb.DBStats) error {
 	return ldb.db.Stats(s)
 }
+
+// SizeOf returns the approximate file system space used by the keys in the range.
+func (ldb *LevelEngine) SizeOf(r kv.Range) (int64, error) {
+	sizes, err := ldb.db.SizeOf([]util.Range{util.Range(r)})
+	if err != nil {
+		return 0, err
+	}
+	return sizes.Sum(), nil
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package muxdb
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"github.com/vechain/thor/v2/kv"
+	"github.com/vechain/thor/v2/muxdb/engine"
+)
+
+var errUnsupportedEngine = errors.New("unsupported engine")
+
+// Usage is the number of entries, and the size of their keys and values.
+type Usage struct {
+	Entries int64
+	Size    int64
+}
+
+// Add adds an entry.
+func (u *Usage) Add(key, val []byte) {
+	u.Entries++
+	u.Size += int64(len(key) + len(val))
+}
+
+// TrieUsage is the usage of trie nodes, grouped by the leading byte of the trie names.
+type TrieUsage struct {
+	Hist    map[byte]*Usage // historical nodes
+	Deduped map[byte]*Usage // deduped nodes
+
+	HistDiskSize    int64 // approximate file system space used by historical nodes
+	DedupedDiskSize int64 // approximate file system space used by deduped nodes
+}
+
+// InspectTries scans all the trie nodes to compute their usage.
+func (db *MuxDB) InspectTries(ctx context.Context) (*TrieUsage, error) {
+	lvl, ok := db.engine.(*engine.LevelEngine)
+	if !ok {
+		return nil, errUnsupportedEngine
+	}
+
+	usage := &TrieUsage{
+		Hist:    make(map[byte]*Usage),
+		Deduped: make(map[byte]*Usage),
+	}
+	for _, space := range []struct {
+		space     byte
+		ptnFactor uint32
+		groups    map[byte]*Usage
+		diskSize  *int64
+	}{
+		{trieHistSpace, db.trieBackend.HistPtnFactor, usage.Hist, &usage.HistDiskSize},
+		{trieDedupedSpace, db.trieBackend.DedupedPtnFactor, usage.Deduped, &usage.DedupedDiskSize},
+	} {
+		rng := kv.Range(*util.BytesPrefix([]byte{space.space}))
+		size, err := lvl.SizeOf(rng)
+		if err != nil {
+			return nil, err
+		}
+		*space.diskSize = size
+
+		// the trie name follows the space and the partition id
+		nameOffset := 1
+		if space.ptnFactor != math.MaxUint32 {
+			nameOffset += 4
+		}
+		if err := scan(ctx, lvl, rng, func(key, val []byte) {
+			if len(key) <= nameOffset {
+				return
+			}
+			u := space.groups[key[nameOffset]]
+			if u == nil {
+				u = &Usage{}
+				space.groups[key[nameOffset]] = u
+			}
+			u.Add(key, val)
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return usage, nil
+}
+
+// InspectStores scans the named stores to compute their usage. The group function maps the keys, without the
+// store space, to the groups of the usage.
+func (db *MuxDB) InspectStores(ctx context.Context, group func(key []byte) string) (map[string]*Usage, error) {
+	lvl, ok := db.engine.(*engine.LevelEngine)
+	if !ok {
+		return nil, errUnsupportedEngine
+	}
+
+	usage := make(map[string]*Usage)
+	if err := scan(ctx, lvl, kv.Range(*util.BytesPrefix([]byte{namedStoreSpace})), func(key, val []byte) {
+		name := group(key[1:])
+		u := usage[name]
+		if u == nil {
+			u = &Usage{}
+			usage[name] = u
+		}
+		u.Add(key, val)
+	}); err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+// StoreDiskSize returns the approximate file system space used by the named store.
+func (db *MuxDB) StoreDiskSize(name string) (int64, error) {
+	lvl, ok := db.engine.(*engine.LevelEngine)
+	if !ok {
+		return 0, errUnsupportedEngine
+	}
+	return lvl.SizeOf(kv.Range(*util.BytesPrefix([]byte(string(namedStoreSpace) + name))))
+}
+
+// Stats reads the stats of the underlying LevelDB, including the compaction stats.
+func (db *MuxDB) Stats(s *leveldb.DBStats) error {
+	lvl, ok := db.engine.(*engine.LevelEngine)
+	if !ok {
+		return errUnsupportedEngine
+	}
+	return lvl.Stats(s)
+}
+
+func scan(ctx context.Context, lvl *engine.LevelEngine, rng kv.Range, fn func(key, val []byte)) error {
+	iter := lvl.Iterate(rng)
+	defer iter.Release()
+
+	for cnt := 0; iter.Next(); cnt++ {
+		// check context every 1000 times.
+		if cnt%1000 == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		fn(iter.Key(), iter.Value())
+	}
+	return iter.Error()
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package muxdb
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/vechain/thor/v2/trie"
+)
+
+func TestInspect(t *testing.T) {
+	db := NewMem()
+	defer db.Close()
+
+	for _, name := range []string{"a", "s1", "s2"} {
+		tr := db.NewTrie(name, trie.Root{})
+		assert.Nil(t, tr.Update([]byte("key"), []byte("value"), nil))
+		assert.Nil(t, tr.Commit(trie.Version{Major: 1}, false))
+		assert.Nil(t, tr.Checkpoint(context.Background(), 0, nil))
+	}
+	assert.Nil(t, db.NewStore("foo.a").Put([]byte("key"), []byte("value")))
+	assert.Nil(t, db.NewStore("foo.b").Put([]byte("key"), []byte("value")))
+	assert.Nil(t, db.NewStore("bar").Put([]byte("key"), []byte("value")))
+
+	usage, err := db.InspectTries(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{'a', 's'}, slices.Sorted(maps.Keys(usage.Hist)))
+	assert.Equal(t, int64(1), usage.Hist['a'].Entries)
+	assert.Equal(t, int64(2), usage.Hist['s'].Entries)
+	assert.Equal(t, int64(2), usage.Deduped['s'].Entries)
+
+	stores, err := db.InspectStores(context.Background(), func(key []byte) string {
+		if strings.HasPrefix(string(key), "foo.") {
+			return "foo"
+		}
+		return "other"
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), stores["foo"].Entries)
+	assert.Equal(t, int64(2*len("\x02foo.akeyvalue")), stores["foo"].Size)
+	assert.Equal(t, int64(1), stores["other"].Entries)
+
+	var stats leveldb.DBStats
+	assert.Nil(t, db.Stats(&stats))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = db.InspectTries(ctx)
+	assert.Equal(t, context.Canceled, err)
+}
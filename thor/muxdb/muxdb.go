@@ -185,15 +185,15 @@ func (db *MuxDB) EnableMetrics() {
 		for {
 			select {
 			case <-ticker.C:
-				// we only have one engine implementation for now, put the type assertion just for safety
-				lvl, ok := db.engine.(*engine.LevelEngine)
-				if ok {
-					err = lvl.Stats(&stats)
-					if err != nil {
-						logger.Warn("Failed to get LevelDB stats: %v", err)
-					}
-					registerCompactionMetrics(&stats)
+				// we only have one engine implementation for now, Stats checks the type just for safety
+				err = db.Stats(&stats)
+				if err == errUnsupportedEngine {
+					continue
 				}
+				if err != nil {
+					logger.Warn("Failed to get LevelDB stats: %v", err)
+				}
+				registerCompactionMetrics(&stats)
 			case <-db.done:
 				return
 			}
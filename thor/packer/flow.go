@@ -10,15 +10,14 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/vechain/thor/v2/block"
 	"github.com/vechain/thor/v2/builtin"
 	"github.com/vechain/thor/v2/runtime"
+	"github.com/vechain/thor/v2/signer"
 	"github.com/vechain/thor/v2/state"
 	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/trie"
 	"github.com/vechain/thor/v2/tx"
-	"github.com/vechain/thor/v2/vrf"
 )
 
 // Flow the flow of packing a new block.
@@ -205,7 +204,12 @@ func (f *Flow) Adopt(t *tx.Transaction) error {
 
 // Pack build and sign the new block.
 func (f *Flow) Pack(privateKey *ecdsa.PrivateKey, newBlockConflicts uint32, shouldVote bool) (*block.Block, *state.Stage, tx.Receipts, error) {
-	if f.packer.nodeMaster != thor.Address(crypto.PubkeyToAddress(privateKey.PublicKey)) {
+	return f.PackWithSigner(signer.NewLocal(privateKey), newBlockConflicts, shouldVote)
+}
+
+// PackWithSigner build the new block and sign it with the signer, which may hold the key out of the process.
+func (f *Flow) PackWithSigner(s signer.Signer, newBlockConflicts uint32, shouldVote bool) (*block.Block, *state.Stage, tx.Receipts, error) {
+	if f.packer.nodeMaster != s.Address() {
 		return nil, nil, nil, errors.New("private key mismatch")
 	}
 
@@ -238,7 +242,7 @@ func (f *Flow) Pack(privateKey *ecdsa.PrivateKey, newBlockConflicts uint32, shou
 	if f.Number() < f.packer.forkConfig.VIP214 {
 		newBlock := builder.Build()
 
-		sig, err := crypto.Sign(newBlock.Header().SigningHash().Bytes(), privateKey)
+		sig, err := s.SignBlock(newBlock.Header())
 		if err != nil {
 			return nil, nil, nil, err
 		}
@@ -258,12 +262,12 @@ func (f *Flow) Pack(privateKey *ecdsa.PrivateKey, newBlockConflicts uint32, shou
 		}
 
 		newBlock := builder.Alpha(alpha).Build()
-		ec, err := crypto.Sign(newBlock.Header().SigningHash().Bytes(), privateKey)
+		ec, err := s.SignBlock(newBlock.Header())
 		if err != nil {
 			return nil, nil, nil, err
 		}
 
-		_, proof, err := vrf.Prove(privateKey, alpha)
+		proof, err := s.Prove(alpha)
 		if err != nil {
 			return nil, nil, nil, err
 		}
@@ -6,8 +6,10 @@
 package packer_test
 
 import (
+	"bytes"
 	"fmt"
 	"math/big"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -20,6 +22,7 @@ import (
 	"github.com/vechain/thor/v2/genesis"
 	"github.com/vechain/thor/v2/muxdb"
 	"github.com/vechain/thor/v2/packer"
+	"github.com/vechain/thor/v2/signer"
 	"github.com/vechain/thor/v2/state"
 	"github.com/vechain/thor/v2/test/testchain"
 	"github.com/vechain/thor/v2/thor"
@@ -183,6 +186,43 @@ func TestPack(t *testing.T) {
 	}
 }
 
+func TestPackWithRemoteSigner(t *testing.T) {
+	db := muxdb.NewMem()
+	g := genesis.NewDevnet()
+
+	stater := state.NewStater(db)
+	parent, _, _, _ := g.Build(stater)
+
+	repo, _ := chain.NewRepository(db, parent)
+
+	forkConfig := thor.NoFork
+	forkConfig.VIP214 = 0
+
+	proposer := genesis.DevAccounts()[0]
+	allow, err := signer.ParseAllowlist([]string{"127.0.0.1", "::1"})
+	assert.NoError(t, err)
+	var audit bytes.Buffer
+	srv := httptest.NewServer(signer.NewServer(signer.NewLocal(proposer.PrivateKey), allow, &audit))
+	defer srv.Close()
+
+	remote, err := signer.NewRemote(srv.URL)
+	assert.NoError(t, err)
+
+	p := packer.New(repo, stater, proposer.Address, &proposer.Address, &forkConfig, 0)
+	parentSum, _ := repo.GetBlockSummary(parent.Header().ID())
+	flow, _ := p.Schedule(parentSum, parent.Header().Timestamp()+100*thor.BlockInterval)
+
+	blk, _, _, err := flow.PackWithSigner(remote, 0, false)
+	assert.NoError(t, err)
+	blkSigner, err := blk.Header().Signer()
+	assert.NoError(t, err)
+	assert.Equal(t, proposer.Address, blkSigner)
+	beta, err := blk.Header().Beta()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, beta)
+	assert.Equal(t, 2, bytes.Count(audit.Bytes(), []byte("\n")))
+}
+
 func TestPackAfterGalacticaFork(t *testing.T) {
 	db := muxdb.NewMem()
 	g := genesis.NewDevnet()
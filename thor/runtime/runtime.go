@@ -332,6 +332,28 @@ func (rt *Runtime) PrepareClause(
 	clauseIndex uint32,
 	gas uint64,
 	txCtx *xenv.TransactionContext,
+) (exec func() (output *Output, interrupted bool, err error), interrupt func()) {
+	return rt.prepareClause(clause, clauseIndex, gas, txCtx, nil)
+}
+
+// PrepareDeployment prepare to run the creation code of a contract, which is deployed at the given address
+// instead of the one derived from the transaction. Contracts created by the creation code get derived addresses
+// as usual. It's used to deploy contracts in the genesis block.
+func (rt *Runtime) PrepareDeployment(
+	code []byte,
+	addr thor.Address,
+	gas uint64,
+	txCtx *xenv.TransactionContext,
+) (exec func() (output *Output, interrupted bool, err error), interrupt func()) {
+	return rt.prepareClause(tx.NewClause(nil).WithData(code), 0, gas, txCtx, &addr)
+}
+
+func (rt *Runtime) prepareClause(
+	clause *tx.Clause,
+	clauseIndex uint32,
+	gas uint64,
+	txCtx *xenv.TransactionContext,
+	deployAt *thor.Address,
 ) (exec func() (output *Output, interrupted bool, err error), interrupt func()) {
 	var (
 		stateDB       = statedb.New(rt.state)
@@ -342,6 +364,16 @@ func (rt *Runtime) PrepareClause(
 		contractAddr  *thor.Address
 		interruptFlag uint32
 	)
+	if deployAt != nil {
+		newContractAddress := evm.NewContractAddress
+		evm.NewContractAddress = func(evm *vm.EVM, counter uint32) common.Address {
+			// the first creation is the deployment itself
+			if counter == 0 {
+				return common.Address(*deployAt)
+			}
+			return newContractAddress(evm, counter)
+		}
+	}
 
 	exec = func() (output *Output, interrupted bool, err error) {
 		defer func() {
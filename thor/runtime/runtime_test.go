@@ -656,6 +656,51 @@ func TestCall(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestPrepareDeployment(t *testing.T) {
+	db := muxdb.NewMem()
+
+	g := genesis.NewDevnet()
+	b0, _, _, err := g.Build(state.NewStater(db))
+	assert.Nil(t, err)
+
+	repo, _ := chain.NewRepository(db, b0)
+	st := state.New(db, trie.Root{Hash: b0.Header().StateRoot()})
+	rt := runtime.New(repo.NewChain(b0.Header().ID()), st, &xenv.BlockContext{}, &thor.NoFork)
+
+	var (
+		addr     = thor.BytesToAddress([]byte("deployed"))
+		deployer = genesis.DevAccounts()[0].Address
+		txID     = thor.BytesToBytes32([]byte("tx"))
+	)
+	// the constructor creates a contract with empty code, and stores its address at slot 0
+	code, _ := hex.DecodeString("600060006000f0600055")
+	exec, _ := rt.PrepareDeployment(code, addr, math.MaxUint64, &xenv.TransactionContext{ID: txID, Origin: deployer})
+	out, _, err := exec()
+	assert.Nil(t, err)
+	assert.Nil(t, out.VMErr)
+	assert.Equal(t, addr, *out.ContractAddress)
+
+	master, err := st.GetMaster(addr)
+	assert.Nil(t, err)
+	assert.Equal(t, deployer, master)
+
+	// the created contract gets the derived address
+	child := thor.CreateContractAddress(txID, 0, 1)
+	stored, err := st.GetStorage(addr, thor.Bytes32{})
+	assert.Nil(t, err)
+	assert.Equal(t, thor.BytesToBytes32(child.Bytes()), stored)
+
+	master, err = st.GetMaster(child)
+	assert.Nil(t, err)
+	assert.Equal(t, addr, master)
+
+	// deploying at the address of a contract collides
+	exec, _ = rt.PrepareDeployment(code, builtin.Params.Address, math.MaxUint64, &xenv.TransactionContext{ID: txID, Origin: deployer})
+	out, _, err = exec()
+	assert.Nil(t, err)
+	assert.Equal(t, vm.ErrContractAddressCollision, out.VMErr)
+}
+
 func getMockTx(repo *chain.Repository, txType tx.Type, t *testing.T) *tx.Transaction {
 	var blockRef = tx.NewBlockRef(0)
 	var chainTag = repo.ChainTag()
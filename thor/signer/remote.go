@@ -0,0 +1,202 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package signer
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+	"github.com/vechain/thor/v2/vrf"
+)
+
+const remoteTimeout = 10 * time.Second
+
+// KeyResponse is the response of GET /key.
+type KeyResponse struct {
+	Address   thor.Address  `json:"address"`
+	PublicKey hexutil.Bytes `json:"publicKey"`
+}
+
+// SignBlockRequest is the request of POST /sign/block, with the RLP encoded header to sign.
+type SignBlockRequest struct {
+	Header hexutil.Bytes `json:"header"`
+}
+
+// SignDelegationRequest is the request of POST /sign/delegation, with the RLP encoded transaction of the origin.
+type SignDelegationRequest struct {
+	Raw    hexutil.Bytes `json:"raw"`
+	Origin thor.Address  `json:"origin"`
+}
+
+// SignResponse is the response of POST /sign/block and /sign/delegation.
+type SignResponse struct {
+	Signature hexutil.Bytes `json:"signature"`
+}
+
+// ProveRequest is the request of POST /prove.
+type ProveRequest struct {
+	Alpha hexutil.Bytes `json:"alpha"`
+}
+
+// ProveResponse is the response of POST /prove.
+type ProveResponse struct {
+	Proof hexutil.Bytes `json:"proof"`
+}
+
+type remote struct {
+	client  *http.Client
+	baseURL string
+	pub     *ecdsa.PublicKey
+	addr    thor.Address
+}
+
+// NewRemote returns a signer of the key held by the signing service at the url, either http(s)://host:port or
+// unix:///path/to/socket. The signatures and proofs of the service are verified against its public key.
+func NewRemote(rawURL string) (Signer, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse signer url")
+	}
+
+	r := &remote{client: &http.Client{Timeout: remoteTimeout}}
+	switch u.Scheme {
+	case "http", "https":
+		r.baseURL = strings.TrimSuffix(u.String(), "/")
+	case "unix":
+		path := u.Path
+		if path == "" {
+			return nil, errors.New("signer url: socket path required")
+		}
+		r.client.Transport = &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", path)
+			},
+		}
+		r.baseURL = "http://unix"
+	default:
+		return nil, errors.Errorf("signer url: unsupported scheme %q, want http, https or unix", u.Scheme)
+	}
+
+	var key KeyResponse
+	if err := r.call(http.MethodGet, "/key", nil, &key); err != nil {
+		return nil, err
+	}
+	if r.pub, err = crypto.UnmarshalPubkey(key.PublicKey); err != nil {
+		return nil, errors.Wrap(err, "signer public key")
+	}
+	r.addr = thor.Address(crypto.PubkeyToAddress(*r.pub))
+	if r.addr != key.Address {
+		return nil, errors.Errorf("signer address %v mismatches its public key", key.Address)
+	}
+	return r, nil
+}
+
+func (r *remote) Address() thor.Address {
+	return r.addr
+}
+
+func (r *remote) PublicKey() *ecdsa.PublicKey {
+	return r.pub
+}
+
+func (r *remote) SignBlock(header *block.Header) ([]byte, error) {
+	data, err := rlp.EncodeToBytes(header)
+	if err != nil {
+		return nil, err
+	}
+	var res SignResponse
+	if err := r.call(http.MethodPost, "/sign/block", &SignBlockRequest{Header: data}, &res); err != nil {
+		return nil, err
+	}
+	return r.verify(header.SigningHash(), res.Signature)
+}
+
+func (r *remote) SignDelegation(trx *tx.Transaction, origin thor.Address) ([]byte, error) {
+	raw, err := trx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	var res SignResponse
+	if err := r.call(http.MethodPost, "/sign/delegation", &SignDelegationRequest{Raw: raw, Origin: origin}, &res); err != nil {
+		return nil, err
+	}
+	return r.verify(trx.DelegatorSigningHash(origin), res.Signature)
+}
+
+// verify checks the signature of the hash returned by the service is of its key.
+func (r *remote) verify(hash thor.Bytes32, sig []byte) ([]byte, error) {
+	pub, err := crypto.SigToPub(hash.Bytes(), sig)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid signature from signer")
+	}
+	if thor.Address(crypto.PubkeyToAddress(*pub)) != r.addr {
+		return nil, errors.New("invalid signature from signer: signer mismatch")
+	}
+	return sig, nil
+}
+
+func (r *remote) Prove(alpha []byte) ([]byte, error) {
+	var res ProveResponse
+	if err := r.call(http.MethodPost, "/prove", &ProveRequest{Alpha: alpha}, &res); err != nil {
+		return nil, err
+	}
+	if _, err := vrf.Verify(r.pub, alpha, res.Proof); err != nil {
+		return nil, errors.Wrap(err, "invalid proof from signer")
+	}
+	return res.Proof, nil
+}
+
+func (r *remote) call(method, path string, reqObj, resObj any) error {
+	var body io.Reader
+	if reqObj != nil {
+		data, err := json.Marshal(reqObj)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, r.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	if reqObj != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := r.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "signer request")
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if err != nil {
+		return errors.Wrap(err, "signer response")
+	}
+	if res.StatusCode != http.StatusOK {
+		return errors.Errorf("signer %v %v: %v %v", method, path, res.StatusCode, strings.TrimSpace(string(data)))
+	}
+	if err := json.Unmarshal(data, resObj); err != nil {
+		return errors.Wrap(err, "decode signer response")
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package signer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/tx"
+)
+
+// refusedError is the error of a request the service refuses to serve.
+type refusedError struct {
+	msg string
+}
+
+func (e *refusedError) Error() string {
+	return e.msg
+}
+
+// AuditRecord is a line of the audit log, written for each sign or prove request.
+type AuditRecord struct {
+	Time   time.Time     `json:"time"`
+	Remote string        `json:"remote"`
+	Op     string        `json:"op"`
+	Input  hexutil.Bytes `json:"input,omitempty"`
+	Result string        `json:"result"`
+}
+
+// Server is the signing service of a signer. Requests over TCP are served only for the clients in the allowlist,
+// the ones over a unix socket are always served, access to the socket being restricted by its file permissions.
+// Every sign and prove request is recorded in the audit log, a request is not served if its record can't be
+// written.
+//
+// The service signs block headers and transactions it decodes, over the signing hashes it computes, never a hash
+// given by the client. Blocks are signed in increasing timestamp only, so that the key never signs two blocks of
+// the same slot, the timestamp of the last block signed being kept in memory. Block numbers are not compared, since
+// the best chain may switch to a branch shorter than a block already signed.
+type Server struct {
+	signer Signer
+	allow  []*net.IPNet
+
+	blockLock sync.Mutex
+	lastBlock uint64 // the timestamp of the last block signed
+
+	auditLock sync.Mutex
+	audit     io.Writer
+}
+
+// NewServer creates the signing service of the signer.
+func NewServer(signer Signer, allow []*net.IPNet, audit io.Writer) *Server {
+	return &Server{signer: signer, allow: allow, audit: audit}
+}
+
+// ParseAllowlist parses IP addresses and CIDR notations into networks.
+func ParseAllowlist(entries []string) ([]*net.IPNet, error) {
+	var nets []*net.IPNet
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, errors.Errorf("invalid allowlist entry %q", entry)
+			}
+			bits := 8 * net.IPv6len
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, n, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid allowlist entry %q", entry)
+		}
+		nets = append(nets, n)
+	}
+	return nets, nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var op string
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/key":
+		op = "key"
+	case r.Method == http.MethodPost && r.URL.Path == "/sign/block":
+		op = "signBlock"
+	case r.Method == http.MethodPost && r.URL.Path == "/sign/delegation":
+		op = "signDelegation"
+	case r.Method == http.MethodPost && r.URL.Path == "/prove":
+		op = "prove"
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	if !s.allowed(r) {
+		if op != "key" {
+			if err := s.record(r, op, nil, "denied"); err != nil {
+				http.Error(w, "audit log unavailable", http.StatusInternalServerError)
+				return
+			}
+		}
+		http.Error(w, "client not allowed", http.StatusForbidden)
+		return
+	}
+
+	var (
+		input []byte
+		res   any
+		err   error
+	)
+	switch op {
+	case "key":
+		writeJSON(w, &KeyResponse{
+			Address:   s.signer.Address(),
+			PublicKey: crypto.FromECDSAPub(s.signer.PublicKey()),
+		})
+		return
+	case "signBlock":
+		var (
+			req    SignBlockRequest
+			header block.Header
+		)
+		if err := json.NewDecoder(io.LimitReader(r.Body, 1<<20)).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if err := rlp.DecodeBytes(req.Header, &header); err != nil {
+			http.Error(w, "invalid header", http.StatusBadRequest)
+			return
+		}
+		input = req.Header
+		var sig []byte
+		if sig, err = s.signBlock(&header); err == nil {
+			res = &SignResponse{Signature: sig}
+		}
+	case "signDelegation":
+		var (
+			req SignDelegationRequest
+			trx tx.Transaction
+		)
+		if err := json.NewDecoder(io.LimitReader(r.Body, 1<<20)).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if err := trx.UnmarshalBinary(req.Raw); err != nil || !trx.Features().IsDelegated() {
+			http.Error(w, "invalid delegated transaction", http.StatusBadRequest)
+			return
+		}
+		input = req.Raw
+		var sig []byte
+		if sig, err = s.signer.SignDelegation(&trx, req.Origin); err == nil {
+			res = &SignResponse{Signature: sig}
+		}
+	case "prove":
+		var req ProveRequest
+		if err := json.NewDecoder(io.LimitReader(r.Body, 1<<20)).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		input = req.Alpha
+		var proof []byte
+		if proof, err = s.signer.Prove(req.Alpha); err == nil {
+			res = &ProveResponse{Proof: proof}
+		}
+	}
+
+	result, status := "ok", http.StatusInternalServerError
+	if _, refused := err.(*refusedError); refused {
+		result, status = "refused: "+err.Error(), http.StatusConflict
+	} else if err != nil {
+		result = "error: " + err.Error()
+	}
+	if auditErr := s.record(r, op, input, result); auditErr != nil {
+		http.Error(w, "audit log unavailable", http.StatusInternalServerError)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	writeJSON(w, res)
+}
+
+// signBlock signs the header if its timestamp is after the one of the last block signed.
+func (s *Server) signBlock(header *block.Header) ([]byte, error) {
+	s.blockLock.Lock()
+	defer s.blockLock.Unlock()
+
+	if header.Timestamp() <= s.lastBlock {
+		return nil, &refusedError{fmt.Sprintf("block timestamp %d is not after the last block signed %d", header.Timestamp(), s.lastBlock)}
+	}
+	sig, err := s.signer.SignBlock(header)
+	if err != nil {
+		return nil, err
+	}
+	s.lastBlock = header.Timestamp()
+	return sig, nil
+}
+
+// allowed returns whether the client of the request is served.
+func (s *Server) allowed(r *http.Request) bool {
+	// clients of unix sockets are served, the access being restricted by the file permissions
+	if local, ok := r.Context().Value(http.LocalAddrContextKey).(net.Addr); ok && local.Network() == "unix" {
+		return true
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, n := range s.allow {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) record(r *http.Request, op string, input []byte, result string) error {
+	data, err := json.Marshal(&AuditRecord{
+		Time:   time.Now().UTC(),
+		Remote: r.RemoteAddr,
+		Op:     op,
+		Input:  input,
+		Result: result,
+	})
+	if err != nil {
+		return err
+	}
+
+	s.auditLock.Lock()
+	defer s.auditLock.Unlock()
+	_, err = s.audit.Write(append(data, '\n'))
+	return err
+}
+
+func writeJSON(w http.ResponseWriter, obj any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(obj)
+}
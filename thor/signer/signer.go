@@ -0,0 +1,66 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package signer abstracts the master key of a node, which signs the blocks it packs. The key may be held by the
+// node process, or by a remote signing service, so that it never sits in the node process memory.
+//
+// Signers sign blocks and delegations, not arbitrary hashes, so that a remote signing service computes the hashes
+// it signs itself and can't be used to sign transactions with the key.
+package signer
+
+import (
+	"crypto/ecdsa"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+	"github.com/vechain/thor/v2/vrf"
+)
+
+// Signer signs block headers and gas payer delegations, and proves VRF outputs with a private key.
+type Signer interface {
+	// Address returns the address of the key.
+	Address() thor.Address
+	// PublicKey returns the public key of the key.
+	PublicKey() *ecdsa.PublicKey
+	// SignBlock returns the 65 bytes recoverable signature of the signing hash of the header.
+	SignBlock(header *block.Header) ([]byte, error)
+	// SignDelegation returns the 65 bytes recoverable signature of the gas payer of the transaction of the origin,
+	// as specified by VIP-191.
+	SignDelegation(trx *tx.Transaction, origin thor.Address) ([]byte, error)
+	// Prove returns the VRF proof of the alpha.
+	Prove(alpha []byte) ([]byte, error)
+}
+
+type local struct {
+	key *ecdsa.PrivateKey
+}
+
+// NewLocal returns a signer of the private key held in memory.
+func NewLocal(key *ecdsa.PrivateKey) Signer {
+	return &local{key}
+}
+
+func (l *local) Address() thor.Address {
+	return thor.Address(crypto.PubkeyToAddress(l.key.PublicKey))
+}
+
+func (l *local) PublicKey() *ecdsa.PublicKey {
+	return &l.key.PublicKey
+}
+
+func (l *local) SignBlock(header *block.Header) ([]byte, error) {
+	return crypto.Sign(header.SigningHash().Bytes(), l.key)
+}
+
+func (l *local) SignDelegation(trx *tx.Transaction, origin thor.Address) ([]byte, error) {
+	return crypto.Sign(trx.DelegatorSigningHash(origin).Bytes(), l.key)
+}
+
+func (l *local) Prove(alpha []byte) ([]byte, error) {
+	_, proof, err := vrf.Prove(l.key, alpha)
+	return proof, err
+}
@@ -0,0 +1,330 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package signer_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/signer"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+	"github.com/vechain/thor/v2/vrf"
+)
+
+// newHeader returns a header of the block number.
+func newHeader(number uint32) *block.Header {
+	var parentID thor.Bytes32
+	binary.BigEndian.PutUint32(parentID[:], number-1)
+	return new(block.Builder).ParentID(parentID).Timestamp(uint64(number) * thor.BlockInterval).Build().Header()
+}
+
+func newDelegatedTx() *tx.Transaction {
+	return tx.NewBuilder(tx.TypeLegacy).Gas(21000).Nonce(1).Features(tx.DelegationFeature).Build()
+}
+
+func loopback(t *testing.T) []*net.IPNet {
+	allow, err := signer.ParseAllowlist([]string{"127.0.0.1", "::1"})
+	require.NoError(t, err)
+	return allow
+}
+
+func TestLocal(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	s := signer.NewLocal(key)
+
+	assert.Equal(t, thor.Address(crypto.PubkeyToAddress(key.PublicKey)), s.Address())
+
+	header := newHeader(1)
+	sig, err := s.SignBlock(header)
+	require.NoError(t, err)
+	pub, err := crypto.SigToPub(header.SigningHash().Bytes(), sig)
+	require.NoError(t, err)
+	assert.Equal(t, key.PublicKey, *pub)
+
+	trx, origin := newDelegatedTx(), thor.BytesToAddress([]byte("origin"))
+	sig, err = s.SignDelegation(trx, origin)
+	require.NoError(t, err)
+	pub, err = crypto.SigToPub(trx.DelegatorSigningHash(origin).Bytes(), sig)
+	require.NoError(t, err)
+	assert.Equal(t, key.PublicKey, *pub)
+
+	proof, err := s.Prove([]byte("alpha"))
+	require.NoError(t, err)
+	_, err = vrf.Verify(&key.PublicKey, []byte("alpha"), proof)
+	assert.NoError(t, err)
+}
+
+func TestRemote(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	local := signer.NewLocal(key)
+
+	var audit bytes.Buffer
+	srv := httptest.NewServer(signer.NewServer(local, loopback(t), &audit))
+	defer srv.Close()
+
+	remote, err := signer.NewRemote(srv.URL)
+	require.NoError(t, err)
+	assert.Equal(t, local.Address(), remote.Address())
+
+	header := newHeader(10)
+	sig, err := remote.SignBlock(header)
+	require.NoError(t, err)
+	expected, err := local.SignBlock(header)
+	require.NoError(t, err)
+	assert.Equal(t, expected, sig)
+
+	trx, origin := newDelegatedTx(), thor.BytesToAddress([]byte("origin"))
+	sig, err = remote.SignDelegation(trx, origin)
+	require.NoError(t, err)
+	expected, err = local.SignDelegation(trx, origin)
+	require.NoError(t, err)
+	assert.Equal(t, expected, sig)
+
+	proof, err := remote.Prove([]byte("alpha"))
+	require.NoError(t, err)
+	_, err = vrf.Verify(&key.PublicKey, []byte("alpha"), proof)
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(audit.String()), "\n")
+	require.Len(t, lines, 3)
+	var record signer.AuditRecord
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &record))
+	assert.Equal(t, "signBlock", record.Op)
+	encoded, err := rlp.EncodeToBytes(header)
+	require.NoError(t, err)
+	assert.Equal(t, encoded, []byte(record.Input))
+	assert.Equal(t, "ok", record.Result)
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &record))
+	assert.Equal(t, "signDelegation", record.Op)
+	raw, err := trx.MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, raw, []byte(record.Input))
+	require.NoError(t, json.Unmarshal([]byte(lines[2]), &record))
+	assert.Equal(t, "prove", record.Op)
+	assert.Equal(t, []byte("alpha"), []byte(record.Input))
+}
+
+func TestRemoteBlockTimestamps(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	var audit bytes.Buffer
+	srv := httptest.NewServer(signer.NewServer(signer.NewLocal(key), loopback(t), &audit))
+	defer srv.Close()
+	remote, err := signer.NewRemote(srv.URL)
+	require.NoError(t, err)
+
+	_, err = remote.SignBlock(newHeader(10))
+	require.NoError(t, err)
+	// another block of the same slot, or of an earlier one, is refused
+	_, err = remote.SignBlock(newHeader(10))
+	assert.ErrorContains(t, err, "409")
+	assert.ErrorContains(t, err, "block timestamp 100 is not after the last block signed 100")
+	_, err = remote.SignBlock(newHeader(9))
+	assert.ErrorContains(t, err, "409")
+	_, err = remote.SignBlock(newHeader(11))
+	assert.NoError(t, err)
+
+	// a block of a later slot on a shorter branch is signed
+	parentID := thor.Bytes32{31: 1}
+	binary.BigEndian.PutUint32(parentID[:], 8)
+	shorter := new(block.Builder).ParentID(parentID).Timestamp(12 * thor.BlockInterval).Build().Header()
+	require.Equal(t, uint32(9), shorter.Number())
+	_, err = remote.SignBlock(shorter)
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(audit.String()), "\n")
+	require.Len(t, lines, 5)
+	var record signer.AuditRecord
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &record))
+	assert.Equal(t, "refused: block timestamp 100 is not after the last block signed 100", record.Result)
+}
+
+func TestServerInvalidRequests(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	var audit bytes.Buffer
+	srv := httptest.NewServer(signer.NewServer(signer.NewLocal(key), loopback(t), &audit))
+	defer srv.Close()
+
+	for _, tc := range []struct {
+		path string
+		body string
+	}{
+		// hashes are not signed
+		{"/sign", `{"hash":"` + thor.Blake2b([]byte("hash")).String() + `"}`},
+		{"/sign/block", `{"header":"0x1234"}`},
+		{"/sign/delegation", `{"raw":"0x1234","origin":"` + thor.Address{}.String() + `"}`},
+		{"/sign/block", `not json`},
+	} {
+		res, err := http.Post(srv.URL+tc.path, "application/json", strings.NewReader(tc.body))
+		require.NoError(t, err)
+		res.Body.Close()
+		assert.NotEqual(t, http.StatusOK, res.StatusCode, tc.path)
+	}
+
+	// not delegated
+	raw, err := tx.NewBuilder(tx.TypeLegacy).Gas(21000).Build().MarshalBinary()
+	require.NoError(t, err)
+	res, err := http.Post(srv.URL+"/sign/delegation", "application/json",
+		strings.NewReader(`{"raw":"`+hexutil.Encode(raw)+`","origin":"`+thor.Address{}.String()+`"}`))
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	assert.Zero(t, audit.Len())
+}
+
+func TestRemoteUnixSocket(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "signer.sock")
+	listener, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	// unix socket clients are served regardless of the allowlist
+	srv := &http.Server{Handler: signer.NewServer(signer.NewLocal(key), nil, &bytes.Buffer{})}
+	go srv.Serve(listener)
+	defer srv.Close()
+
+	remote, err := signer.NewRemote("unix://" + path)
+	require.NoError(t, err)
+	assert.Equal(t, thor.Address(crypto.PubkeyToAddress(key.PublicKey)), remote.Address())
+
+	_, err = remote.SignBlock(newHeader(1))
+	assert.NoError(t, err)
+}
+
+func TestRemoteNotAllowed(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	local := signer.NewLocal(key)
+
+	allow, err := signer.ParseAllowlist([]string{"10.0.0.0/8"})
+	require.NoError(t, err)
+	var audit bytes.Buffer
+	srv := httptest.NewServer(signer.NewServer(local, allow, &audit))
+	defer srv.Close()
+
+	_, err = signer.NewRemote(srv.URL)
+	assert.ErrorContains(t, err, "403")
+
+	header, err := rlp.EncodeToBytes(newHeader(1))
+	require.NoError(t, err)
+	res, err := http.Post(srv.URL+"/sign/block", "application/json", strings.NewReader(`{"header":"`+hexutil.Encode(header)+`"}`))
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+	var record signer.AuditRecord
+	require.NoError(t, json.Unmarshal(audit.Bytes(), &record))
+	assert.Equal(t, "signBlock", record.Op)
+	assert.Equal(t, "denied", record.Result)
+}
+
+func TestServerInvalidRemoteAddr(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	srv := signer.NewServer(signer.NewLocal(key), loopback(t), &bytes.Buffer{})
+
+	// a remote address which can't be parsed is not served
+	req := httptest.NewRequest(http.MethodGet, "/key", nil)
+	req.RemoteAddr = "invalid"
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestRemoteAuditFailure(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(signer.NewServer(signer.NewLocal(key), loopback(t), failingWriter{}))
+	defer srv.Close()
+
+	remote, err := signer.NewRemote(srv.URL)
+	require.NoError(t, err)
+	_, err = remote.SignBlock(newHeader(1))
+	assert.ErrorContains(t, err, "audit log unavailable")
+}
+
+// wrongKey signs with another key than the one it claims.
+type wrongKey struct {
+	signer.Signer
+	other signer.Signer
+}
+
+func (w *wrongKey) SignBlock(header *block.Header) ([]byte, error) { return w.other.SignBlock(header) }
+
+func (w *wrongKey) SignDelegation(trx *tx.Transaction, origin thor.Address) ([]byte, error) {
+	return w.other.SignDelegation(trx, origin)
+}
+
+func (w *wrongKey) Prove(alpha []byte) ([]byte, error) { return w.other.Prove(alpha) }
+
+func TestRemoteVerifies(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	other, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	s := &wrongKey{signer.NewLocal(key), signer.NewLocal(other)}
+	srv := httptest.NewServer(signer.NewServer(s, loopback(t), &bytes.Buffer{}))
+	defer srv.Close()
+
+	remote, err := signer.NewRemote(srv.URL)
+	require.NoError(t, err)
+	_, err = remote.SignBlock(newHeader(1))
+	assert.ErrorContains(t, err, "signer mismatch")
+	_, err = remote.SignDelegation(newDelegatedTx(), thor.Address{})
+	assert.ErrorContains(t, err, "signer mismatch")
+	_, err = remote.Prove([]byte("alpha"))
+	assert.ErrorContains(t, err, "invalid proof from signer")
+}
+
+func TestNewRemoteInvalidURL(t *testing.T) {
+	_, err := signer.NewRemote("ftp://localhost")
+	assert.ErrorContains(t, err, "unsupported scheme")
+	_, err = signer.NewRemote("unix://")
+	assert.ErrorContains(t, err, "socket path required")
+}
+
+func TestParseAllowlist(t *testing.T) {
+	allow, err := signer.ParseAllowlist([]string{"127.0.0.1", " 10.0.0.0/8", "", "::1"})
+	require.NoError(t, err)
+	require.Len(t, allow, 3)
+	assert.True(t, allow[0].Contains(net.ParseIP("127.0.0.1")))
+	assert.False(t, allow[0].Contains(net.ParseIP("127.0.0.2")))
+	assert.True(t, allow[1].Contains(net.ParseIP("10.1.2.3")))
+	assert.True(t, allow[2].Contains(net.ParseIP("::1")))
+
+	_, err = signer.ParseAllowlist([]string{"localhost"})
+	assert.Error(t, err)
+	_, err = signer.ParseAllowlist([]string{"10.0.0.0/33"})
+	assert.Error(t, err)
+}
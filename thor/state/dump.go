@@ -0,0 +1,86 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
+)
+
+// DumpedAccount is an account read from the account trie, to dump the state.
+type DumpedAccount struct {
+	Account
+	obj *cachedObject
+}
+
+// Code returns the code of the account.
+func (a *DumpedAccount) Code() ([]byte, error) {
+	return a.obj.GetCode()
+}
+
+// IterateStorage calls fn with each storage entry of the account, in the order of the storage trie.
+func (a *DumpedAccount) IterateStorage(fn func(key thor.Bytes32, value rlp.RawValue) error) error {
+	t := a.obj.getOrCreateStorageTrie()
+	if t == nil {
+		return nil
+	}
+	it := trie.NewIterator(t.NodeIterator(nil, 0))
+	for it.Next() {
+		// the key preimage is kept as metadata
+		if err := fn(thor.BytesToBytes32(it.Meta), it.Value); err != nil {
+			return err
+		}
+	}
+	return it.Err
+}
+
+// DumpAccounts calls fn with each account of the state, in the order of the account trie whose keys are
+// hashes of the addresses. Uncommitted changes are not included.
+func (s *State) DumpAccounts(fn func(keyHash thor.Bytes32, acc *DumpedAccount) error) error {
+	it := trie.NewIterator(s.trie.NodeIterator(nil, 0))
+	for it.Next() {
+		var acc Account
+		if err := rlp.DecodeBytes(it.Value, &acc); err != nil {
+			return &Error{err}
+		}
+		var meta AccountMetadata
+		if len(it.Meta) > 0 {
+			if err := rlp.DecodeBytes(it.Meta, &meta); err != nil {
+				return &Error{err}
+			}
+		}
+		if err := fn(thor.BytesToBytes32(it.Key), s.newDumpedAccount(thor.Address{}, &acc, &meta)); err != nil {
+			return err
+		}
+	}
+	if it.Err != nil {
+		return &Error{it.Err}
+	}
+	return nil
+}
+
+// DumpAccount returns the account at the given address, or nil if the account is empty.
+// Uncommitted changes are not included.
+func (s *State) DumpAccount(addr thor.Address) (*DumpedAccount, error) {
+	acc, meta, err := loadAccount(s.trie, addr)
+	if err != nil {
+		return nil, &Error{err}
+	}
+	if acc.IsEmpty() {
+		return nil, nil
+	}
+	return s.newDumpedAccount(addr, acc, meta), nil
+}
+
+// AccountKeyHash returns the key of the address in the account trie.
+func AccountKeyHash(addr thor.Address) thor.Bytes32 {
+	return thor.BytesToBytes32(secureKey(addr[:]))
+}
+
+func (s *State) newDumpedAccount(addr thor.Address, acc *Account, meta *AccountMetadata) *DumpedAccount {
+	return &DumpedAccount{Account: *acc, obj: newCachedObject(s.db, addr, acc, meta)}
+}
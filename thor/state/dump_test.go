@@ -0,0 +1,66 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
+)
+
+func TestDumpAccounts(t *testing.T) {
+	db := muxdb.NewMem()
+	st := New(db, trie.Root{})
+
+	addr1 := thor.BytesToAddress([]byte("addr1"))
+	addr2 := thor.BytesToAddress([]byte("addr2"))
+	master := thor.BytesToAddress([]byte("master"))
+	key := thor.BytesToBytes32([]byte("key"))
+
+	st.SetBalance(addr1, big.NewInt(1))
+	st.SetCode(addr2, []byte("code"))
+	st.SetMaster(addr2, master)
+	st.SetStorage(addr2, key, thor.BytesToBytes32([]byte("value")))
+
+	stage, err := st.Stage(trie.Version{})
+	assert.Nil(t, err)
+	root, err := stage.Commit()
+	assert.Nil(t, err)
+	st = New(db, trie.Root{Hash: root})
+
+	accounts := make(map[thor.Bytes32]*DumpedAccount)
+	assert.Nil(t, st.DumpAccounts(func(keyHash thor.Bytes32, acc *DumpedAccount) error {
+		accounts[keyHash] = acc
+		return nil
+	}))
+	assert.Len(t, accounts, 2)
+	assert.Equal(t, big.NewInt(1), accounts[AccountKeyHash(addr1)].Balance)
+
+	acc := accounts[AccountKeyHash(addr2)]
+	assert.Equal(t, master.Bytes(), acc.Master)
+	assert.Equal(t, M([]byte("code"), nil), M(acc.Code()))
+
+	storage := make(map[thor.Bytes32]rlp.RawValue)
+	assert.Nil(t, acc.IterateStorage(func(key thor.Bytes32, value rlp.RawValue) error {
+		storage[key] = value
+		return nil
+	}))
+	value, _ := rlp.EncodeToBytes([]byte("value"))
+	assert.Equal(t, map[thor.Bytes32]rlp.RawValue{key: value}, storage)
+
+	dumped, err := st.DumpAccount(addr2)
+	assert.Nil(t, err)
+	assert.Equal(t, acc.Account, dumped.Account)
+
+	dumped, err = st.DumpAccount(thor.BytesToAddress([]byte("empty")))
+	assert.Nil(t, err)
+	assert.Nil(t, dumped)
+}
@@ -180,6 +180,17 @@ func (c *Chain) MintClauses(account genesis.DevAccount, clauses []*tx.Clause) er
 // MintBlock creates and finalizes a new block with the given transactions.
 // It schedules a new block, adopts transactions, packs them into a block, and commits it to the chain.
 func (c *Chain) MintBlock(account genesis.DevAccount, transactions ...*tx.Transaction) error {
+	_, err := c.mintBlock(account, transactions, false)
+	return err
+}
+
+// MintAdoptable creates and finalizes a new block with the given transactions which can be adopted,
+// skipping the others. It returns the adopted transactions.
+func (c *Chain) MintAdoptable(account genesis.DevAccount, transactions ...*tx.Transaction) (tx.Transactions, error) {
+	return c.mintBlock(account, transactions, true)
+}
+
+func (c *Chain) mintBlock(account genesis.DevAccount, transactions []*tx.Transaction, skipFailed bool) (tx.Transactions, error) {
 	// Create a new block packer with the current chain state and account information.
 	blkPacker := packer.New(c.Repo(), c.Stater(), account.Address, &genesis.DevAccounts()[0].Address, c.forkConfig, 0)
 
@@ -190,41 +201,46 @@ func (c *Chain) MintBlock(account genesis.DevAccount, transactions ...*tx.Transa
 		c.Repo().BestBlockSummary().Header.GasLimit(),
 	)
 	if err != nil {
-		return fmt.Errorf("unable to mock a new block: %w", err)
+		return nil, fmt.Errorf("unable to mock a new block: %w", err)
 	}
 
 	// Adopt the provided transactions into the block.
+	var adopted tx.Transactions
 	for _, trx := range transactions {
 		if err = blkFlow.Adopt(trx); err != nil {
-			return fmt.Errorf("unable to adopt tx into block: %w", err)
+			if skipFailed {
+				continue
+			}
+			return nil, fmt.Errorf("unable to adopt tx into block: %w", err)
 		}
+		adopted = append(adopted, trx)
 	}
 
 	// Pack the adopted transactions into a block.
 	newBlk, stage, receipts, err := blkFlow.Pack(account.PrivateKey, 0, false)
 	if err != nil {
-		return fmt.Errorf("unable to pack tx: %w", err)
+		return nil, fmt.Errorf("unable to pack tx: %w", err)
 	}
 
 	// Commit the new block to the chain's state.
 	if _, err := stage.Commit(); err != nil {
-		return fmt.Errorf("unable to commit tx: %w", err)
+		return nil, fmt.Errorf("unable to commit tx: %w", err)
 	}
 
 	// Add the block to the repository.
 	if err := c.Repo().AddBlock(newBlk, receipts, 0, true); err != nil {
-		return fmt.Errorf("unable to add tx to repo: %w", err)
+		return nil, fmt.Errorf("unable to add tx to repo: %w", err)
 	}
 
 	// Write the new block and receipts to the logdb.
 	w := c.LogDB().NewWriter()
 	if err := w.Write(newBlk, receipts); err != nil {
-		return err
+		return nil, err
 	}
 	if err := w.Commit(); err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+	return adopted, nil
 }
 
 // GetAllBlocks retrieves all blocks from the blockchain, starting from the best block and moving backward to the genesis block.
@@ -0,0 +1,227 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package testnode runs the node API in-process on top of a testchain.Chain, and provides a thorclient.Client
+// connected to it without any network listener.
+//
+// Transactions sent through the client are packed into a new block before the submission returns, unless
+// the node is created with WithManualMint, in which case blocks are only minted by calling Mint. The errors of
+// the automatic minting are received from MintErrors:
+//
+//	chain, _ := testchain.NewDefault()
+//	node, _ := testnode.New(chain)
+//	defer node.Close()
+//
+//	client := node.Client()
+package testnode
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+	"github.com/vechain/thor/v2/api/accounts"
+	"github.com/vechain/thor/v2/api/blocks"
+	"github.com/vechain/thor/v2/api/debug"
+	"github.com/vechain/thor/v2/api/events"
+	"github.com/vechain/thor/v2/api/fees"
+	"github.com/vechain/thor/v2/api/node"
+	"github.com/vechain/thor/v2/api/subscriptions"
+	"github.com/vechain/thor/v2/api/transactions"
+	"github.com/vechain/thor/v2/api/transfers"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/co"
+	"github.com/vechain/thor/v2/comm"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/log"
+	"github.com/vechain/thor/v2/logdb"
+	"github.com/vechain/thor/v2/test/testchain"
+	"github.com/vechain/thor/v2/thorclient"
+	"github.com/vechain/thor/v2/thorclient/httpclient"
+	"github.com/vechain/thor/v2/thorclient/wsclient"
+	"github.com/vechain/thor/v2/txpool"
+)
+
+const (
+	url               = "http://testnode"
+	callGasLimit      = 50_000_000
+	logsLimit         = 1000
+	backtraceLimit    = 1000
+	priorityFeesRatio = 5
+	feeCacheSize      = 1024
+	mintErrorsSize    = 16
+)
+
+var logger = log.WithContext("pkg", "testnode")
+
+// Option configures a Node.
+type Option func(*Node)
+
+// WithManualMint disables minting a block on each transaction submission.
+func WithManualMint() Option {
+	return func(n *Node) {
+		n.autoMint = false
+	}
+}
+
+// WithMinter sets the account signing the minted blocks, the first dev account by default.
+func WithMinter(account genesis.DevAccount) Option {
+	return func(n *Node) {
+		n.minter = account
+	}
+}
+
+// Node serves the node API of a test chain in-process.
+type Node struct {
+	chain    *testchain.Chain
+	txPool   *txpool.TxPool
+	subs     *subscriptions.Subscriptions
+	listener *pipeListener
+	server   *http.Server
+	client   *thorclient.Client
+	goes     co.Goes
+
+	minter     genesis.DevAccount
+	autoMint   bool
+	mintMu     sync.Mutex
+	mintErrors chan error
+}
+
+// New starts serving the API of the given chain.
+func New(chain *testchain.Chain, opts ...Option) (*Node, error) {
+	n := &Node{
+		chain:      chain,
+		listener:   newPipeListener(),
+		minter:     genesis.DevAccounts()[0],
+		autoMint:   true,
+		mintErrors: make(chan error, mintErrorsSize),
+	}
+	for _, opt := range opts {
+		opt(n)
+	}
+
+	repo, stater, engine, forkConfig := chain.Repo(), chain.Stater(), chain.Engine(), chain.GetForkConfig()
+	n.txPool = txpool.New(repo, stater, txpool.Options{
+		Limit:           10000,
+		LimitPerAccount: 1024,
+		MaxLifetime:     10 * time.Minute,
+	}, forkConfig)
+	n.subs = subscriptions.New(repo, []string{"*"}, backtraceLimit, n.txPool, true)
+
+	router := mux.NewRouter()
+	accounts.New(repo, stater, callGasLimit, forkConfig, engine, true).Mount(router, "/accounts")
+	events.New(repo, chain.LogDB(), logsLimit, logdb.QueryBudget{}, engine).Mount(router, "/logs/event")
+	transfers.New(repo, chain.LogDB(), logsLimit, logdb.QueryBudget{}, engine).Mount(router, "/logs/transfer")
+	blocks.New(repo, engine).Mount(router, "/blocks")
+	transactions.New(repo, n.txPool, engine).Mount(router, "/transactions")
+	debug.New(repo, stater, forkConfig, engine, callGasLimit, true, []string{"all"}, true).Mount(router, "/debug")
+	node.New(comm.New(repo, n.txPool), n.txPool, true).Mount(router, "/node")
+	fees.New(repo, engine, forkConfig, stater, fees.Config{
+		APIBacktraceLimit:          backtraceLimit,
+		PriorityIncreasePercentage: priorityFeesRatio,
+		FixedCacheSize:             feeCacheSize,
+	}).Mount(router, "/fees")
+	n.subs.Mount(router, "/subscriptions")
+	router.Use(n.handleAutoMint)
+
+	n.server = &http.Server{Handler: router, ReadHeaderTimeout: time.Second}
+	n.goes.Go(func() {
+		n.server.Serve(n.listener)
+	})
+
+	wsConn, err := wsclient.NewClientWithDialer(url, &websocket.Dialer{
+		NetDialContext:   n.listener.DialContext,
+		HandshakeTimeout: 5 * time.Second,
+	})
+	if err != nil {
+		n.Close()
+		return nil, err
+	}
+	httpConn := httpclient.NewWithHTTP(url, &http.Client{
+		Transport: &http.Transport{DialContext: n.listener.DialContext},
+	})
+	n.client = thorclient.NewWithClients(httpConn, wsConn)
+	return n, nil
+}
+
+// Client returns a client connected to the node.
+func (n *Node) Client() *thorclient.Client {
+	return n.client
+}
+
+// Chain returns the chain of the node.
+func (n *Node) Chain() *testchain.Chain {
+	return n.chain
+}
+
+// TxPool returns the transaction pool of the node.
+func (n *Node) TxPool() *txpool.TxPool {
+	return n.txPool
+}
+
+// MintErrors returns the channel receiving the errors of the blocks minted on transaction submissions. The errors
+// are dropped while the channel is full.
+func (n *Node) MintErrors() <-chan error {
+	return n.mintErrors
+}
+
+// Mint packs the pending transactions which can be adopted into a new block, and returns the block.
+// A block is minted even if there is no transaction to pack.
+func (n *Node) Mint() (*block.Block, error) {
+	n.mintMu.Lock()
+	defer n.mintMu.Unlock()
+
+	adopted, err := n.chain.MintAdoptable(n.minter, n.txPool.Dump()...)
+	if err != nil {
+		return nil, err
+	}
+	for _, trx := range adopted {
+		n.txPool.Remove(trx.Hash(), trx.ID())
+	}
+	return n.chain.BestBlock()
+}
+
+// Close stops serving the API.
+func (n *Node) Close() {
+	n.server.Close()
+	n.listener.Close()
+	n.subs.Close()
+	n.txPool.Close()
+	n.goes.Wait()
+}
+
+// handleAutoMint mints a block once a transaction is accepted, before the response is sent.
+func (n *Node) handleAutoMint(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !n.autoMint || r.Method != http.MethodPost || r.URL.Path != "/transactions" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(sw, r)
+		if sw.status == http.StatusOK {
+			// the response is buffered until the handler returns, so the block is minted first
+			if _, err := n.Mint(); err != nil {
+				logger.Warn("failed to mint block", "err", err)
+				select {
+				case n.mintErrors <- err:
+				default:
+				}
+			}
+		}
+	})
+}
+
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
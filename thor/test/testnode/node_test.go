@@ -0,0 +1,146 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package testnode
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/logdb"
+	"github.com/vechain/thor/v2/test/testchain"
+	"github.com/vechain/thor/v2/thorclient"
+	"github.com/vechain/thor/v2/tx"
+
+	tccommon "github.com/vechain/thor/v2/thorclient/common"
+)
+
+func newNode(t *testing.T, opts ...Option) *Node {
+	chain, err := testchain.NewDefault()
+	require.NoError(t, err)
+	n, err := New(chain, opts...)
+	require.NoError(t, err)
+	t.Cleanup(n.Close)
+	return n
+}
+
+func TestNode(t *testing.T) {
+	n := newNode(t)
+	client := n.Client()
+	from, to := genesis.DevAccounts()[0], genesis.DevAccounts()[1]
+
+	sub, err := client.SubscribeBlocks("")
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	before, err := client.Account(&to.Address)
+	require.NoError(t, err)
+
+	transactor := thorclient.NewTransactor(client, from.PrivateKey, thorclient.WithPollInterval(10*time.Millisecond))
+	amount := big.NewInt(1e18)
+	trx, err := transactor.Send(tx.NewClause(&to.Address).WithValue(amount))
+	require.NoError(t, err)
+
+	// the transaction is packed before the submission returns
+	receipt, err := client.TransactionReceipt(ptr(trx.ID()))
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+	assert.False(t, receipt.Reverted)
+	assert.Equal(t, uint32(1), receipt.Meta.BlockNumber)
+
+	after, err := client.Account(&to.Address)
+	require.NoError(t, err)
+	diff := new(big.Int).Sub((*big.Int)(after.Balance), (*big.Int)(before.Balance))
+	assert.Equal(t, amount, diff)
+
+	transfers, err := client.FilterTransfers(&api.TransferFilter{
+		CriteriaSet: []*logdb.TransferCriteria{{Recipient: &to.Address}},
+	})
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	assert.Equal(t, trx.ID(), transfers[0].Meta.TxID)
+
+	select {
+	case ev := <-sub.EventChan:
+		require.NoError(t, ev.Error)
+		assert.Equal(t, uint32(1), ev.Data.Number)
+		assert.Equal(t, []string{trx.ID().String()}, idStrings(ev.Data))
+	case <-time.After(5 * time.Second):
+		t.Fatal("block not received")
+	}
+}
+
+func TestNode_ManualMint(t *testing.T) {
+	n := newNode(t, WithManualMint())
+	client := n.Client()
+
+	transactor := thorclient.NewTransactor(client, genesis.DevAccounts()[0].PrivateKey, thorclient.WithPollInterval(10*time.Millisecond))
+	to := genesis.DevAccounts()[1].Address
+	trx, err := transactor.Send(tx.NewClause(&to).WithValue(big.NewInt(1)))
+	require.NoError(t, err)
+
+	_, err = client.TransactionReceipt(ptr(trx.ID()))
+	assert.ErrorIs(t, err, tccommon.ErrNotFound)
+	pending, err := client.Transaction(ptr(trx.ID()), thorclient.Pending())
+	require.NoError(t, err)
+	assert.NotNil(t, pending)
+
+	blk, err := n.Mint()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), blk.Header().Number())
+	assert.Len(t, blk.Transactions(), 1)
+	assert.Equal(t, 0, n.TxPool().Len())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	receipt, err := transactor.Wait(ctx, trx)
+	require.NoError(t, err)
+	assert.Equal(t, blk.Header().ID(), receipt.Meta.BlockID)
+
+	// empty blocks can be minted too
+	blk, err = n.Mint()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), blk.Header().Number())
+	assert.Empty(t, blk.Transactions())
+}
+
+func TestNode_MintErrors(t *testing.T) {
+	// blocks can't be signed with the key of another account
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	n := newNode(t, WithMinter(genesis.DevAccount{Address: genesis.DevAccounts()[0].Address, PrivateKey: key}))
+
+	transactor := thorclient.NewTransactor(n.Client(), genesis.DevAccounts()[0].PrivateKey)
+	to := genesis.DevAccounts()[1].Address
+	_, err = transactor.Send(tx.NewClause(&to).WithValue(big.NewInt(1)))
+	require.NoError(t, err)
+
+	select {
+	case err := <-n.MintErrors():
+		assert.ErrorContains(t, err, "private key mismatch")
+	default:
+		t.Fatal("mint error not reported")
+	}
+	assert.Equal(t, 1, n.TxPool().Len())
+}
+
+func idStrings(blk *api.BlockMessage) []string {
+	ids := make([]string, 0, len(blk.Transactions))
+	for _, id := range blk.Transactions {
+		ids = append(ids, id.String())
+	}
+	return ids
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
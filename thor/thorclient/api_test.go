@@ -6,11 +6,16 @@
 package thorclient
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
 	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -66,7 +71,7 @@ func initAPIServer(t *testing.T) (*testchain.Chain, *httptest.Server) {
 		Mount(router, "/accounts")
 
 	mempool := txpool.New(thorChain.Repo(), thorChain.Stater(), txpool.Options{Limit: 10000, LimitPerAccount: 16, MaxLifetime: 10 * time.Minute}, &forks)
-	transactions.New(thorChain.Repo(), mempool).Mount(router, "/transactions")
+	transactions.New(thorChain.Repo(), mempool, thorChain.Engine()).Mount(router, "/transactions")
 
 	blocks.New(thorChain.Repo(), thorChain.Engine()).Mount(router, "/blocks")
 
@@ -75,7 +80,7 @@ func initAPIServer(t *testing.T) (*testchain.Chain, *httptest.Server) {
 
 	logDb, err := logdb.NewMem()
 	require.NoError(t, err)
-	events.New(thorChain.Repo(), logDb, logDBLimit).Mount(router, "/logs/event")
+	events.New(thorChain.Repo(), logDb, logDBLimit, logdb.QueryBudget{}, thorChain.Engine()).Mount(router, "/logs/event")
 
 	communicator := comm.New(
 		thorChain.Repo(),
@@ -510,3 +515,146 @@ func testFeesEndpoint(t *testing.T, testchain *testchain.Chain, ts *httptest.Ser
 		require.Equal(t, expectedFeesPriority, feesPriority)
 	})
 }
+
+func TestTransactor(t *testing.T) {
+	thorChain, ts := initAPIServer(t)
+	defer ts.Close()
+
+	c := New(ts.URL)
+	origin := genesis.DevAccounts()[2]
+	toAddr := datagen.RandAddress()
+	clause := tx.NewClause(&toAddr).WithValue(big.NewInt(1000))
+
+	t.Run("BuildAndWait", func(t *testing.T) {
+		transactor := NewTransactor(c, origin.PrivateKey, WithPollInterval(10*time.Millisecond))
+		trx, err := transactor.Build(clause)
+		require.NoError(t, err)
+		require.Equal(t, tx.TypeDynamicFee, trx.Type())
+		require.Equal(t, thorChain.Repo().ChainTag(), trx.ChainTag())
+		require.Equal(t, uint64(21000), trx.Gas())
+		require.Equal(t, 1, trx.MaxFeePerGas().Cmp(trx.MaxPriorityFeePerGas()))
+
+		trx, err = transactor.Sign(trx)
+		require.NoError(t, err)
+		require.NoError(t, thorChain.MintTransactions(origin, trx))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		receipt, err := transactor.Wait(ctx, trx)
+		require.NoError(t, err)
+		require.Equal(t, trx.ID(), receipt.Meta.TxID)
+		require.Equal(t, origin.Address, receipt.Meta.TxOrigin)
+	})
+
+	t.Run("Delegated", func(t *testing.T) {
+		delegator := NewKeyDelegator(genesis.DevAccounts()[3].PrivateKey)
+		transactor := NewTransactor(c, origin.PrivateKey, WithDelegator(delegator), WithLegacyTx(10), WithPollInterval(10*time.Millisecond))
+		trx, err := transactor.Build(clause)
+		require.NoError(t, err)
+		require.Equal(t, tx.TypeLegacy, trx.Type())
+		require.Equal(t, uint8(10), trx.GasPriceCoef())
+
+		trx, err = transactor.Sign(trx)
+		require.NoError(t, err)
+		require.NoError(t, thorChain.MintTransactions(origin, trx))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		receipt, err := transactor.Wait(ctx, trx)
+		require.NoError(t, err)
+		require.Equal(t, delegator.Address(), receipt.GasPayer)
+	})
+
+	t.Run("RemoteDelegated", func(t *testing.T) {
+		payer := NewKeyDelegator(genesis.DevAccounts()[4].PrivateKey)
+		service := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req api.DelegationRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			trx, err := req.Decode()
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			sig, err := payer.Delegate(trx, req.Origin)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(&api.DelegationResponse{Signature: hexutil.Encode(sig)})
+		}))
+		defer service.Close()
+
+		// record the gas payer of the estimations
+		var (
+			lock     sync.Mutex
+			gasPayer *thor.Address
+		)
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/accounts/*" {
+				body, _ := io.ReadAll(r.Body)
+				var calldata api.BatchCallData
+				if json.Unmarshal(body, &calldata) == nil {
+					lock.Lock()
+					gasPayer = calldata.GasPayer
+					lock.Unlock()
+				}
+				r.Body = io.NopCloser(bytes.NewReader(body))
+			}
+			ts.Config.Handler.ServeHTTP(w, r)
+		}))
+		defer proxy.Close()
+
+		delegator := NewRemoteDelegator(service.URL, payer.Address())
+		transactor := NewTransactor(New(proxy.URL), origin.PrivateKey, WithDelegator(delegator), WithPollInterval(10*time.Millisecond))
+		trx, err := transactor.Build(clause)
+		require.NoError(t, err)
+		lock.Lock()
+		require.NotNil(t, gasPayer)
+		require.Equal(t, payer.Address(), *gasPayer)
+		lock.Unlock()
+
+		trx, err = transactor.Sign(trx)
+		require.NoError(t, err)
+		require.NoError(t, thorChain.MintTransactions(origin, trx))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		receipt, err := transactor.Wait(ctx, trx)
+		require.NoError(t, err)
+		require.Equal(t, payer.Address(), receipt.GasPayer)
+
+		// signatures of another key are refused
+		_, err = NewTransactor(c, origin.PrivateKey, WithDelegator(NewRemoteDelegator(service.URL, origin.Address))).Sign(trx)
+		require.ErrorContains(t, err, "delegator signature of")
+	})
+
+	t.Run("Send", func(t *testing.T) {
+		transactor := NewTransactor(c, origin.PrivateKey)
+		trx, err := transactor.Send(clause)
+		require.NoError(t, err)
+
+		pending, err := c.Transaction(ptr(trx.ID()), Pending())
+		require.NoError(t, err)
+		require.Equal(t, trx.ID(), pending.ID)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		transactor := NewTransactor(c, origin.PrivateKey, WithExpiration(1), WithPollInterval(10*time.Millisecond))
+		trx, err := transactor.Build(clause)
+		require.NoError(t, err)
+		trx, err = transactor.Sign(trx)
+		require.NoError(t, err)
+		require.NoError(t, thorChain.MintBlock(origin))
+		require.NoError(t, thorChain.MintBlock(origin))
+
+		_, err = transactor.Wait(context.Background(), trx)
+		require.ErrorIs(t, err, ErrTxExpired)
+	})
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,399 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package bind provides the runtime support of the contract bindings generated by thorgen.
+package bind
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/vechain/thor/v2/abi"
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/logdb"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/thorclient"
+	"github.com/vechain/thor/v2/thorclient/common"
+	"github.com/vechain/thor/v2/tx"
+)
+
+// FilterOpts holds the range, paging and order of event queries.
+type FilterOpts struct {
+	Range   *api.Range
+	Options *api.Options
+	Order   logdb.Order
+}
+
+// Contract is a contract deployed at an address, reached through a thorclient.
+type Contract struct {
+	client  *thorclient.Client
+	address thor.Address
+	abi     *abi.ABI
+	events  map[string]ethabi.Event
+}
+
+// NewContract creates a contract deployed at address with the given JSON ABI.
+func NewContract(client *thorclient.Client, address thor.Address, abiJSON string) (*Contract, error) {
+	contractABI, err := abi.New([]byte(abiJSON))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse abi - %w", err)
+	}
+	// the indexed inputs of events are not exposed by the abi package
+	ethABI, err := ethabi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse abi - %w", err)
+	}
+	return &Contract{
+		client:  client,
+		address: address,
+		abi:     contractABI,
+		events:  ethABI.Events,
+	}, nil
+}
+
+// Address returns the address of the contract.
+func (c *Contract) Address() thor.Address {
+	return c.address
+}
+
+// Client returns the client used to reach the contract.
+func (c *Contract) Client() *thorclient.Client {
+	return c.client
+}
+
+// Call calls a constant method and decodes its outputs into out, which holds a pointer per output.
+func (c *Contract) Call(method string, out []any, opts []thorclient.Option, args ...any) error {
+	clause, err := c.Clause(method, nil, args...)
+	if err != nil {
+		return err
+	}
+	results, err := c.client.InspectClauses(&api.BatchCallData{
+		Clauses: api.Clauses{{To: clause.To(), Data: hexutil.Encode(clause.Data())}},
+	}, opts...)
+	if err != nil {
+		return fmt.Errorf("unable to call %s - %w", method, err)
+	}
+	if len(results) != 1 {
+		return fmt.Errorf("unable to call %s - unexpected number of results", method)
+	}
+	if results[0].Reverted {
+		return fmt.Errorf("call to %s reverted - %s", method, results[0].VMError)
+	}
+	if len(out) == 0 {
+		return nil
+	}
+
+	data, err := hexutil.Decode(results[0].Data)
+	if err != nil {
+		return fmt.Errorf("unable to decode output of %s - %w", method, err)
+	}
+	m, _ := c.abi.MethodByName(method)
+	if len(out) == 1 {
+		err = m.DecodeOutput(data, out[0])
+	} else {
+		err = m.DecodeOutput(data, &out)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to decode output of %s - %w", method, err)
+	}
+	return nil
+}
+
+// Clause returns a clause calling the method with the given args, sending value VET to the contract.
+func (c *Contract) Clause(method string, value *big.Int, args ...any) (*tx.Clause, error) {
+	m, ok := c.abi.MethodByName(method)
+	if !ok {
+		return nil, fmt.Errorf("method %s not found", method)
+	}
+	data, err := m.EncodeInput(args...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode input of %s - %w", method, err)
+	}
+	clause := tx.NewClause(&c.address).WithData(data)
+	if value != nil {
+		clause = clause.WithValue(value)
+	}
+	return clause, nil
+}
+
+// DeployClause returns a clause deploying the contract bytecode, with the constructor args appended.
+func DeployClause(abiJSON, bytecode string, value *big.Int, args ...any) (*tx.Clause, error) {
+	contractABI, err := abi.New([]byte(abiJSON))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse abi - %w", err)
+	}
+	code, err := hexutil.Decode(bytecode)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode bytecode - %w", err)
+	}
+	if ctor := contractABI.Constructor(); ctor != nil {
+		// constructor args are encoded without method id
+		input, err := ctor.EncodeInput(args...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode constructor args - %w", err)
+		}
+		code = append(code, input[len(abi.MethodID{}):]...)
+	} else if len(args) > 0 {
+		return nil, errors.New("constructor args given, but the contract has no constructor")
+	}
+	clause := tx.NewClause(nil).WithData(code)
+	if value != nil {
+		clause = clause.WithValue(value)
+	}
+	return clause, nil
+}
+
+// EventCriteria returns the criteria set matching the event, with topics holding the accepted values of
+// each indexed input in order. An empty list of values matches any value.
+func (c *Contract) EventCriteria(event string, topics ...[]any) ([]*api.EventCriteria, error) {
+	ev, ok := c.events[event]
+	if !ok {
+		return nil, fmt.Errorf("event %s not found", event)
+	}
+
+	var indexed ethabi.Arguments
+	for _, input := range ev.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	if len(topics) > len(indexed) {
+		return nil, fmt.Errorf("event %s has %d indexed inputs, got %d", event, len(indexed), len(topics))
+	}
+
+	// the criteria set is the cartesian product of the accepted values
+	criteriaSet := []*api.EventCriteria{{Address: &c.address}}
+	if !ev.Anonymous {
+		id := thor.Bytes32(ev.Id())
+		criteriaSet[0].Topic0 = &id
+	}
+	for i, values := range topics {
+		if len(values) == 0 {
+			continue
+		}
+		index := i + 1
+		if ev.Anonymous {
+			index = i
+		}
+		product := make([]*api.EventCriteria, 0, len(criteriaSet)*len(values))
+		for _, v := range values {
+			topic, err := EncodeTopic(indexed[i].Type, v)
+			if err != nil {
+				return nil, fmt.Errorf("%s of %s - %w", indexed[i].Name, event, err)
+			}
+			for _, criteria := range criteriaSet {
+				cpy := *criteria
+				setTopic(&cpy.TopicSet, index, &topic)
+				product = append(product, &cpy)
+			}
+		}
+		criteriaSet = product
+	}
+	return criteriaSet, nil
+}
+
+// FilterEvents queries the event logs of the contract, see EventCriteria for topics.
+func (c *Contract) FilterEvents(event string, opts *FilterOpts, topics ...[]any) ([]api.FilteredEvent, error) {
+	criteriaSet, err := c.EventCriteria(event, topics...)
+	if err != nil {
+		return nil, err
+	}
+	filter := &api.EventFilter{CriteriaSet: criteriaSet}
+	if opts != nil {
+		filter.Range = opts.Range
+		filter.Options = opts.Options
+		filter.Order = opts.Order
+	}
+	return c.client.FilterEvents(filter)
+}
+
+// WatchEvents subscribes to the events of the contract from the block pos, see EventCriteria for topics.
+func (c *Contract) WatchEvents(event string, pos string, topics ...[]any) (*common.Subscription[*api.EventMessage], error) {
+	criteriaSet, err := c.EventCriteria(event, topics...)
+	if err != nil {
+		return nil, err
+	}
+	return c.client.SubscribeEvents(pos, &api.SubscriptionEventFilter{CriteriaSet: criteriaSet})
+}
+
+// UnpackEvent decodes the topics and data of an event into fields, which holds a pointer per input.
+// Indexed inputs of dynamic types are only available as their hash, and must be given as *thor.Bytes32.
+func (c *Contract) UnpackEvent(event string, topics []thor.Bytes32, data string, fields ...any) error {
+	ev, ok := c.events[event]
+	if !ok {
+		return fmt.Errorf("event %s not found", event)
+	}
+	if len(fields) != len(ev.Inputs) {
+		return fmt.Errorf("event %s has %d inputs, got %d fields", event, len(ev.Inputs), len(fields))
+	}
+	if !ev.Anonymous {
+		if len(topics) == 0 || topics[0] != thor.Bytes32(ev.Id()) {
+			return fmt.Errorf("not a %s event", event)
+		}
+		topics = topics[1:]
+	}
+
+	var nonIndexed []any
+	for i, input := range ev.Inputs {
+		if !input.Indexed {
+			nonIndexed = append(nonIndexed, fields[i])
+			continue
+		}
+		if len(topics) == 0 {
+			return fmt.Errorf("missing topic of %s", input.Name)
+		}
+		if err := decodeTopic(input.Type, topics[0], fields[i]); err != nil {
+			return fmt.Errorf("unable to decode %s of %s - %w", input.Name, event, err)
+		}
+		topics = topics[1:]
+	}
+
+	if len(nonIndexed) == 0 {
+		return nil
+	}
+	raw, err := hexutil.Decode(data)
+	if err != nil {
+		return fmt.Errorf("unable to decode data of %s - %w", event, err)
+	}
+	abiEvent, _ := c.abi.EventByName(event)
+	if len(nonIndexed) == 1 {
+		err = abiEvent.Decode(raw, nonIndexed[0])
+	} else {
+		err = abiEvent.Decode(raw, &nonIndexed)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to decode data of %s - %w", event, err)
+	}
+	return nil
+}
+
+// Watch converts a subscription of raw events into a subscription of events decoded by parse.
+// Unsubscribing stops the conversion, even if the events are no longer read.
+func Watch[T any](sub *common.Subscription[*api.EventMessage], parse func(*api.EventMessage) (T, error)) *common.Subscription[T] {
+	var (
+		events = make(chan common.EventWrapper[T])
+		done   = make(chan struct{})
+		once   sync.Once
+	)
+	go func() {
+		defer close(events)
+		for ev := range sub.EventChan {
+			var wrapped common.EventWrapper[T]
+			if ev.Error != nil {
+				wrapped.Error = ev.Error
+			} else {
+				wrapped.Data, wrapped.Error = parse(ev.Data)
+			}
+			select {
+			case events <- wrapped:
+			case <-done:
+				// drain the raw events until the closed subscription ends
+				for range sub.EventChan {
+				}
+				return
+			}
+		}
+	}()
+	return &common.Subscription[T]{
+		EventChan: events,
+		Unsubscribe: func() error {
+			once.Do(func() { close(done) })
+			return sub.Unsubscribe()
+		},
+	}
+}
+
+// Topics converts the accepted values of an indexed input for EventCriteria.
+func Topics[T any](values []T) []any {
+	list := make([]any, len(values))
+	for i, v := range values {
+		list[i] = v
+	}
+	return list
+}
+
+// FilteredTopics converts the topics of a filtered event for UnpackEvent.
+func FilteredTopics(topics []*thor.Bytes32) []thor.Bytes32 {
+	list := make([]thor.Bytes32, 0, len(topics))
+	for _, t := range topics {
+		if t != nil {
+			list = append(list, *t)
+		}
+	}
+	return list
+}
+
+// EncodeTopic encodes the value of an indexed input as a topic. Values of dynamic types are hashed,
+// or can be given as their hash.
+func EncodeTopic(typ ethabi.Type, v any) (thor.Bytes32, error) {
+	if isHashedTopic(typ) {
+		if hash, ok := v.(thor.Bytes32); ok {
+			return hash, nil
+		}
+	}
+	switch typ.T {
+	case ethabi.StringTy:
+		s, ok := v.(string)
+		if !ok {
+			return thor.Bytes32{}, fmt.Errorf("expected string, got %T", v)
+		}
+		return thor.Keccak256([]byte(s)), nil
+	case ethabi.BytesTy:
+		b, ok := v.([]byte)
+		if !ok {
+			return thor.Bytes32{}, fmt.Errorf("expected []byte, got %T", v)
+		}
+		return thor.Keccak256(b), nil
+	case ethabi.SliceTy, ethabi.ArrayTy:
+		return thor.Bytes32{}, fmt.Errorf("expected the hash of the %s value", typ)
+	}
+	data, err := ethabi.Arguments{{Type: typ}}.Pack(v)
+	if err != nil {
+		return thor.Bytes32{}, err
+	}
+	return thor.BytesToBytes32(data), nil
+}
+
+func decodeTopic(typ ethabi.Type, topic thor.Bytes32, v any) error {
+	if isHashedTopic(typ) {
+		hash, ok := v.(*thor.Bytes32)
+		if !ok {
+			return fmt.Errorf("expected *thor.Bytes32, got %T", v)
+		}
+		*hash = topic
+		return nil
+	}
+	// topics of static types are their abi encoding
+	return ethabi.Arguments{{Type: typ}}.Unpack(v, topic[:])
+}
+
+// isHashedTopic returns whether values of the type are logged as their hash when indexed.
+func isHashedTopic(typ ethabi.Type) bool {
+	switch typ.T {
+	case ethabi.StringTy, ethabi.BytesTy, ethabi.SliceTy, ethabi.ArrayTy:
+		return true
+	}
+	return false
+}
+
+func setTopic(set *api.TopicSet, index int, topic *thor.Bytes32) {
+	switch index {
+	case 0:
+		set.Topic0 = topic
+	case 1:
+		set.Topic1 = topic
+	case 2:
+		set.Topic2 = topic
+	case 3:
+		set.Topic3 = topic
+	case 4:
+		set.Topic4 = topic
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package bind
+
+import (
+	"math/big"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/api/accounts"
+	"github.com/vechain/thor/v2/api/events"
+	"github.com/vechain/thor/v2/builtin"
+	"github.com/vechain/thor/v2/builtin/gen"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/logdb"
+	"github.com/vechain/thor/v2/test/datagen"
+	"github.com/vechain/thor/v2/test/testchain"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/thorclient"
+	tccommon "github.com/vechain/thor/v2/thorclient/common"
+	"github.com/vechain/thor/v2/tx"
+)
+
+func newTestContract(t *testing.T, name string) (*testchain.Chain, *Contract) {
+	thorChain, err := testchain.NewDefault()
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	accounts.New(thorChain.Repo(), thorChain.Stater(), 10_000_000, thorChain.GetForkConfig(), thorChain.Engine(), true).
+		Mount(router, "/accounts")
+	events.New(thorChain.Repo(), thorChain.LogDB(), 1000, logdb.QueryBudget{}, thorChain.Engine()).
+		Mount(router, "/logs/event")
+	ts := httptest.NewServer(router)
+	t.Cleanup(ts.Close)
+
+	contract, err := NewContract(thorclient.New(ts.URL), thor.BytesToAddress([]byte(name)), string(gen.MustAsset("compiled/"+name+".abi")))
+	require.NoError(t, err)
+	return thorChain, contract
+}
+
+func TestContract_Call(t *testing.T) {
+	_, energy := newTestContract(t, "Energy")
+
+	var decimals uint8
+	require.NoError(t, energy.Call("decimals", []any{&decimals}, nil))
+	assert.Equal(t, uint8(18), decimals)
+
+	var balance *big.Int
+	require.NoError(t, energy.Call("balanceOf", []any{&balance}, nil, common.Address(genesis.DevAccounts()[0].Address)))
+	assert.Equal(t, 1, balance.Sign())
+
+	assert.Error(t, energy.Call("balanceOf", []any{&balance}, nil))
+	assert.Error(t, energy.Call("unknown", nil, nil))
+
+	_, authority := newTestContract(t, "Authority")
+	var first common.Address
+	require.NoError(t, authority.Call("first", []any{&first}, nil))
+
+	var (
+		listed, active bool
+		endorsor       common.Address
+		identity       [32]byte
+	)
+	require.NoError(t, authority.Call("get", []any{&listed, &endorsor, &identity, &active}, nil, first))
+	assert.True(t, listed)
+	assert.True(t, active)
+	assert.NotEqual(t, common.Address{}, endorsor)
+}
+
+func TestContract_Events(t *testing.T) {
+	thorChain, energy := newTestContract(t, "Energy")
+
+	from := genesis.DevAccounts()[0]
+	to := datagen.RandAddress()
+	clause, err := energy.Clause("transfer", nil, common.Address(to), big.NewInt(1000))
+	require.NoError(t, err)
+	assert.Equal(t, builtin.Energy.Address, *clause.To())
+	require.NoError(t, thorChain.MintClauses(from, []*tx.Clause{clause}))
+
+	list, err := energy.FilterEvents("Transfer", nil, Topics([]common.Address{common.Address(from.Address)}), Topics([]common.Address{common.Address(to)}))
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+
+	var (
+		evFrom, evTo common.Address
+		value        *big.Int
+	)
+	require.NoError(t, energy.UnpackEvent("Transfer", FilteredTopics(list[0].Topics), list[0].Data, &evFrom, &evTo, &value))
+	assert.Equal(t, common.Address(from.Address), evFrom)
+	assert.Equal(t, common.Address(to), evTo)
+	assert.Equal(t, big.NewInt(1000), value)
+
+	assert.Error(t, energy.UnpackEvent("Approval", FilteredTopics(list[0].Topics), list[0].Data, &evFrom, &evTo, &value))
+	assert.Error(t, energy.UnpackEvent("Transfer", FilteredTopics(list[0].Topics), list[0].Data, &evFrom, &evTo))
+
+	list, err = energy.FilterEvents("Transfer", nil, nil, Topics([]common.Address{common.Address(from.Address)}))
+	require.NoError(t, err)
+	assert.Empty(t, list)
+}
+
+func TestContract_EventCriteria(t *testing.T) {
+	_, energy := newTestContract(t, "Energy")
+	a, b, c := datagen.RandAddress(), datagen.RandAddress(), datagen.RandAddress()
+
+	criteriaSet, err := energy.EventCriteria("Approval")
+	require.NoError(t, err)
+	require.Len(t, criteriaSet, 1)
+	ev, _ := builtin.Energy.ABI.EventByName("Approval")
+	assert.Equal(t, &api.EventCriteria{
+		Address:  &builtin.Energy.Address,
+		TopicSet: api.TopicSet{Topic0: ptr(ev.ID())},
+	}, criteriaSet[0])
+
+	criteriaSet, err = energy.EventCriteria("Transfer", Topics([]thor.Address{a, b}), Topics([]thor.Address{c}))
+	require.NoError(t, err)
+	require.Len(t, criteriaSet, 2)
+	assert.Equal(t, ptr(thor.BytesToBytes32(a.Bytes())), criteriaSet[0].Topic1)
+	assert.Equal(t, ptr(thor.BytesToBytes32(b.Bytes())), criteriaSet[1].Topic1)
+	assert.Equal(t, ptr(thor.BytesToBytes32(c.Bytes())), criteriaSet[0].Topic2)
+	assert.Equal(t, ptr(thor.BytesToBytes32(c.Bytes())), criteriaSet[1].Topic2)
+
+	_, err = energy.EventCriteria("Transfer", nil, nil, nil)
+	assert.Error(t, err)
+	_, err = energy.EventCriteria("Transfer", []any{"not an address"})
+	assert.Error(t, err)
+	_, err = energy.EventCriteria("Unknown")
+	assert.Error(t, err)
+}
+
+func TestDeployClause(t *testing.T) {
+	abiJSON := `[{"type":"constructor","inputs":[{"name":"n","type":"uint256"}]}]`
+
+	clause, err := DeployClause(abiJSON, "0x6080", big.NewInt(1), big.NewInt(2))
+	require.NoError(t, err)
+	assert.Nil(t, clause.To())
+	assert.Equal(t, big.NewInt(1), clause.Value())
+	assert.Equal(t, append([]byte{0x60, 0x80}, common.LeftPadBytes([]byte{2}, 32)...), clause.Data())
+
+	_, err = DeployClause(`[]`, "0x6080", nil, big.NewInt(2))
+	assert.Error(t, err)
+	_, err = DeployClause(abiJSON, "0x6080", nil, "2")
+	assert.Error(t, err)
+}
+
+func TestWatch(t *testing.T) {
+	raw := make(chan tccommon.EventWrapper[*api.EventMessage], 3)
+	for range 3 {
+		raw <- tccommon.EventWrapper[*api.EventMessage]{Data: &api.EventMessage{}}
+	}
+	sub := Watch(&tccommon.Subscription[*api.EventMessage]{
+		EventChan:   raw,
+		Unsubscribe: func() error { return nil },
+	}, func(*api.EventMessage) (int, error) { return 1, nil })
+
+	ev := <-sub.EventChan
+	require.NoError(t, ev.Error)
+	assert.Equal(t, 1, ev.Data)
+
+	// the events not read are dropped once unsubscribed, the second one being held by the conversion
+	require.NoError(t, sub.Unsubscribe())
+	require.NoError(t, sub.Unsubscribe())
+	require.Eventually(t, func() bool { return len(raw) == 0 }, time.Second, time.Millisecond)
+	close(raw)
+	select {
+	case _, ok := <-sub.EventChan:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("subscription not closed")
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
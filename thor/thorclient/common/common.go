@@ -7,6 +7,7 @@ package common
 
 import (
 	"errors"
+	"fmt"
 )
 
 const (
@@ -20,6 +21,21 @@ var (
 	ErrUnexpectedMsg = errors.New("unexpected message format")
 )
 
+// StatusError is returned when the node responds with a non 2xx status code.
+// It wraps ErrNot200Status.
+type StatusError struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("http error - Status Code %d - %s - %v", e.StatusCode, e.Body, ErrNot200Status)
+}
+
+func (e *StatusError) Unwrap() error {
+	return ErrNot200Status
+}
+
 // EventWrapper is used to return errors from the websocket alongside the data
 type EventWrapper[T any] struct {
 	Data  T
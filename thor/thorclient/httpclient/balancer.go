@@ -0,0 +1,399 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package httpclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+// BalancerOptions configures the health checks and retries of a Balancer.
+type BalancerOptions struct {
+	HealthCheckInterval time.Duration // interval between health checks of the nodes, negative disables them
+	MaxBlockAge         time.Duration // nodes with an older best block are unhealthy
+	AdminURLs           []string      // optional admin server URL of each node, whose /admin/health must report healthy
+	Retries             int           // number of retries of idempotent requests
+	Backoff             time.Duration // delay before the first retry, doubled on each retry
+	DedupTTL            time.Duration // how long transaction submissions are remembered for deduplication
+}
+
+// DefaultBalancerOptions are the options used when none are given.
+var DefaultBalancerOptions = BalancerOptions{
+	HealthCheckInterval: 10 * time.Second,
+	MaxBlockAge:         time.Minute,
+	Retries:             2,
+	Backoff:             200 * time.Millisecond,
+	DedupTTL:            time.Minute,
+}
+
+type endpoint struct {
+	url   string
+	admin string
+
+	mu      sync.Mutex
+	healthy bool
+	best    uint32
+}
+
+func (e *endpoint) status() (bool, uint32) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.healthy, e.best
+}
+
+func (e *endpoint) setStatus(healthy bool, best uint32) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.healthy = healthy
+	if healthy {
+		e.best = best
+	}
+}
+
+func (e *endpoint) setHealthy(healthy bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.healthy = healthy
+}
+
+// submission is a transaction submission, shared by the submissions of the same transaction.
+type submission struct {
+	done    chan struct{}
+	status  int
+	header  http.Header
+	body    []byte
+	err     error
+	expires time.Time
+}
+
+// Balancer is an http.RoundTripper spreading requests across several nodes. Requests are built against
+// the URL of the first node, and routed to the healthy node with the highest best block.
+// Idempotent requests are retried with backoff on other nodes. Transaction submissions are never resent
+// once they may have reached a node, and the submissions of the same transaction are deduplicated.
+type Balancer struct {
+	endpoints []*endpoint
+	next      http.RoundTripper
+	opts      BalancerOptions
+
+	checking  atomic.Bool
+	lastCheck atomic.Int64 // unix nano
+
+	mu          sync.Mutex
+	submissions map[thor.Bytes32]*submission
+}
+
+// NewBalancer creates a balancer across the nodes at the given URLs, using http.DefaultTransport.
+// The default options are used if opts is nil.
+func NewBalancer(urls []string, opts *BalancerOptions) *Balancer {
+	if opts == nil {
+		opts = &DefaultBalancerOptions
+	}
+	b := &Balancer{
+		next:        http.DefaultTransport,
+		opts:        *opts,
+		submissions: make(map[thor.Bytes32]*submission),
+	}
+	for i, u := range urls {
+		ep := &endpoint{url: strings.TrimRight(u, "/"), healthy: true}
+		if i < len(opts.AdminURLs) {
+			ep.admin = strings.TrimRight(opts.AdminURLs[i], "/")
+		}
+		b.endpoints = append(b.endpoints, ep)
+	}
+	return b
+}
+
+// URL returns the URL requests must be built against.
+func (b *Balancer) URL() string {
+	return b.endpoints[0].url
+}
+
+// RoundTrip implements http.RoundTripper.
+func (b *Balancer) RoundTrip(req *http.Request) (*http.Response, error) {
+	b.maybeCheckHealth()
+
+	path, ok := strings.CutPrefix(req.URL.String(), b.URL())
+	if !ok {
+		return b.next.RoundTrip(req)
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+	}
+
+	if req.Method == http.MethodPost && strings.TrimRight(req.URL.Path, "/") == "/transactions" {
+		return b.submit(req, path, body)
+	}
+	return b.forward(req, path, body, req.Method == http.MethodGet || req.Method == http.MethodHead)
+}
+
+// forward sends the request to the best node. Idempotent requests are retried on failures, others
+// only when the node could not be reached.
+func (b *Balancer) forward(req *http.Request, path string, body []byte, idempotent bool) (*http.Response, error) {
+	candidates := b.candidates()
+	attempts := len(candidates)
+	if idempotent {
+		attempts = max(attempts, b.opts.Retries+1)
+	}
+
+	var (
+		res *http.Response
+		err error
+	)
+	for i := range attempts {
+		if i > 0 && idempotent {
+			if err := sleep(req, b.opts.Backoff<<(i-1)); err != nil {
+				return nil, err
+			}
+		}
+		ep := candidates[i%len(candidates)]
+		res, err = b.send(req, ep, path, body)
+		if err != nil {
+			ep.setHealthy(false)
+			if !idempotent && !isDialError(err) {
+				return nil, err
+			}
+			continue
+		}
+		if idempotent && isUnavailable(res.StatusCode) && i < attempts-1 {
+			ep.setHealthy(false)
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+			continue
+		}
+		return res, nil
+	}
+	return res, err
+}
+
+// submit forwards a transaction submission, unless the same transaction was already submitted.
+func (b *Balancer) submit(req *http.Request, path string, body []byte) (*http.Response, error) {
+	var raw api.RawTx
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return b.forward(req, path, body, false)
+	}
+	data, err := hexutil.Decode(raw.Raw)
+	if err != nil {
+		return b.forward(req, path, body, false)
+	}
+	var trx tx.Transaction
+	if err := trx.UnmarshalBinary(data); err != nil {
+		return b.forward(req, path, body, false)
+	}
+	id := trx.ID()
+
+	b.mu.Lock()
+	now := time.Now()
+	for k, s := range b.submissions {
+		if !s.expires.IsZero() && now.After(s.expires) {
+			delete(b.submissions, k)
+		}
+	}
+	s, ok := b.submissions[id]
+	if !ok {
+		s = &submission{done: make(chan struct{})}
+		b.submissions[id] = s
+	}
+	b.mu.Unlock()
+
+	if ok {
+		select {
+		case <-s.done:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		return s.response(req)
+	}
+
+	res, err := b.forward(req, path, body, false)
+	if err == nil {
+		s.status, s.header = res.StatusCode, res.Header
+		s.body, s.err = io.ReadAll(res.Body)
+		res.Body.Close()
+	} else {
+		s.err = err
+	}
+
+	b.mu.Lock()
+	if s.err != nil || s.status != http.StatusOK {
+		// failed submissions are not remembered, so that they can be sent again
+		delete(b.submissions, id)
+	}
+	s.expires = time.Now().Add(b.opts.DedupTTL)
+	b.mu.Unlock()
+	close(s.done)
+
+	return s.response(req)
+}
+
+func (s *submission) response(req *http.Request) (*http.Response, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &http.Response{
+		Status:        http.StatusText(s.status),
+		StatusCode:    s.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        s.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(s.body)),
+		ContentLength: int64(len(s.body)),
+		Request:       req,
+	}, nil
+}
+
+func (b *Balancer) send(req *http.Request, ep *endpoint, path string, body []byte) (*http.Response, error) {
+	target, err := url.Parse(ep.url + path)
+	if err != nil {
+		return nil, err
+	}
+	out := req.Clone(req.Context())
+	out.URL = target
+	out.Host = target.Host
+	if body != nil {
+		out.Body = io.NopCloser(bytes.NewReader(body))
+		out.ContentLength = int64(len(body))
+	}
+
+	res, err := b.next.RoundTrip(out)
+	if err == nil && !isUnavailable(res.StatusCode) {
+		ep.setHealthy(true)
+	}
+	return res, err
+}
+
+// candidates returns the nodes in order of preference: healthy ones first, then by best block.
+func (b *Balancer) candidates() []*endpoint {
+	type ranked struct {
+		ep      *endpoint
+		healthy bool
+		best    uint32
+	}
+	list := make([]ranked, len(b.endpoints))
+	for i, ep := range b.endpoints {
+		healthy, best := ep.status()
+		list[i] = ranked{ep, healthy, best}
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].healthy != list[j].healthy {
+			return list[i].healthy
+		}
+		return list[i].best > list[j].best
+	})
+
+	eps := make([]*endpoint, len(list))
+	for i, r := range list {
+		eps[i] = r.ep
+	}
+	return eps
+}
+
+// maybeCheckHealth starts a health check of all nodes in the background, if the last one is stale.
+func (b *Balancer) maybeCheckHealth() {
+	if b.opts.HealthCheckInterval < 0 || len(b.endpoints) < 2 {
+		return
+	}
+	if time.Since(time.Unix(0, b.lastCheck.Load())) < b.opts.HealthCheckInterval {
+		return
+	}
+	if !b.checking.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		defer b.checking.Store(false)
+		b.CheckHealth()
+	}()
+}
+
+// CheckHealth checks the health and the best block of all nodes.
+func (b *Balancer) CheckHealth() {
+	client := &http.Client{Transport: b.next, Timeout: 5 * time.Second}
+
+	var wg sync.WaitGroup
+	for _, ep := range b.endpoints {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			best, err := checkEndpoint(client, ep, b.opts.MaxBlockAge)
+			ep.setStatus(err == nil, best)
+		}()
+	}
+	wg.Wait()
+	b.lastCheck.Store(time.Now().UnixNano())
+}
+
+func checkEndpoint(client *http.Client, ep *endpoint, maxBlockAge time.Duration) (uint32, error) {
+	if ep.admin != "" {
+		res, err := client.Get(ep.admin + "/admin/health")
+		if err != nil {
+			return 0, err
+		}
+		io.Copy(io.Discard, res.Body)
+		res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			return 0, errors.New("node is unhealthy")
+		}
+	}
+
+	res, err := client.Get(ep.url + "/blocks/best")
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return 0, errors.New("best block unavailable")
+	}
+	var best api.JSONBlockSummary
+	if err := json.NewDecoder(res.Body).Decode(&best); err != nil {
+		return 0, err
+	}
+	if maxBlockAge > 0 && time.Since(time.Unix(int64(best.Timestamp), 0)) > maxBlockAge {
+		return 0, errors.New("best block is stale")
+	}
+	return best.Number, nil
+}
+
+func isUnavailable(status int) bool {
+	return status == http.StatusBadGateway || status == http.StatusServiceUnavailable || status == http.StatusGatewayTimeout
+}
+
+// isDialError returns whether the request failed before reaching the node.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+func sleep(req *http.Request, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-req.Context().Done():
+		return req.Context().Err()
+	}
+}
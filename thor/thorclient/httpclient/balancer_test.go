@@ -0,0 +1,196 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package httpclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/tx"
+)
+
+type testNode struct {
+	*httptest.Server
+	best     atomic.Uint32
+	age      atomic.Int64 // seconds
+	status   atomic.Int32 // status of non health check requests, 0 means 200
+	requests atomic.Int32 // non health check requests
+	healthy  atomic.Bool  // admin health
+}
+
+func newTestNode(t *testing.T, best uint32) *testNode {
+	n := &testNode{}
+	n.best.Store(best)
+	n.healthy.Store(true)
+	n.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/blocks/best":
+			json.NewEncoder(w).Encode(&api.JSONBlockSummary{
+				Number:    n.best.Load(),
+				Timestamp: uint64(time.Now().Unix() - n.age.Load()),
+			})
+			return
+		case "/admin/health":
+			if !n.healthy.Load() {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+			return
+		}
+		n.requests.Add(1)
+		if status := n.status.Load(); status != 0 {
+			w.WriteHeader(int(status))
+			return
+		}
+		if r.URL.Path == "/transactions" {
+			var raw api.RawTx
+			json.NewDecoder(r.Body).Decode(&raw)
+			data, _ := hexutil.Decode(raw.Raw)
+			var trx tx.Transaction
+			trx.UnmarshalBinary(data)
+			id := trx.ID()
+			json.NewEncoder(w).Encode(&api.SendTxResult{ID: &id})
+			return
+		}
+		json.NewEncoder(w).Encode(&api.JSONCollapsedBlock{JSONBlockSummary: &api.JSONBlockSummary{Number: n.best.Load()}})
+	}))
+	t.Cleanup(n.Close)
+	return n
+}
+
+func newBalancedClient(opts BalancerOptions, nodes ...*testNode) (*Client, *Balancer) {
+	urls := make([]string, len(nodes))
+	for i, n := range nodes {
+		urls[i] = n.URL
+	}
+	b := NewBalancer(urls, &opts)
+	return NewWithHTTP(b.URL(), &http.Client{Transport: b}), b
+}
+
+var testBalancerOptions = BalancerOptions{
+	HealthCheckInterval: -1,
+	MaxBlockAge:         time.Minute,
+	Retries:             2,
+	Backoff:             time.Millisecond,
+	DedupTTL:            time.Minute,
+}
+
+func TestBalancer_Routing(t *testing.T) {
+	n1, n2, n3 := newTestNode(t, 10), newTestNode(t, 20), newTestNode(t, 30)
+	n3.age.Store(3600)
+	c, b := newBalancedClient(testBalancerOptions, n1, n2, n3)
+
+	// no health check yet, the first node is used
+	blk, err := c.GetBlock("1")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(10), blk.Number)
+
+	// the node with the highest fresh best block is used
+	b.CheckHealth()
+	blk, err = c.GetBlock("1")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(20), blk.Number)
+
+	// unhealthy nodes are skipped
+	n2.best.Store(5)
+	b.opts.AdminURLs = nil
+	b.endpoints[0].admin = n1.URL
+	n1.healthy.Store(false)
+	b.CheckHealth()
+	blk, err = c.GetBlock("1")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(5), blk.Number)
+}
+
+func TestBalancer_Retry(t *testing.T) {
+	n1, n2 := newTestNode(t, 10), newTestNode(t, 20)
+	c, _ := newBalancedClient(testBalancerOptions, n1, n2)
+
+	// unavailable node
+	n1.status.Store(http.StatusServiceUnavailable)
+	blk, err := c.GetBlock("1")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(20), blk.Number)
+	assert.Equal(t, int32(1), n1.requests.Load())
+
+	// unreachable node
+	n1.status.Store(0)
+	n2.Close()
+	blk, err = c.GetBlock("1")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(10), blk.Number)
+
+	// all nodes unavailable, the last response is returned
+	n1.status.Store(http.StatusServiceUnavailable)
+	n1.requests.Store(0)
+	_, err = c.GetBlock("1")
+	assert.Error(t, err)
+	assert.Equal(t, int32(2), n1.requests.Load())
+}
+
+func TestBalancer_Submission(t *testing.T) {
+	n1, n2 := newTestNode(t, 10), newTestNode(t, 10)
+	c, _ := newBalancedClient(testBalancerOptions, n1, n2)
+
+	trx := tx.MustSign(tx.NewBuilder(tx.TypeLegacy).Gas(21000).Nonce(1).Build(), genesis.DevAccounts()[0].PrivateKey)
+	data, err := trx.MarshalBinary()
+	require.NoError(t, err)
+	raw := &api.RawTx{Raw: hexutil.Encode(data)}
+
+	// concurrent and repeated submissions of the same transaction are sent once
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := c.SendTransaction(raw)
+			assert.NoError(t, err)
+			assert.Equal(t, trx.ID(), *res.ID)
+		}()
+	}
+	wg.Wait()
+	_, err = c.SendTransaction(raw)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), n1.requests.Load()+n2.requests.Load())
+
+	// failed submissions are not resent on other nodes, nor remembered
+	trx = tx.MustSign(tx.NewBuilder(tx.TypeLegacy).Gas(21000).Nonce(2).Build(), genesis.DevAccounts()[0].PrivateKey)
+	data, err = trx.MarshalBinary()
+	require.NoError(t, err)
+	raw = &api.RawTx{Raw: hexutil.Encode(data)}
+
+	n1.requests.Store(0)
+	n1.status.Store(http.StatusServiceUnavailable)
+	_, err = c.SendTransaction(raw)
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), n1.requests.Load())
+	assert.Equal(t, int32(0), n2.requests.Load())
+
+	n1.status.Store(0)
+	res, err := c.SendTransaction(raw)
+	require.NoError(t, err)
+	assert.Equal(t, trx.ID(), *res.ID)
+	assert.Equal(t, int32(2), n1.requests.Load())
+
+	// unreachable nodes are skipped
+	n1.Close()
+	trx = tx.MustSign(tx.NewBuilder(tx.TypeLegacy).Gas(21000).Nonce(3).Build(), genesis.DevAccounts()[0].PrivateKey)
+	data, err = trx.MarshalBinary()
+	require.NoError(t, err)
+	res, err = c.SendTransaction(&api.RawTx{Raw: hexutil.Encode(data)})
+	require.NoError(t, err)
+	assert.Equal(t, trx.ID(), *res.ID)
+	assert.Equal(t, int32(1), n2.requests.Load())
+}
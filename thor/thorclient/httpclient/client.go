@@ -10,6 +10,7 @@ package httpclient
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -245,32 +246,79 @@ func (c *Client) GetExpandedBlock(revision string) (*api.JSONExpandedBlock, erro
 
 // FilterEvents filters events based on the provided event filter.
 func (c *Client) FilterEvents(req *api.EventFilter) ([]api.FilteredEvent, error) {
-	body, err := c.httpPOST(c.url+"/logs/event", req)
+	return c.FilterEventsContext(context.Background(), req)
+}
+
+// FilterEventsContext is like FilterEvents, with a context for the request.
+func (c *Client) FilterEventsContext(ctx context.Context, req *api.EventFilter) ([]api.FilteredEvent, error) {
+	events, _, err := c.FilterEventsDowngradable(ctx, req)
+	return events, err
+}
+
+// FilterEventsDowngradable is like FilterEventsContext, and also returns the block range the node narrowed
+// the query to when it runs with --api-logs-downgrade, or nil if the range was kept.
+func (c *Client) FilterEventsDowngradable(ctx context.Context, req *api.EventFilter) ([]api.FilteredEvent, *api.Range, error) {
+	body, header, err := c.httpPOSTResponseContext(ctx, c.url+"/logs/event", req)
 	if err != nil {
-		return nil, fmt.Errorf("unable to filter events - %w", err)
+		return nil, nil, fmt.Errorf("unable to filter events - %w", err)
+	}
+	downgraded, err := parseDowngradedRange(header)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	var filteredEvents []api.FilteredEvent
 	if err = json.Unmarshal(body, &filteredEvents); err != nil {
-		return nil, fmt.Errorf("unable to unmarshal events - %w", err)
+		return nil, nil, fmt.Errorf("unable to unmarshal events - %w", err)
 	}
 
-	return filteredEvents, nil
+	return filteredEvents, downgraded, nil
 }
 
 // FilterTransfers filters transfer based on the provided transfer filter.
 func (c *Client) FilterTransfers(req *api.TransferFilter) ([]*api.FilteredTransfer, error) {
-	body, err := c.httpPOST(c.url+"/logs/transfer", req)
+	return c.FilterTransfersContext(context.Background(), req)
+}
+
+// FilterTransfersContext is like FilterTransfers, with a context for the request.
+func (c *Client) FilterTransfersContext(ctx context.Context, req *api.TransferFilter) ([]*api.FilteredTransfer, error) {
+	transfers, _, err := c.FilterTransfersDowngradable(ctx, req)
+	return transfers, err
+}
+
+// FilterTransfersDowngradable is like FilterTransfersContext, and also returns the block range the node
+// narrowed the query to, see FilterEventsDowngradable.
+func (c *Client) FilterTransfersDowngradable(ctx context.Context, req *api.TransferFilter) ([]*api.FilteredTransfer, *api.Range, error) {
+	body, header, err := c.httpPOSTResponseContext(ctx, c.url+"/logs/transfer", req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to retrieve transfer logs - %w", err)
+	}
+	downgraded, err := parseDowngradedRange(header)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve transfer logs - %w", err)
+		return nil, nil, err
 	}
 
 	var filteredTransfers []*api.FilteredTransfer
 	if err = json.Unmarshal(body, &filteredTransfers); err != nil {
-		return nil, fmt.Errorf("unable to unmarshal transfers - %w", err)
+		return nil, nil, fmt.Errorf("unable to unmarshal transfers - %w", err)
 	}
 
-	return filteredTransfers, nil
+	return filteredTransfers, downgraded, nil
+}
+
+// parseDowngradedRange returns the block range of the api.LogsDowngradedRangeHeader, or nil if not set.
+func parseDowngradedRange(header http.Header) (*api.Range, error) {
+	value := header.Get(api.LogsDowngradedRangeHeader)
+	if value == "" {
+		return nil, nil
+	}
+	fromStr, toStr, ok := strings.Cut(value, "-")
+	from, err1 := strconv.ParseUint(fromStr, 10, 64)
+	to, err2 := strconv.ParseUint(toStr, 10, 64)
+	if !ok || err1 != nil || err2 != nil || to < from {
+		return nil, fmt.Errorf("invalid %s header %q", api.LogsDowngradedRangeHeader, value)
+	}
+	return &api.Range{Unit: api.BlockRangeType, From: &from, To: &to}, nil
 }
 
 // GetPeers retrieves the network peers connected to the node.
@@ -348,3 +396,8 @@ func (c *Client) RawHTTPPost(url string, calldata any) ([]byte, int, error) {
 func (c *Client) RawHTTPGet(url string) ([]byte, int, error) {
 	return c.rawHTTPRequest("GET", c.url+url, nil)
 }
+
+// RawHTTPDelete sends a raw HTTP DELETE request to the specified URL.
+func (c *Client) RawHTTPDelete(url string) ([]byte, int, error) {
+	return c.rawHTTPRequest("DELETE", c.url+url, nil)
+}
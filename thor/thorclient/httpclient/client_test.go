@@ -6,6 +6,7 @@
 package httpclient
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/big"
@@ -146,6 +147,39 @@ func TestClient_FilterEvents(t *testing.T) {
 	assert.Equal(t, expectedEvents, events)
 }
 
+func TestClient_FilterEventsDowngradable(t *testing.T) {
+	header := ""
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if header != "" {
+			w.Header().Set(api.LogsDowngradedRangeHeader, header)
+		}
+		w.Write([]byte("[]"))
+	}))
+	defer ts.Close()
+	client := New(ts.URL)
+
+	_, rng, err := client.FilterEventsDowngradable(context.Background(), &api.EventFilter{})
+	require.NoError(t, err)
+	assert.Nil(t, rng)
+
+	header = "10-25"
+	_, rng, err = client.FilterEventsDowngradable(context.Background(), &api.EventFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, api.BlockRangeType, rng.Unit)
+	assert.Equal(t, uint64(10), *rng.From)
+	assert.Equal(t, uint64(25), *rng.To)
+
+	_, rng, err = client.FilterTransfersDowngradable(context.Background(), &api.TransferFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(25), *rng.To)
+
+	for _, invalid := range []string{"10", "a-b", "25-10"} {
+		header = invalid
+		_, _, err = client.FilterEventsDowngradable(context.Background(), &api.EventFilter{})
+		assert.ErrorContains(t, err, api.LogsDowngradedRangeHeader)
+	}
+}
+
 func TestClient_GetAccount(t *testing.T) {
 	addr := thor.Address{0x01}
 	expectedAccount := &api.Account{
@@ -451,6 +485,25 @@ func TestClient_RawHTTPGet(t *testing.T) {
 	assert.Equal(t, http.StatusOK, statusCode)
 }
 
+func TestClient_RawHTTPDelete(t *testing.T) {
+	url := "/test"
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, url, r.URL.Path)
+		assert.Equal(t, http.MethodDelete, r.Method)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client := New(ts.URL)
+	response, statusCode, err := client.RawHTTPDelete(url)
+
+	assert.NoError(t, err)
+	assert.Empty(t, response)
+	assert.Equal(t, http.StatusNoContent, statusCode)
+}
+
 func TestClient_GetPeers(t *testing.T) {
 	expectedPeers := []*api.PeerStats{{
 		Name:        "nodeA",
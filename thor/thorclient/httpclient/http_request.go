@@ -7,6 +7,7 @@ package httpclient
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -16,14 +17,24 @@ import (
 )
 
 func (c *Client) httpRequest(method, url string, payload io.Reader) ([]byte, error) {
-	body, statusCode, err := c.rawHTTPRequest(method, url, payload)
+	return c.httpRequestContext(context.Background(), method, url, payload)
+}
+
+func (c *Client) httpRequestContext(ctx context.Context, method, url string, payload io.Reader) ([]byte, error) {
+	body, _, err := c.httpResponseContext(ctx, method, url, payload)
+	return body, err
+}
+
+// httpResponseContext is like httpRequestContext, and also returns the response headers.
+func (c *Client) httpResponseContext(ctx context.Context, method, url string, payload io.Reader) ([]byte, http.Header, error) {
+	body, statusCode, header, err := c.rawHTTPResponseContext(ctx, method, url, payload)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if !statusCodeIs2xx(statusCode) {
-		return nil, fmt.Errorf("http error - Status Code %d - %s - %w", statusCode, body, common.ErrNot200Status)
+		return nil, nil, &common.StatusError{StatusCode: statusCode, Body: body}
 	}
-	return body, nil
+	return body, header, nil
 }
 
 func statusCodeIs2xx(statusCode int) bool {
@@ -31,9 +42,18 @@ func statusCodeIs2xx(statusCode int) bool {
 }
 
 func (c *Client) rawHTTPRequest(method, url string, payload io.Reader) ([]byte, int, error) {
-	req, err := http.NewRequest(method, url, payload)
+	return c.rawHTTPRequestContext(context.Background(), method, url, payload)
+}
+
+func (c *Client) rawHTTPRequestContext(ctx context.Context, method, url string, payload io.Reader) ([]byte, int, error) {
+	body, statusCode, _, err := c.rawHTTPResponseContext(ctx, method, url, payload)
+	return body, statusCode, err
+}
+
+func (c *Client) rawHTTPResponseContext(ctx context.Context, method, url string, payload io.Reader) ([]byte, int, http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, payload)
 	if err != nil {
-		return nil, 0, fmt.Errorf("error creating request: %w", err)
+		return nil, 0, nil, fmt.Errorf("error creating request: %w", err)
 	}
 
 	if method == "POST" {
@@ -42,16 +62,16 @@ func (c *Client) rawHTTPRequest(method, url string, payload io.Reader) ([]byte,
 
 	resp, err := c.c.Do(req)
 	if err != nil {
-		return nil, 0, fmt.Errorf("error performing request: %w", err)
+		return nil, 0, nil, fmt.Errorf("error performing request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	responseBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, 0, fmt.Errorf("error reading response body: %w", err)
+		return nil, 0, nil, fmt.Errorf("error reading response body: %w", err)
 	}
 
-	return responseBody, resp.StatusCode, nil
+	return responseBody, resp.StatusCode, resp.Header, nil
 }
 
 func (c *Client) httpGET(url string) ([]byte, error) {
@@ -59,6 +79,16 @@ func (c *Client) httpGET(url string) ([]byte, error) {
 }
 
 func (c *Client) httpPOST(url string, payload any) ([]byte, error) {
+	return c.httpPOSTContext(context.Background(), url, payload)
+}
+
+func (c *Client) httpPOSTContext(ctx context.Context, url string, payload any) ([]byte, error) {
+	body, _, err := c.httpPOSTResponseContext(ctx, url, payload)
+	return body, err
+}
+
+// httpPOSTResponseContext is like httpPOSTContext, and also returns the response headers.
+func (c *Client) httpPOSTResponseContext(ctx context.Context, url string, payload any) ([]byte, http.Header, error) {
 	var data []byte
 
 	if _, ok := payload.([]byte); ok {
@@ -67,9 +97,9 @@ func (c *Client) httpPOST(url string, payload any) ([]byte, error) {
 		var err error
 		data, err = json.Marshal(payload)
 		if err != nil {
-			return nil, fmt.Errorf("unable to marshal payload - %w", err)
+			return nil, nil, fmt.Errorf("unable to marshal payload - %w", err)
 		}
 	}
 
-	return c.httpRequest("POST", url, bytes.NewBuffer(data))
+	return c.httpResponseContext(ctx, "POST", url, bytes.NewBuffer(data))
 }
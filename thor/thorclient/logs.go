@@ -0,0 +1,267 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package thorclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/logdb"
+	"github.com/vechain/thor/v2/thorclient/common"
+)
+
+// IterOptions configures the log iterators.
+type IterOptions struct {
+	ChunkSize uint64 // number of blocks queried at once, halved when the node rejects a query as too costly, or narrowed as the node downgrades it
+	PageSize  uint64 // number of logs per page, lowered to the limit of the node when it is exceeded
+}
+
+// DefaultIterOptions are the options used when none are given.
+var DefaultIterOptions = IterOptions{
+	ChunkSize: 10_000,
+	PageSize:  1000,
+}
+
+// IterateEvents returns an iterator over the events matching the filter, across a block range of any size.
+//
+// The range is queried in chunks of blocks, and each chunk is paged through, so the Offset and Limit of the
+// filter options are ignored. Only block ranges are supported; the range defaults to the whole chain up to
+// the best block at the time of the first query. The iteration stops at the first error, which is yielded.
+func (c *Client) IterateEvents(ctx context.Context, filter *api.EventFilter, opts *IterOptions) iter.Seq2[api.FilteredEvent, error] {
+	if filter == nil {
+		filter = &api.EventFilter{}
+	}
+	return iterateLogs(ctx, c, filter.Range, filter.Order, filter.Options, opts,
+		func(ctx context.Context, rng *api.Range, options *api.Options) ([]api.FilteredEvent, *api.Range, error) {
+			return c.httpConn.FilterEventsDowngradable(ctx, &api.EventFilter{
+				CriteriaSet: filter.CriteriaSet,
+				Range:       rng,
+				Options:     options,
+				Order:       filter.Order,
+			})
+		},
+		func(ev api.FilteredEvent) uint32 { return ev.Meta.BlockNumber },
+	)
+}
+
+// IterateTransfers returns an iterator over the transfers matching the filter, across a block range of any size.
+// See IterateEvents.
+func (c *Client) IterateTransfers(ctx context.Context, filter *api.TransferFilter, opts *IterOptions) iter.Seq2[*api.FilteredTransfer, error] {
+	if filter == nil {
+		filter = &api.TransferFilter{}
+	}
+	return iterateLogs(ctx, c, filter.Range, filter.Order, filter.Options, opts,
+		func(ctx context.Context, rng *api.Range, options *api.Options) ([]*api.FilteredTransfer, *api.Range, error) {
+			return c.httpConn.FilterTransfersDowngradable(ctx, &api.TransferFilter{
+				CriteriaSet: filter.CriteriaSet,
+				Range:       rng,
+				Options:     options,
+				Order:       filter.Order,
+			})
+		},
+		func(tr *api.FilteredTransfer) uint32 { return tr.Meta.BlockNumber },
+	)
+}
+
+// logsQuery queries a page of logs, and returns the range the node narrowed the query to, if any.
+type logsQuery[T any] func(ctx context.Context, rng *api.Range, options *api.Options) ([]T, *api.Range, error)
+
+func iterateLogs[T any](
+	ctx context.Context,
+	c *Client,
+	rng *api.Range,
+	order logdb.Order,
+	filterOpts *api.Options,
+	opts *IterOptions,
+	query logsQuery[T],
+	blockOf func(T) uint32,
+) iter.Seq2[T, error] {
+	if opts == nil {
+		opts = &DefaultIterOptions
+	}
+	includeIndexes := filterOpts != nil && filterOpts.IncludeIndexes
+	desc := order == logdb.DESC
+
+	return func(yield func(T, error) bool) {
+		var zero T
+		lo, hi, err := c.resolveRange(rng)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		chunk, page := max(opts.ChunkSize, 1), max(opts.PageSize, 1)
+
+		// the block of the last yielded log and the number of yielded logs of that block,
+		// to skip them when a chunk is queried again from that block
+		var (
+			last  uint32
+			count int
+			skip  int
+		)
+
+		for lo <= hi {
+			from, to := lo, min(hi, lo+chunk-1)
+			if desc {
+				from, to = max(lo, hi-min(hi, chunk-1)), hi
+			}
+
+			var (
+				offset   uint64
+				restart  bool
+				chunkLen int
+			)
+			// requery prepares the chunk to be queried again, resumed from the block of the last yielded log
+			requery := func() {
+				if chunkLen > 0 {
+					if desc {
+						hi = uint64(last)
+					} else {
+						lo = uint64(last)
+					}
+					skip, count = count, 0
+				} else {
+					count = 0
+				}
+				restart = true
+			}
+			for {
+				if err := ctx.Err(); err != nil {
+					yield(zero, err)
+					return
+				}
+				logs, downgraded, err := query(ctx,
+					&api.Range{Unit: api.BlockRangeType, From: &from, To: &to},
+					&api.Options{Offset: offset, Limit: page, IncludeIndexes: includeIndexes},
+				)
+				if err != nil {
+					if limit, ok := isLimitExceeded(err); ok && limit < page && limit > 0 {
+						page = limit
+						continue
+					}
+					if isTooCostly(err) && chunk > 1 {
+						chunk /= 2
+						requery()
+						break
+					}
+					yield(zero, err)
+					return
+				}
+				if downgraded != nil && (*downgraded.From != from || *downgraded.To != to) {
+					// the node narrowed the chunk to fit its budget, keep within the narrowed range from now on
+					nfrom, nto := *downgraded.From, *downgraded.To
+					if nfrom < from || nto > to {
+						yield(zero, fmt.Errorf("downgraded range %d-%d is out of the queried range %d-%d", nfrom, nto, from, to))
+						return
+					}
+					chunk = nto - nfrom + 1
+					if offset > 0 {
+						// the previous pages were of the wider range, and may span past the narrowed one
+						requery()
+						break
+					}
+					from, to = nfrom, nto
+				}
+
+				for _, log := range logs {
+					if b := blockOf(log); b == last && (count > 0 || skip > 0) {
+						count++
+						if count <= skip {
+							continue
+						}
+					} else {
+						last, count, skip = b, 1, 0
+					}
+					chunkLen++
+					if !yield(log, nil) {
+						return
+					}
+				}
+				if uint64(len(logs)) < page {
+					break
+				}
+				offset += uint64(len(logs))
+			}
+			if restart {
+				continue
+			}
+
+			if desc {
+				if from == 0 {
+					return
+				}
+				hi = from - 1
+			} else {
+				lo = to + 1
+			}
+		}
+	}
+}
+
+// resolveRange returns the bounds of a block range, defaulting to the whole chain.
+func (c *Client) resolveRange(rng *api.Range) (uint64, uint64, error) {
+	var from, to uint64
+	if rng != nil {
+		if rng.Unit != "" && rng.Unit != api.BlockRangeType {
+			return 0, 0, errors.New("only block ranges can be iterated")
+		}
+		if rng.From != nil {
+			from = *rng.From
+		}
+		if rng.To != nil {
+			to = *rng.To
+		}
+	}
+	if rng == nil || rng.To == nil {
+		best, err := c.Block(common.BestRevision)
+		if err != nil {
+			return 0, 0, err
+		}
+		to = uint64(best.Number)
+	}
+	if to < from {
+		return 0, 0, errors.New("range.to must be greater than or equal to range.from")
+	}
+	return from, to, nil
+}
+
+var limitExceededRe = regexp.MustCompile(`options\.limit exceeds the maximum allowed value of (\d+)`)
+
+// isLimitExceeded returns whether the node rejected the page size, and its limit.
+func isLimitExceeded(err error) (uint64, bool) {
+	var statusErr *common.StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusForbidden {
+		return 0, false
+	}
+	m := limitExceededRe.FindSubmatch(statusErr.Body)
+	if m == nil {
+		return 0, false
+	}
+	limit, err := strconv.ParseUint(string(m[1]), 10, 64)
+	return limit, err == nil
+}
+
+// isTooCostly returns whether the node rejected a query as too costly or timed out, so that a smaller
+// block range may succeed.
+func isTooCostly(err error) bool {
+	var statusErr *common.StatusError
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+	switch statusErr.StatusCode {
+	case http.StatusServiceUnavailable:
+		return true
+	case http.StatusForbidden:
+		return strings.Contains(string(statusErr.Body), "query cost")
+	}
+	return false
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package thorclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/logdb"
+)
+
+// logsNode serves the events of 100 blocks, block n having n%3 events. It rejects pages larger than limit,
+// and ranges larger than maxRange as too costly, or narrows them if downgrade is set, halving maxRange
+// for pages after the first as offsets add to the cost.
+type logsNode struct {
+	limit     uint64
+	maxRange  uint64
+	downgrade bool
+	failPage  atomic.Int32 // number of queries of a second page failing with a timeout
+	queries   atomic.Int32
+}
+
+func (n *logsNode) events(order logdb.Order, from, to uint64) []api.FilteredEvent {
+	var events []api.FilteredEvent
+	for b := from; b <= to; b++ {
+		for i := range b % 3 {
+			events = append(events, api.FilteredEvent{
+				Data: fmt.Sprintf("%d-%d", b, i),
+				Meta: api.LogMeta{BlockNumber: uint32(b)},
+			})
+		}
+	}
+	if order == logdb.DESC {
+		slices.Reverse(events)
+	}
+	return events
+}
+
+func (n *logsNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/blocks/best":
+		json.NewEncoder(w).Encode(&api.JSONCollapsedBlock{JSONBlockSummary: &api.JSONBlockSummary{Number: 99}})
+	case "/logs/event":
+		n.queries.Add(1)
+		var filter api.EventFilter
+		if err := json.NewDecoder(r.Body).Decode(&filter); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if filter.Options.Limit > n.limit {
+			http.Error(w, fmt.Sprintf("options.limit exceeds the maximum allowed value of %d", n.limit), http.StatusForbidden)
+			return
+		}
+		from, to := *filter.Range.From, *filter.Range.To
+		if maxRange := n.maxRange; n.downgrade {
+			if filter.Options.Offset > 0 {
+				maxRange = max(maxRange/2, 1)
+			}
+			if to-from+1 > maxRange {
+				if filter.Order == logdb.DESC {
+					from = to - maxRange + 1
+				} else {
+					to = from + maxRange - 1
+				}
+				w.Header().Set(api.LogsDowngradedRangeHeader, fmt.Sprintf("%d-%d", from, to))
+			}
+		} else if to-from+1 > maxRange {
+			http.Error(w, "query cost 100 exceeds the maximum allowed value of 10, please narrow the range or criteria", http.StatusForbidden)
+			return
+		}
+		if filter.Options.Offset > 0 && n.failPage.Add(-1) >= 0 {
+			http.Error(w, "query timeout, please narrow the range or criteria", http.StatusServiceUnavailable)
+			return
+		}
+		events := n.events(filter.Order, from, to)
+		start := min(filter.Options.Offset, uint64(len(events)))
+		end := min(start+filter.Options.Limit, uint64(len(events)))
+		json.NewEncoder(w).Encode(events[start:end])
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func collectEvents(t *testing.T, seq func(func(api.FilteredEvent, error) bool)) []string {
+	var got []string
+	for ev, err := range seq {
+		require.NoError(t, err)
+		got = append(got, ev.Data)
+	}
+	return got
+}
+
+func eventData(events []api.FilteredEvent) []string {
+	data := make([]string, 0, len(events))
+	for _, ev := range events {
+		data = append(data, ev.Data)
+	}
+	return data
+}
+
+func TestIterateEvents(t *testing.T) {
+	node := &logsNode{limit: 7, maxRange: 16}
+	ts := httptest.NewServer(node)
+	defer ts.Close()
+	client := New(ts.URL)
+	opts := &IterOptions{ChunkSize: 50, PageSize: 10}
+
+	t.Run("Asc", func(t *testing.T) {
+		got := collectEvents(t, client.IterateEvents(context.Background(), nil, opts))
+		assert.Equal(t, eventData(node.events(logdb.ASC, 0, 99)), got)
+	})
+
+	t.Run("Desc", func(t *testing.T) {
+		from, to := uint64(5), uint64(77)
+		got := collectEvents(t, client.IterateEvents(context.Background(), &api.EventFilter{
+			Range: &api.Range{Unit: api.BlockRangeType, From: &from, To: &to},
+			Order: logdb.DESC,
+		}, opts))
+		assert.Equal(t, eventData(node.events(logdb.DESC, 5, 77)), got)
+	})
+
+	t.Run("TimeoutWithinChunk", func(t *testing.T) {
+		// a chunk failing after its first page is resumed from the last block, without duplicates
+		node.failPage.Store(3)
+		got := collectEvents(t, client.IterateEvents(context.Background(), nil, &IterOptions{ChunkSize: 16, PageSize: 7}))
+		assert.Equal(t, eventData(node.events(logdb.ASC, 0, 99)), got)
+
+		node.failPage.Store(3)
+		got = collectEvents(t, client.IterateEvents(context.Background(), &api.EventFilter{Order: logdb.DESC}, &IterOptions{ChunkSize: 16, PageSize: 7}))
+		assert.Equal(t, eventData(node.events(logdb.DESC, 0, 99)), got)
+	})
+
+	t.Run("Lazy", func(t *testing.T) {
+		node.queries.Store(0)
+		var n int
+		for _, err := range client.IterateEvents(context.Background(), nil, &IterOptions{ChunkSize: 10, PageSize: 5}) {
+			require.NoError(t, err)
+			if n++; n == 3 {
+				break
+			}
+		}
+		assert.Equal(t, int32(1), node.queries.Load())
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var (
+			n       int
+			lastErr error
+		)
+		for _, err := range client.IterateEvents(ctx, nil, &IterOptions{ChunkSize: 10, PageSize: 5}) {
+			if err != nil {
+				lastErr = err
+				break
+			}
+			if n++; n == 5 {
+				cancel()
+			}
+		}
+		assert.ErrorIs(t, lastErr, context.Canceled)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		from, to := uint64(10), uint64(5)
+		for _, err := range client.IterateEvents(context.Background(), &api.EventFilter{
+			Range: &api.Range{Unit: api.BlockRangeType, From: &from, To: &to},
+		}, nil) {
+			assert.Error(t, err)
+		}
+		for _, err := range client.IterateEvents(context.Background(), &api.EventFilter{
+			Range: &api.Range{Unit: api.TimeRangeType},
+		}, nil) {
+			assert.Error(t, err)
+		}
+	})
+}
+
+func TestIterateEventsDowngraded(t *testing.T) {
+	node := &logsNode{limit: 7, maxRange: 16, downgrade: true}
+	ts := httptest.NewServer(node)
+	defer ts.Close()
+	client := New(ts.URL)
+
+	t.Run("Asc", func(t *testing.T) {
+		// chunks are narrowed by the node, pages after the first narrowed further
+		got := collectEvents(t, client.IterateEvents(context.Background(), nil, &IterOptions{ChunkSize: 50, PageSize: 7}))
+		assert.Equal(t, eventData(node.events(logdb.ASC, 0, 99)), got)
+	})
+
+	t.Run("Desc", func(t *testing.T) {
+		from, to := uint64(5), uint64(77)
+		got := collectEvents(t, client.IterateEvents(context.Background(), &api.EventFilter{
+			Range: &api.Range{Unit: api.BlockRangeType, From: &from, To: &to},
+			Order: logdb.DESC,
+		}, &IterOptions{ChunkSize: 50, PageSize: 7}))
+		assert.Equal(t, eventData(node.events(logdb.DESC, 5, 77)), got)
+	})
+}
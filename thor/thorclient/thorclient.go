@@ -33,12 +33,24 @@ type Client struct {
 }
 
 // New creates a new Client using the provided HTTP URL.
-func New(url string) *Client {
+// When several URLs are given, requests are balanced across the nodes with the default options,
+// see NewWithBalancer.
+func New(url string, urls ...string) *Client {
+	if len(urls) > 0 {
+		return NewWithBalancer(httpclient.NewBalancer(append([]string{url}, urls...), nil))
+	}
 	return &Client{
 		httpConn: httpclient.New(url),
 	}
 }
 
+// NewWithBalancer creates a new Client sending requests through the given balancer.
+func NewWithBalancer(b *httpclient.Balancer) *Client {
+	return &Client{
+		httpConn: httpclient.NewWithHTTP(b.URL(), &http.Client{Transport: b}),
+	}
+}
+
 // NewWithHTTP creates a new Client using the provided HTTP URL and HTTP client.
 func NewWithHTTP(url string, c *http.Client) *Client {
 	return &Client{
@@ -60,6 +72,15 @@ func NewWithWS(url string) (*Client, error) {
 	}, nil
 }
 
+// NewWithClients creates a new Client from the given HTTP and WebSocket clients.
+// The WebSocket client may be nil, disabling subscriptions.
+func NewWithClients(httpConn *httpclient.Client, wsConn *wsclient.Client) *Client {
+	return &Client{
+		httpConn: httpConn,
+		wsConn:   wsConn,
+	}
+}
+
 // Option represents a functional option for customizing client requests.
 type Option func(*getOptions)
 
@@ -265,6 +286,38 @@ func (c *Client) SubscribeTxPool(txID *thor.Bytes32) (*common.Subscription[*api.
 	return c.wsConn.SubscribeTxPool(txID)
 }
 
+// SubscribeEventsResumable subscribes to event updates over WebSocket, reconnecting when the connection drops.
+func (c *Client) SubscribeEventsResumable(pos string, filter *api.SubscriptionEventFilter, opts *wsclient.ReconnectOptions) (*wsclient.ResumableSubscription[*api.EventMessage], error) {
+	if c.wsConn == nil {
+		return nil, fmt.Errorf("not a websocket typed client")
+	}
+	return c.wsConn.SubscribeEventsResumable(pos, filter, opts)
+}
+
+// SubscribeTransfersResumable subscribes to transfer updates over WebSocket, reconnecting when the connection drops.
+func (c *Client) SubscribeTransfersResumable(pos string, filter *api.SubscriptionTransferFilter, opts *wsclient.ReconnectOptions) (*wsclient.ResumableSubscription[*api.TransferMessage], error) {
+	if c.wsConn == nil {
+		return nil, fmt.Errorf("not a websocket typed client")
+	}
+	return c.wsConn.SubscribeTransfersResumable(pos, filter, opts)
+}
+
+// SubscribeBlocksResumable subscribes to block updates over WebSocket, reconnecting when the connection drops.
+func (c *Client) SubscribeBlocksResumable(pos string, opts *wsclient.ReconnectOptions) (*wsclient.ResumableSubscription[*api.BlockMessage], error) {
+	if c.wsConn == nil {
+		return nil, fmt.Errorf("not a websocket typed client")
+	}
+	return c.wsConn.SubscribeBlocksResumable(pos, opts)
+}
+
+// SubscribeBeats2Resumable subscribes to Beat2 message updates over WebSocket, reconnecting when the connection drops.
+func (c *Client) SubscribeBeats2Resumable(pos string, opts *wsclient.ReconnectOptions) (*wsclient.ResumableSubscription[*api.Beat2Message], error) {
+	if c.wsConn == nil {
+		return nil, fmt.Errorf("not a websocket typed client")
+	}
+	return c.wsConn.SubscribeBeats2Resumable(pos, opts)
+}
+
 // convertToBatchCallData converts a transaction and sender address to batch call data format.
 func convertToBatchCallData(tx *tx.Transaction, addr *thor.Address) *api.BatchCallData {
 	cls := make(api.Clauses, len(tx.Clauses()))
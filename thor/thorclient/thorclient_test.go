@@ -128,3 +128,19 @@ func TestGetTransaction(t *testing.T) {
 		})
 	}
 }
+
+func TestNewWithSeveralURLs(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	down.Close()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// also serves the health checks of the balancer on /blocks/best
+		w.Write([]byte(`{"number":0,"id":"0x00000000851caf3cfdb6e899cf5958bfb1ac3413d346d43539627e6be7ec1b4a"}`))
+	}))
+	defer ts.Close()
+
+	client := New(down.URL, ts.URL)
+	tag, err := client.ChainTag()
+	assert.NoError(t, err)
+	assert.Equal(t, byte(0x4a), tag)
+}
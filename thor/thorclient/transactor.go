@@ -0,0 +1,411 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package thorclient
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/thorclient/common"
+	"github.com/vechain/thor/v2/tx"
+)
+
+const (
+	// vmInvocationGas is the extra gas a clause executing code needs on top of the
+	// gas used reported by the clause inspection.
+	vmInvocationGas = 15000
+
+	defaultExpiration   = 32
+	defaultPollInterval = time.Second
+)
+
+var (
+	// ErrTxExpired is returned when waiting for the receipt of a transaction that can no longer be packed.
+	ErrTxExpired = errors.New("transaction expired")
+	// ErrTxReverted is returned when the estimation of a transaction reverts.
+	ErrTxReverted = errors.New("transaction reverted")
+)
+
+// Delegator pays the gas of transactions on behalf of their origin (VIP-191).
+type Delegator interface {
+	// Address returns the address of the delegator, the gas payer of the transactions.
+	Address() thor.Address
+	// Delegate returns the delegator signature of the transaction sent by origin.
+	Delegate(trx *tx.Transaction, origin thor.Address) ([]byte, error)
+}
+
+// KeyDelegator is a delegator signing with a local private key.
+type KeyDelegator struct {
+	key *ecdsa.PrivateKey
+}
+
+// NewKeyDelegator creates a delegator signing with the given private key.
+func NewKeyDelegator(key *ecdsa.PrivateKey) *KeyDelegator {
+	return &KeyDelegator{key: key}
+}
+
+// Address returns the address of the delegator.
+func (d *KeyDelegator) Address() thor.Address {
+	return thor.Address(crypto.PubkeyToAddress(d.key.PublicKey))
+}
+
+// Delegate returns the delegator signature of the transaction sent by origin.
+func (d *KeyDelegator) Delegate(trx *tx.Transaction, origin thor.Address) ([]byte, error) {
+	return crypto.Sign(trx.DelegatorSigningHash(origin).Bytes(), d.key)
+}
+
+// RemoteDelegator is a delegator service reached over HTTP. The service is given the
+// origin and the raw unsigned transaction, and returns the delegator signature.
+type RemoteDelegator struct {
+	url     string
+	address thor.Address
+	c       *http.Client
+}
+
+// NewRemoteDelegator creates a delegator requesting signatures from the service at url,
+// which signs with the key of the given address.
+func NewRemoteDelegator(url string, address thor.Address) *RemoteDelegator {
+	return &RemoteDelegator{url: url, address: address, c: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Address returns the address of the delegator.
+func (d *RemoteDelegator) Address() thor.Address {
+	return d.address
+}
+
+// Delegate returns the delegator signature of the transaction sent by origin.
+func (d *RemoteDelegator) Delegate(trx *tx.Transaction, origin thor.Address) ([]byte, error) {
+	raw, err := trx.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode transaction - %w", err)
+	}
+	data, err := json.Marshal(&struct {
+		Origin thor.Address `json:"origin"`
+		Raw    string       `json:"raw"`
+	}{origin, hexutil.Encode(raw)})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := d.c.Post(d.url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("unable to request delegation - %w", err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read delegation response - %w", err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("delegation rejected - Status Code %d - %s", res.StatusCode, body)
+	}
+
+	var result struct {
+		Signature string `json:"signature"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal delegation response - %w", err)
+	}
+	sig, err := hexutil.Decode(result.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("invalid delegator signature - %w", err)
+	}
+	pub, err := crypto.SigToPub(trx.DelegatorSigningHash(origin).Bytes(), sig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid delegator signature - %w", err)
+	}
+	if signer := thor.Address(crypto.PubkeyToAddress(*pub)); signer != d.address {
+		return nil, fmt.Errorf("delegator signature of %v, want %v", signer, d.address)
+	}
+	return sig, nil
+}
+
+// TransactorOption represents a functional option for customizing a Transactor.
+type TransactorOption func(*Transactor)
+
+// WithDelegator makes the transactor send delegated transactions, with the gas paid by the delegator.
+func WithDelegator(d Delegator) TransactorOption {
+	return func(t *Transactor) {
+		t.delegator = d
+	}
+}
+
+// WithLegacyTx makes the transactor send legacy transactions with the given gas price coefficient,
+// even after the GALACTICA fork.
+func WithLegacyTx(gasPriceCoef uint8) TransactorOption {
+	return func(t *Transactor) {
+		t.legacy = true
+		t.gasPriceCoef = gasPriceCoef
+	}
+}
+
+// WithExpiration sets the number of blocks after the current best block during which transactions can be packed.
+func WithExpiration(expiration uint32) TransactorOption {
+	return func(t *Transactor) {
+		t.expiration = expiration
+	}
+}
+
+// WithGasMargin sets the extra gas added to the estimated gas of transactions.
+func WithGasMargin(margin uint64) TransactorOption {
+	return func(t *Transactor) {
+		t.gasMargin = margin
+	}
+}
+
+// WithConfirmations sets the number of blocks to wait on top of the block including a transaction
+// before its receipt is returned.
+func WithConfirmations(confirmations uint32) TransactorOption {
+	return func(t *Transactor) {
+		t.confirmations = confirmations
+	}
+}
+
+// WithPollInterval sets the interval between checks while waiting for receipts.
+func WithPollInterval(interval time.Duration) TransactorOption {
+	return func(t *Transactor) {
+		t.pollInterval = interval
+	}
+}
+
+// Transactor builds, signs and sends transactions from a single origin account.
+type Transactor struct {
+	client *Client
+	key    *ecdsa.PrivateKey
+	origin thor.Address
+
+	delegator     Delegator
+	legacy        bool
+	gasPriceCoef  uint8
+	expiration    uint32
+	gasMargin     uint64
+	confirmations uint32
+	pollInterval  time.Duration
+
+	mu       sync.Mutex
+	chainTag *byte
+}
+
+// NewTransactor creates a transactor sending transactions signed with the given private key.
+func NewTransactor(client *Client, key *ecdsa.PrivateKey, opts ...TransactorOption) *Transactor {
+	t := &Transactor{
+		client:       client,
+		key:          key,
+		origin:       thor.Address(crypto.PubkeyToAddress(key.PublicKey)),
+		expiration:   defaultExpiration,
+		pollInterval: defaultPollInterval,
+	}
+	for _, o := range opts {
+		o(t)
+	}
+	return t
+}
+
+// Origin returns the address sending the transactions.
+func (t *Transactor) Origin() thor.Address {
+	return t.origin
+}
+
+// Build builds an unsigned transaction for the given clauses. The chain tag, block ref and
+// fees are taken from the node, and the gas is estimated by inspecting the clauses.
+func (t *Transactor) Build(clauses ...*tx.Clause) (*tx.Transaction, error) {
+	chainTag, err := t.getChainTag()
+	if err != nil {
+		return nil, err
+	}
+	best, err := t.client.Block(common.BestRevision)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch best block - %w", err)
+	}
+
+	txType := tx.TypeLegacy
+	if !t.legacy && best.BaseFeePerGas != nil {
+		txType = tx.TypeDynamicFee
+	}
+	builder := tx.NewBuilder(txType).
+		ChainTag(chainTag).
+		Clauses(clauses).
+		BlockRef(tx.NewBlockRefFromID(best.ID)).
+		Expiration(t.expiration).
+		Nonce(randomNonce())
+	if t.delegator != nil {
+		var features tx.Features
+		features.SetDelegated(true)
+		builder.Features(features)
+	}
+
+	if txType == tx.TypeDynamicFee {
+		priority, err := t.client.FeesPriority()
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch priority fee - %w", err)
+		}
+		// leave room for the base fee to double before the transaction is packed
+		baseFee := (*big.Int)(best.BaseFeePerGas)
+		maxFee := new(big.Int).Add(new(big.Int).Mul(baseFee, big.NewInt(2)), priority.MaxPriorityFeePerGas.ToInt())
+		builder.MaxPriorityFeePerGas(priority.MaxPriorityFeePerGas.ToInt()).MaxFeePerGas(maxFee)
+	} else {
+		builder.GasPriceCoef(t.gasPriceCoef)
+	}
+
+	gas, err := t.EstimateGas(clauses, Revision(best.ID.String()))
+	if err != nil {
+		return nil, err
+	}
+	return builder.Gas(gas).Build(), nil
+}
+
+// EstimateGas estimates the gas needed by a transaction with the given clauses, including the
+// intrinsic gas and the gas margin.
+func (t *Transactor) EstimateGas(clauses []*tx.Clause, opts ...Option) (uint64, error) {
+	intrinsic, err := tx.IntrinsicGas(clauses...)
+	if err != nil {
+		return 0, err
+	}
+
+	calldata := &api.BatchCallData{
+		Clauses: make(api.Clauses, len(clauses)),
+		Caller:  &t.origin,
+	}
+	if t.delegator != nil {
+		payer := t.delegator.Address()
+		calldata.GasPayer = &payer
+	}
+	for i, c := range clauses {
+		calldata.Clauses[i] = convertClauseAccounts(c)
+	}
+	results, err := t.client.InspectClauses(calldata, opts...)
+	if err != nil {
+		return 0, fmt.Errorf("unable to inspect clauses - %w", err)
+	}
+
+	var execGas uint64
+	for i, res := range results {
+		if res.Reverted {
+			return 0, fmt.Errorf("clause %d: %w - %s", i, ErrTxReverted, res.VMError)
+		}
+		execGas += res.GasUsed
+	}
+	if execGas > 0 {
+		execGas += vmInvocationGas
+	}
+	return intrinsic + execGas + t.gasMargin, nil
+}
+
+// Sign signs the transaction with the origin key, and with the delegator for delegated transactions.
+func (t *Transactor) Sign(trx *tx.Transaction) (*tx.Transaction, error) {
+	sig, err := crypto.Sign(trx.SigningHash().Bytes(), t.key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign transaction - %w", err)
+	}
+	if trx.Features().IsDelegated() {
+		if t.delegator == nil {
+			return nil, errors.New("delegated transaction without delegator")
+		}
+		dSig, err := t.delegator.Delegate(trx, t.origin)
+		if err != nil {
+			return nil, err
+		}
+		sig = append(sig, dSig...)
+	}
+	return trx.WithSignature(sig), nil
+}
+
+// Send builds, signs and sends a transaction with the given clauses.
+func (t *Transactor) Send(clauses ...*tx.Clause) (*tx.Transaction, error) {
+	trx, err := t.Build(clauses...)
+	if err != nil {
+		return nil, err
+	}
+	if trx, err = t.Sign(trx); err != nil {
+		return nil, err
+	}
+	if _, err := t.client.SendTransaction(trx); err != nil {
+		return nil, err
+	}
+	return trx, nil
+}
+
+// SendAndWait sends a transaction with the given clauses and waits for its receipt.
+func (t *Transactor) SendAndWait(ctx context.Context, clauses ...*tx.Clause) (*api.Receipt, error) {
+	trx, err := t.Send(clauses...)
+	if err != nil {
+		return nil, err
+	}
+	return t.Wait(ctx, trx)
+}
+
+// Wait waits until the receipt of the transaction is on the best chain with enough confirmations.
+// A receipt dropped by a chain reorganization is waited for again, until the transaction expires.
+func (t *Transactor) Wait(ctx context.Context, trx *tx.Transaction) (*api.Receipt, error) {
+	id := trx.ID()
+	deadline := trx.BlockRef().Number() + trx.Expiration()
+
+	ticker := time.NewTicker(t.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		best, err := t.client.Block(common.BestRevision)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch best block - %w", err)
+		}
+		// pin the receipt to the best block, so that both come from the same chain
+		receipt, err := t.client.TransactionReceipt(&id, Revision(best.ID.String()))
+		switch {
+		case err == nil:
+			if best.Number-receipt.Meta.BlockNumber >= t.confirmations {
+				return receipt, nil
+			}
+		case errors.Is(err, common.ErrNotFound):
+			if best.Number > deadline {
+				return nil, ErrTxExpired
+			}
+		default:
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (t *Transactor) getChainTag() (byte, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.chainTag == nil {
+		tag, err := t.client.ChainTag()
+		if err != nil {
+			return 0, fmt.Errorf("unable to fetch chain tag - %w", err)
+		}
+		t.chainTag = &tag
+	}
+	return *t.chainTag, nil
+}
+
+func randomNonce() uint64 {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return binary.BigEndian.Uint64(b[:])
+}
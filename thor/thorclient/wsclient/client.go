@@ -8,6 +8,7 @@
 package wsclient
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"strings"
@@ -26,6 +27,7 @@ const readTimeout = 60 * time.Second
 type Client struct {
 	host   string
 	scheme string
+	dialer *websocket.Dialer
 }
 
 // NewClient creates a new WebSocket Client from the provided URL.
@@ -49,35 +51,29 @@ func NewClient(url string) (*Client, error) {
 	return &Client{
 		host:   strings.TrimSuffix(host, "/"),
 		scheme: scheme,
+		dialer: websocket.DefaultDialer,
 	}, nil
 }
 
+// NewClientWithDialer creates a new WebSocket Client from the provided URL, connecting with the given dialer.
+func NewClientWithDialer(url string, dialer *websocket.Dialer) (*Client, error) {
+	c, err := NewClient(url)
+	if err != nil {
+		return nil, err
+	}
+	c.dialer = dialer
+	return c, nil
+}
+
 // SubscribeEvents subscribes to blockchain events based on the provided query.
 // It returns a Subscription that streams event messages or an error if the connection fails.
 func (c *Client) SubscribeEvents(pos string, filter *api.SubscriptionEventFilter) (*common.Subscription[*api.EventMessage], error) {
-	queryValues := &url.Values{}
-	queryValues.Add("pos", pos)
-	if filter != nil {
-		if filter.Address != nil {
-			queryValues.Add("address", filter.Address.String())
-		}
-		if filter.Topic0 != nil {
-			queryValues.Add("topic0", filter.Topic0.String())
-		}
-		if filter.Topic1 != nil {
-			queryValues.Add("topic1", filter.Topic1.String())
-		}
-		if filter.Topic2 != nil {
-			queryValues.Add("topic2", filter.Topic2.String())
-		}
-		if filter.Topic3 != nil {
-			queryValues.Add("topic3", filter.Topic3.String())
-		}
-		if filter.Topic4 != nil {
-			queryValues.Add("topic4", filter.Topic4.String())
-		}
+	queryValues, err := eventQuery(filter)
+	if err != nil {
+		return nil, err
 	}
-	conn, _, err := c.Connect("/subscriptions/event", queryValues)
+	queryValues.Set("pos", pos)
+	conn, _, err := c.Connect("/subscriptions/event", &queryValues)
 	if err != nil {
 		return nil, fmt.Errorf("unable to connect - %w", err)
 	}
@@ -101,20 +97,12 @@ func (c *Client) SubscribeBlocks(pos string) (*common.Subscription[*api.BlockMes
 // SubscribeTransfers subscribes to transfer events based on the provided query.
 // It returns a Subscription that streams transfer messages or an error if the connection fails.
 func (c *Client) SubscribeTransfers(pos string, filter *api.SubscriptionTransferFilter) (*common.Subscription[*api.TransferMessage], error) {
-	queryValues := &url.Values{}
-	queryValues.Add("pos", pos)
-	if filter != nil {
-		if filter.TxOrigin != nil {
-			queryValues.Add("txOrigin", filter.TxOrigin.String())
-		}
-		if filter.Sender != nil {
-			queryValues.Add("sender", filter.Sender.String())
-		}
-		if filter.Recipient != nil {
-			queryValues.Add("recipient", filter.Recipient.String())
-		}
+	queryValues, err := transferQuery(filter)
+	if err != nil {
+		return nil, err
 	}
-	conn, _, err := c.Connect("/subscriptions/transfer", queryValues)
+	queryValues.Set("pos", pos)
+	conn, _, err := c.Connect("/subscriptions/transfer", &queryValues)
 	if err != nil {
 		return nil, fmt.Errorf("unable to connect - %w", err)
 	}
@@ -151,6 +139,65 @@ func (c *Client) SubscribeBeats2(pos string) (*common.Subscription[*api.Beat2Mes
 	return subscribe[api.Beat2Message](conn), nil
 }
 
+// eventQuery returns the query values of an event subscription filter, without the position.
+func eventQuery(filter *api.SubscriptionEventFilter) (url.Values, error) {
+	queryValues := url.Values{}
+	if filter == nil {
+		return queryValues, nil
+	}
+	if filter.Address != nil {
+		queryValues.Add("addr", filter.Address.String())
+	}
+	if filter.Topic0 != nil {
+		queryValues.Add("t0", filter.Topic0.String())
+	}
+	if filter.Topic1 != nil {
+		queryValues.Add("t1", filter.Topic1.String())
+	}
+	if filter.Topic2 != nil {
+		queryValues.Add("t2", filter.Topic2.String())
+	}
+	if filter.Topic3 != nil {
+		queryValues.Add("t3", filter.Topic3.String())
+	}
+	if filter.Topic4 != nil {
+		queryValues.Add("t4", filter.Topic4.String())
+	}
+	if len(filter.CriteriaSet) > 0 {
+		criteriaSet, err := json.Marshal(filter.CriteriaSet)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal criteria set - %w", err)
+		}
+		queryValues.Add("criteriaSet", string(criteriaSet))
+	}
+	return queryValues, nil
+}
+
+// transferQuery returns the query values of a transfer subscription filter, without the position.
+func transferQuery(filter *api.SubscriptionTransferFilter) (url.Values, error) {
+	queryValues := url.Values{}
+	if filter == nil {
+		return queryValues, nil
+	}
+	if filter.TxOrigin != nil {
+		queryValues.Add("txOrigin", filter.TxOrigin.String())
+	}
+	if filter.Sender != nil {
+		queryValues.Add("sender", filter.Sender.String())
+	}
+	if filter.Recipient != nil {
+		queryValues.Add("recipient", filter.Recipient.String())
+	}
+	if len(filter.CriteriaSet) > 0 {
+		criteriaSet, err := json.Marshal(filter.CriteriaSet)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal criteria set - %w", err)
+		}
+		queryValues.Add("criteriaSet", string(criteriaSet))
+	}
+	return queryValues, nil
+}
+
 // subscribe starts a new subscription over the given WebSocket connection.
 // It returns a read-only channel that streams events of type T.
 func subscribe[T any](conn *websocket.Conn) *common.Subscription[*T] {
@@ -210,7 +257,7 @@ func (c *Client) Connect(endpoint string, queryValues *url.Values) (*websocket.C
 		u.RawQuery = queryValues.Encode()
 	}
 
-	conn, res, err := websocket.DefaultDialer.Dial(u.String(), nil)
+	conn, res, err := c.dialer.Dial(u.String(), nil)
 	if err != nil {
 		if res != nil {
 			return nil, res.StatusCode, err
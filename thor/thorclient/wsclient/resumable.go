@@ -0,0 +1,379 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package wsclient
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/thorclient/common"
+)
+
+// ConnState is the connection state of a resumable subscription.
+type ConnState int
+
+const (
+	// Connected is reported once a dropped connection is re-established.
+	Connected ConnState = iota
+	// Reconnecting is reported before each reconnection attempt.
+	Reconnecting
+	// Disconnected is reported when the subscription gives up reconnecting.
+	Disconnected
+)
+
+func (s ConnState) String() string {
+	switch s {
+	case Connected:
+		return "connected"
+	case Reconnecting:
+		return "reconnecting"
+	case Disconnected:
+		return "disconnected"
+	}
+	return "unknown"
+}
+
+// Status is a connection state change of a resumable subscription.
+type Status struct {
+	State   ConnState
+	Pos     string // position the subscription resumes from
+	Attempt int    // reconnection attempt, starting from 1
+	Err     error  // error that caused the reconnection
+}
+
+// ReconnectOptions configures the reconnection of resumable subscriptions.
+type ReconnectOptions struct {
+	MinBackoff  time.Duration // delay before the first reconnection attempt
+	MaxBackoff  time.Duration // the delay is doubled on each attempt, up to MaxBackoff
+	MaxAttempts int           // consecutive attempts before giving up, zero retries forever
+}
+
+// DefaultReconnectOptions are the options used when none are given.
+var DefaultReconnectOptions = ReconnectOptions{
+	MinBackoff: 500 * time.Millisecond,
+	MaxBackoff: 30 * time.Second,
+}
+
+// ResumableSubscription is a subscription which reconnects when the connection drops, resuming from
+// the last received block. Messages of blocks received before the disconnection are not repeated, and
+// the messages of received blocks which left the canonical chain meanwhile are sent again as obsolete.
+//
+// StatusChan reports the reconnections, and is closed along with EventChan. Statuses are dropped if it is
+// not drained. EventChan only carries an error when the subscription gives up reconnecting.
+type ResumableSubscription[T any] struct {
+	*common.Subscription[T]
+	StatusChan <-chan Status
+}
+
+// SubscribeEventsResumable is like SubscribeEvents, but reconnects when the connection drops.
+// The default options are used if opts is nil.
+func (c *Client) SubscribeEventsResumable(pos string, filter *api.SubscriptionEventFilter, opts *ReconnectOptions) (*ResumableSubscription[*api.EventMessage], error) {
+	queryValues, err := eventQuery(filter)
+	if err != nil {
+		return nil, err
+	}
+	return resumable[api.EventMessage](c.resumeDialer("/subscriptions/event", queryValues), pos, opts)
+}
+
+// SubscribeTransfersResumable is like SubscribeTransfers, but reconnects when the connection drops.
+// The default options are used if opts is nil.
+func (c *Client) SubscribeTransfersResumable(pos string, filter *api.SubscriptionTransferFilter, opts *ReconnectOptions) (*ResumableSubscription[*api.TransferMessage], error) {
+	queryValues, err := transferQuery(filter)
+	if err != nil {
+		return nil, err
+	}
+	return resumable[api.TransferMessage](c.resumeDialer("/subscriptions/transfer", queryValues), pos, opts)
+}
+
+// SubscribeBlocksResumable is like SubscribeBlocks, but reconnects when the connection drops.
+// The default options are used if opts is nil.
+func (c *Client) SubscribeBlocksResumable(pos string, opts *ReconnectOptions) (*ResumableSubscription[*api.BlockMessage], error) {
+	return resumable[api.BlockMessage](c.resumeDialer("/subscriptions/block", url.Values{}), pos, opts)
+}
+
+// SubscribeBeats2Resumable is like SubscribeBeats2, but reconnects when the connection drops.
+// The default options are used if opts is nil.
+func (c *Client) SubscribeBeats2Resumable(pos string, opts *ReconnectOptions) (*ResumableSubscription[*api.Beat2Message], error) {
+	return resumable[api.Beat2Message](c.resumeDialer("/subscriptions/beat2", url.Values{}), pos, opts)
+}
+
+// resumeDialer returns a function connecting to the endpoint from the given position.
+func (c *Client) resumeDialer(endpoint string, queryValues url.Values) func(pos string) (*websocket.Conn, error) {
+	return func(pos string) (*websocket.Conn, error) {
+		q := url.Values{}
+		for k, v := range queryValues {
+			q[k] = v
+		}
+		q.Set("pos", pos)
+		conn, _, err := c.Connect(endpoint, &q)
+		if err != nil {
+			return nil, fmt.Errorf("unable to connect - %w", err)
+		}
+		return conn, nil
+	}
+}
+
+// resumable starts a subscription which reconnects with dial from the last received position.
+func resumable[T any](dial func(pos string) (*websocket.Conn, error), pos string, opts *ReconnectOptions) (*ResumableSubscription[*T], error) {
+	if opts == nil {
+		opts = &DefaultReconnectOptions
+	}
+	conn, err := dial(pos)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		eventChan  = make(chan common.EventWrapper[*T], 1_000)
+		statusChan = make(chan Status, 16)
+		done       = make(chan struct{})
+		mu         sync.Mutex
+		closed     bool
+		cursor     = newCursor[T](pos)
+	)
+
+	setConn := func(c *websocket.Conn) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		if closed {
+			return false
+		}
+		conn = c
+		return true
+	}
+	send := func(ev common.EventWrapper[*T]) bool {
+		select {
+		case eventChan <- ev:
+			return true
+		case <-done:
+			return false
+		}
+	}
+	report := func(s Status) {
+		select {
+		case statusChan <- s:
+		default:
+		}
+	}
+	// reconnect dials again with backoff, until it succeeds or gives up.
+	reconnect := func(cause error) (*websocket.Conn, error) {
+		backoff := opts.MinBackoff
+		for attempt := 1; opts.MaxAttempts == 0 || attempt <= opts.MaxAttempts; attempt++ {
+			report(Status{State: Reconnecting, Pos: cursor.pos, Attempt: attempt, Err: cause})
+
+			timer := time.NewTimer(backoff)
+			select {
+			case <-timer.C:
+			case <-done:
+				timer.Stop()
+				return nil, nil
+			}
+			backoff = min(backoff*2, opts.MaxBackoff)
+
+			c, err := dial(cursor.pos)
+			if err == nil {
+				report(Status{State: Connected, Pos: cursor.pos, Attempt: attempt})
+				return c, nil
+			}
+			cause = err
+		}
+		report(Status{State: Disconnected, Pos: cursor.pos, Err: cause})
+		return nil, cause
+	}
+
+	go func() {
+		defer close(statusChan)
+		defer close(eventChan)
+
+		c := conn
+		for {
+			c.SetReadDeadline(time.Now().Add(readTimeout))
+			var data T
+			if err := c.ReadJSON(&data); err != nil {
+				c.Close()
+				select {
+				case <-done:
+					return
+				default:
+				}
+				c, err = reconnect(err)
+				if c == nil {
+					if err != nil {
+						send(common.EventWrapper[*T]{Error: fmt.Errorf("%w: %w", common.ErrUnexpectedMsg, err)})
+					}
+					return
+				}
+				if !setConn(c) {
+					c.Close()
+					return
+				}
+				cursor.resume()
+				continue
+			}
+			for _, msg := range cursor.next(&data) {
+				if !send(common.EventWrapper[*T]{Data: msg}) {
+					return
+				}
+			}
+		}
+	}()
+
+	return &ResumableSubscription[*T]{
+		Subscription: &common.Subscription[*T]{
+			EventChan: eventChan,
+			Unsubscribe: func() error {
+				mu.Lock()
+				defer mu.Unlock()
+				if closed {
+					return nil
+				}
+				closed = true
+				close(done)
+				err := conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+				if err != nil {
+					conn.Close()
+					return fmt.Errorf("failed to issue close message: %w", err)
+				}
+				if err := conn.Close(); err != nil {
+					return fmt.Errorf("failed to close connections: %w", err)
+				}
+				return nil
+			},
+		},
+		StatusChan: statusChan,
+	}, nil
+}
+
+// cursor tracks the position of a subscription, to resume it after a reconnection.
+//
+// Block and beat messages carry whole blocks, so the subscription resumes from the last received one.
+// Events and transfers of a block are spread over several messages, so the subscription resumes from the
+// last block received in full, and the messages of the following block are either skipped as duplicates,
+// or sent again as obsolete if that block left the canonical chain.
+type cursor[T any] struct {
+	pos string // position to resume from
+
+	cur       thor.Bytes32 // block of the last messages, which may not have been received in full
+	curMsgs   []*T         // messages received of cur
+	skip      int          // messages of cur to skip after resuming
+	resolving bool         // whether cur is unresolved after resuming
+
+	obsolete map[thor.Bytes32]int // obsolete messages received since pos, per block
+	replayed map[thor.Bytes32]int // obsolete messages to skip after resuming, per block
+}
+
+func newCursor[T any](pos string) *cursor[T] {
+	return &cursor[T]{
+		pos:      pos,
+		obsolete: make(map[thor.Bytes32]int),
+		replayed: make(map[thor.Bytes32]int),
+	}
+}
+
+// resume prepares the cursor for the messages of a new connection from pos.
+func (c *cursor[T]) resume() {
+	c.skip = len(c.curMsgs)
+	c.resolving = c.skip > 0
+	clear(c.replayed)
+	for id, n := range c.obsolete {
+		c.replayed[id] = n
+	}
+}
+
+// next returns the messages to deliver for a received message.
+func (c *cursor[T]) next(msg *T) []*T {
+	id, parentID, whole, obsolete := messageBlock(msg)
+	if whole {
+		// the node resumes after pos, and walks back from it if it is obsolete
+		if obsolete {
+			c.pos = parentID.String()
+		} else {
+			c.pos = id.String()
+		}
+		return []*T{msg}
+	}
+
+	var out []*T
+	if obsolete {
+		// the node walks back from pos, so the unresolved block has left the canonical chain
+		if c.resolving {
+			out = c.obsoleteCur()
+		}
+		if c.replayed[id] > 0 {
+			c.replayed[id]--
+			return out
+		}
+		c.obsolete[id]++
+		if id == c.cur {
+			c.cur, c.curMsgs = thor.Bytes32{}, nil
+		}
+		return append(out, msg)
+	}
+
+	if c.resolving {
+		if id == c.cur {
+			c.skip--
+			c.resolving = c.skip > 0
+			return nil
+		}
+		// the node moved past the unresolved block without sending it again
+		out = c.obsoleteCur()
+	}
+	if id != c.cur {
+		if !c.cur.IsZero() {
+			c.pos = c.cur.String()
+			clear(c.obsolete)
+		}
+		c.cur, c.curMsgs = id, nil
+	}
+	c.curMsgs = append(c.curMsgs, msg)
+	return append(out, msg)
+}
+
+// obsoleteCur returns the received messages of the current block, marked as obsolete.
+func (c *cursor[T]) obsoleteCur() []*T {
+	out := make([]*T, 0, len(c.curMsgs))
+	for _, msg := range c.curMsgs {
+		out = append(out, markObsolete(msg))
+	}
+	c.cur, c.curMsgs = thor.Bytes32{}, nil
+	c.skip, c.resolving = 0, false
+	return out
+}
+
+// messageBlock returns the block of a message, and whether the message carries the whole block.
+func messageBlock(msg any) (id, parentID thor.Bytes32, whole, obsolete bool) {
+	switch m := msg.(type) {
+	case *api.BlockMessage:
+		return m.ID, m.ParentID, true, m.Obsolete
+	case *api.Beat2Message:
+		return m.ID, m.ParentID, true, m.Obsolete
+	case *api.EventMessage:
+		return m.Meta.BlockID, thor.Bytes32{}, false, m.Obsolete
+	case *api.TransferMessage:
+		return m.Meta.BlockID, thor.Bytes32{}, false, m.Obsolete
+	}
+	return thor.Bytes32{}, thor.Bytes32{}, true, false
+}
+
+func markObsolete[T any](msg *T) *T {
+	switch m := any(msg).(type) {
+	case *api.EventMessage:
+		c := *m
+		c.Obsolete = true
+		return any(&c).(*T)
+	case *api.TransferMessage:
+		c := *m
+		c.Obsolete = true
+		return any(&c).(*T)
+	}
+	return msg
+}
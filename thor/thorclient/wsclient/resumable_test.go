@@ -0,0 +1,249 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package wsclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/test/datagen"
+	"github.com/vechain/thor/v2/thor"
+)
+
+var testReconnectOptions = &ReconnectOptions{MinBackoff: 10 * time.Millisecond, MaxBackoff: 50 * time.Millisecond}
+
+// serveSessions serves one session per connection, dropping the connection at the end of each session.
+func serveSessions(t *testing.T, sessions ...func(pos string) []any) *httptest.Server {
+	var n atomic.Int32
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i := int(n.Add(1)) - 1
+		if i >= len(sessions) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		for _, msg := range sessions[i](r.URL.Query().Get("pos")) {
+			assert.NoError(t, conn.WriteJSON(msg))
+		}
+		if i == len(sessions)-1 {
+			// keep the last session open
+			conn.ReadMessage()
+		}
+	}))
+}
+
+func newEvent(blockID thor.Bytes32, data string, obsolete bool) *api.EventMessage {
+	return &api.EventMessage{Data: data, Meta: api.LogMeta{BlockID: blockID}, Obsolete: obsolete}
+}
+
+func receive[T any](t *testing.T, ch <-chan T) T {
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout")
+	}
+	var zero T
+	return zero
+}
+
+func TestResumable_Events(t *testing.T) {
+	a, b, c := datagen.RandomHash(), datagen.RandomHash(), datagen.RandomHash()
+
+	ts := serveSessions(t,
+		func(pos string) []any {
+			assert.Equal(t, "best", pos)
+			return []any{newEvent(a, "0x01", false), newEvent(b, "0x02", false), newEvent(b, "0x03", false)}
+		},
+		func(pos string) []any {
+			// resumed from the last block received in full, block b is sent again
+			assert.Equal(t, a.String(), pos)
+			return []any{newEvent(b, "0x02", false), newEvent(b, "0x03", false), newEvent(b, "0x04", false), newEvent(c, "0x05", false)}
+		},
+	)
+	defer ts.Close()
+
+	client, err := NewClient(ts.URL)
+	require.NoError(t, err)
+	sub, err := client.SubscribeEventsResumable("best", nil, testReconnectOptions)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	for _, data := range []string{"0x01", "0x02", "0x03", "0x04", "0x05"} {
+		ev := receive(t, sub.EventChan)
+		require.NoError(t, ev.Error)
+		assert.Equal(t, data, ev.Data.Data)
+		assert.False(t, ev.Data.Obsolete)
+	}
+
+	status := receive(t, sub.StatusChan)
+	assert.Equal(t, Reconnecting, status.State)
+	assert.Equal(t, 1, status.Attempt)
+	assert.Error(t, status.Err)
+	status = receive(t, sub.StatusChan)
+	assert.Equal(t, Connected, status.State)
+	assert.Equal(t, a.String(), status.Pos)
+}
+
+func TestResumable_EventsObsolete(t *testing.T) {
+	a, b, b2 := datagen.RandomHash(), datagen.RandomHash(), datagen.RandomHash()
+
+	ts := serveSessions(t,
+		func(pos string) []any {
+			return []any{newEvent(a, "0x01", false), newEvent(b, "0x02", false)}
+		},
+		func(pos string) []any {
+			// block b left the canonical chain during the disconnection
+			assert.Equal(t, a.String(), pos)
+			return []any{newEvent(b2, "0x03", false)}
+		},
+	)
+	defer ts.Close()
+
+	client, err := NewClient(ts.URL)
+	require.NoError(t, err)
+	sub, err := client.SubscribeEventsResumable("best", nil, testReconnectOptions)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	for _, want := range []*api.EventMessage{
+		newEvent(a, "0x01", false),
+		newEvent(b, "0x02", false),
+		newEvent(b, "0x02", true),
+		newEvent(b2, "0x03", false),
+	} {
+		ev := receive(t, sub.EventChan)
+		require.NoError(t, ev.Error)
+		assert.Equal(t, want, ev.Data)
+	}
+}
+
+func TestResumable_Blocks(t *testing.T) {
+	b1 := &api.BlockMessage{ID: datagen.RandomHash(), Number: 1}
+	b2 := &api.BlockMessage{ID: datagen.RandomHash(), ParentID: b1.ID, Number: 2}
+	b2Obsolete := *b2
+	b2Obsolete.Obsolete = true
+
+	ts := serveSessions(t,
+		func(pos string) []any {
+			return []any{b1, b2}
+		},
+		func(pos string) []any {
+			assert.Equal(t, b2.ID.String(), pos)
+			return []any{&b2Obsolete}
+		},
+		func(pos string) []any {
+			// resumed from the parent of the obsolete block
+			assert.Equal(t, b1.ID.String(), pos)
+			return nil
+		},
+	)
+	defer ts.Close()
+
+	client, err := NewClient(ts.URL)
+	require.NoError(t, err)
+	sub, err := client.SubscribeBlocksResumable("best", testReconnectOptions)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	for _, want := range []*api.BlockMessage{b1, b2, &b2Obsolete} {
+		ev := receive(t, sub.EventChan)
+		require.NoError(t, ev.Error)
+		assert.Equal(t, want, ev.Data)
+	}
+
+	// wait for the last session
+	for {
+		status := receive(t, sub.StatusChan)
+		if status.State == Connected && status.Pos == b1.ID.String() {
+			break
+		}
+	}
+}
+
+func TestResumable_GiveUp(t *testing.T) {
+	ts := serveSessions(t, func(pos string) []any { return nil })
+	ts.Close()
+
+	client, err := NewClient(ts.URL)
+	require.NoError(t, err)
+	_, err = client.SubscribeBlocksResumable("best", testReconnectOptions)
+	assert.Error(t, err, "initial connection failure is returned")
+
+	// the node serves a single block, then goes down
+	var served atomic.Bool
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if served.Swap(true) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+		conn.WriteJSON(&api.BlockMessage{})
+	}))
+	defer ts.Close()
+	client, err = NewClient(ts.URL)
+	require.NoError(t, err)
+
+	opts := *testReconnectOptions
+	opts.MaxAttempts = 2
+	sub, err := client.SubscribeBlocksResumable("best", &opts)
+	require.NoError(t, err)
+
+	ev := receive(t, sub.EventChan)
+	require.NoError(t, ev.Error)
+
+	ev = receive(t, sub.EventChan)
+	assert.Error(t, ev.Error)
+	_, ok := <-sub.EventChan
+	assert.False(t, ok)
+
+	var states []ConnState
+	for status := range sub.StatusChan {
+		states = append(states, status.State)
+	}
+	assert.Equal(t, []ConnState{Reconnecting, Reconnecting, Disconnected}, states)
+}
+
+func TestResumable_Unsubscribe(t *testing.T) {
+	ts := serveSessions(t, func(pos string) []any { return []any{&api.BlockMessage{}} })
+	defer ts.Close()
+
+	client, err := NewClient(ts.URL)
+	require.NoError(t, err)
+	sub, err := client.SubscribeBlocksResumable("best", testReconnectOptions)
+	require.NoError(t, err)
+
+	receive(t, sub.EventChan)
+	assert.NoError(t, sub.Unsubscribe())
+
+	_, ok := receiveOK(t, sub.EventChan)
+	assert.False(t, ok)
+	_, ok = receiveOK(t, sub.StatusChan)
+	assert.False(t, ok, "no reconnection after unsubscribe")
+}
+
+func receiveOK[T any](t *testing.T, ch <-chan T) (T, bool) {
+	select {
+	case v, ok := <-ch:
+		return v, ok
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout")
+	}
+	var zero T
+	return zero, false
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// HardenedOffset is the index of the first hardened child key.
+const HardenedOffset = 0x80000000
+
+// Path is a BIP-32 derivation path, of the child indexes from the master key.
+type Path []uint32
+
+// VeChainPath returns the path of the key at the index, m/44'/818'/0'/0/index as specified by SLIP-44.
+func VeChainPath(index uint32) Path {
+	return Path{44 + HardenedOffset, 818 + HardenedOffset, HardenedOffset, 0, index}
+}
+
+// ParsePath parses a path like m/44'/818'/0'/0/0, where hardened indexes end with ' or h.
+func ParsePath(s string) (Path, error) {
+	parts := strings.Split(strings.TrimSpace(s), "/")
+	if parts[0] != "m" {
+		return nil, errors.Errorf("invalid path %q, want to start with m", s)
+	}
+	path := make(Path, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		offset := uint32(0)
+		if trimmed := strings.TrimRight(part, "'h"); len(trimmed) == len(part)-1 {
+			part, offset = trimmed, HardenedOffset
+		}
+		i, err := strconv.ParseUint(part, 10, 32)
+		if err != nil || i >= HardenedOffset {
+			return nil, errors.Errorf("invalid path %q, bad index %q", s, part)
+		}
+		path = append(path, uint32(i)+offset)
+	}
+	return path, nil
+}
+
+func (p Path) String() string {
+	var b strings.Builder
+	b.WriteString("m")
+	for _, i := range p {
+		if i >= HardenedOffset {
+			fmt.Fprintf(&b, "/%v'", i-HardenedOffset)
+		} else {
+			fmt.Fprintf(&b, "/%v", i)
+		}
+	}
+	return b.String()
+}
+
+// ExtendedKey is a BIP-32 extended private key.
+type ExtendedKey struct {
+	key       *ecdsa.PrivateKey
+	chainCode []byte
+}
+
+// NewMasterKey returns the master key of the seed.
+func NewMasterKey(seed []byte) (*ExtendedKey, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, errors.Errorf("invalid seed length %v, want 16 to 64 bytes", len(seed))
+	}
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	key, err := crypto.ToECDSA(sum[:32])
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid master key")
+	}
+	return &ExtendedKey{key: key, chainCode: sum[32:]}, nil
+}
+
+// Child returns the child key at the index, hardened if the index is not less than HardenedOffset.
+func (k *ExtendedKey) Child(index uint32) (*ExtendedKey, error) {
+	var data []byte
+	if index >= HardenedOffset {
+		data = append([]byte{0}, math.PaddedBigBytes(k.key.D, 32)...)
+	} else {
+		data = crypto.CompressPubkey(&k.key.PublicKey)
+	}
+	data = binary.BigEndian.AppendUint32(data, index)
+
+	mac := hmac.New(sha512.New, k.chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	n := crypto.S256().Params().N
+	il := new(big.Int).SetBytes(sum[:32])
+	if il.Cmp(n) >= 0 {
+		return nil, errors.Errorf("invalid child key at index %v", index)
+	}
+	d := il.Add(il, k.key.D)
+	d.Mod(d, n)
+	if d.Sign() == 0 {
+		return nil, errors.Errorf("invalid child key at index %v", index)
+	}
+
+	key, err := crypto.ToECDSA(math.PaddedBigBytes(d, 32))
+	if err != nil {
+		return nil, err
+	}
+	return &ExtendedKey{key: key, chainCode: sum[32:]}, nil
+}
+
+// Derive returns the key at the path, relative to the key.
+func (k *ExtendedKey) Derive(path Path) (*ExtendedKey, error) {
+	key := k
+	for _, i := range path {
+		var err error
+		if key, err = key.Child(i); err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+// PrivateKey returns the private key.
+func (k *ExtendedKey) PrivateKey() *ecdsa.PrivateKey {
+	return k.key
+}
+
+// Address returns the address of the key.
+func (k *ExtendedKey) Address() thor.Address {
+	return thor.Address(crypto.PubkeyToAddress(k.key.PublicKey))
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package wallet
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Path
+		wantErr bool
+	}{
+		{"m", Path{}, false},
+		{"m/44'/818'/0'/0/3", VeChainPath(3), false},
+		{"m/44h/818h/0h/0/3", VeChainPath(3), false},
+		{"m/0/2147483647'", Path{0, HardenedOffset + 2147483647}, false},
+		{"44'/818'", nil, true},
+		{"m/x", nil, true},
+		{"m/2147483648", nil, true},
+		{"m/1''", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			path, err := ParsePath(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, path)
+		})
+	}
+
+	assert.Equal(t, "m/44'/818'/0'/0/7", VeChainPath(7).String())
+}
+
+func TestDerive(t *testing.T) {
+	// BIP-32 test vector 1
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, err := NewMasterKey(seed)
+	require.NoError(t, err)
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"m", "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35"},
+		{"m/0'", "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{"m/0'/1", "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368"},
+		{"m/0'/1/2'", "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca"},
+	}
+	for _, tt := range tests {
+		path, err := ParsePath(tt.path)
+		require.NoError(t, err)
+		key, err := master.Derive(path)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, hex.EncodeToString(crypto.FromECDSA(key.PrivateKey())), tt.path)
+	}
+
+	_, err = NewMasterKey(seed[:8])
+	assert.Error(t, err)
+}
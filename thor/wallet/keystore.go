@@ -0,0 +1,188 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pborman/uuid"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// scrypt parameters of the encrypted keys, lowered in tests.
+var (
+	scryptN = keystore.StandardScryptN
+	scryptP = keystore.StandardScryptP
+)
+
+// EncryptKey encrypts the key into a keystore v3 JSON with the passphrase.
+func EncryptKey(key *ecdsa.PrivateKey, passphrase string) ([]byte, error) {
+	return keystore.EncryptKey(&keystore.Key{
+		PrivateKey: key,
+		Address:    crypto.PubkeyToAddress(key.PublicKey),
+		Id:         uuid.NewRandom(),
+	}, passphrase, scryptN, scryptP)
+}
+
+// DecryptKey decrypts the keystore v3 JSON with the passphrase.
+func DecryptKey(keyjson []byte, passphrase string) (*ecdsa.PrivateKey, error) {
+	if err := json.Unmarshal(keyjson, &map[string]any{}); err != nil {
+		return nil, errors.WithMessage(err, "unmarshal")
+	}
+	key, err := keystore.DecryptKey(keyjson, passphrase)
+	if err != nil {
+		return nil, errors.WithMessage(err, "decrypt")
+	}
+	return key.PrivateKey, nil
+}
+
+// KeyAddress returns the address of the keystore v3 JSON, without decrypting it.
+func KeyAddress(keyjson []byte) (thor.Address, error) {
+	var header struct {
+		Address string `json:"address"`
+	}
+	if err := json.Unmarshal(keyjson, &header); err != nil {
+		return thor.Address{}, errors.WithMessage(err, "unmarshal")
+	}
+	addr, err := thor.ParseAddress(header.Address)
+	if err != nil {
+		return thor.Address{}, errors.WithMessage(err, "address")
+	}
+	return addr, nil
+}
+
+// KeyStore is a directory of keystore v3 files, one key per file.
+type KeyStore struct {
+	dir string
+}
+
+// NewKeyStore returns the key store in the directory, creating it if missing.
+func NewKeyStore(dir string) (*KeyStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &KeyStore{dir: dir}, nil
+}
+
+// Dir returns the directory of the key store.
+func (ks *KeyStore) Dir() string {
+	return ks.dir
+}
+
+// List returns the addresses of the keys in the store, without decrypting them.
+func (ks *KeyStore) List() ([]thor.Address, error) {
+	files, err := ks.files()
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]thor.Address, 0, len(files))
+	for addr := range files {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+		return strings.Compare(addrs[i].String(), addrs[j].String()) < 0
+	})
+	return addrs, nil
+}
+
+// Has returns whether the store holds the key of the address.
+func (ks *KeyStore) Has(addr thor.Address) (bool, error) {
+	files, err := ks.files()
+	if err != nil {
+		return false, err
+	}
+	_, ok := files[addr]
+	return ok, nil
+}
+
+// Import encrypts the key with the passphrase and writes it into the store.
+func (ks *KeyStore) Import(key *ecdsa.PrivateKey, passphrase string) (thor.Address, error) {
+	addr := thor.Address(crypto.PubkeyToAddress(key.PublicKey))
+	if has, err := ks.Has(addr); err != nil {
+		return thor.Address{}, err
+	} else if has {
+		return thor.Address{}, errors.Errorf("key %v already exists", addr)
+	}
+	keyjson, err := EncryptKey(key, passphrase)
+	if err != nil {
+		return thor.Address{}, err
+	}
+	return addr, ks.write(addr, keyjson)
+}
+
+// ImportJSON checks the keystore v3 JSON decrypts with the passphrase, and copies it into the store.
+func (ks *KeyStore) ImportJSON(keyjson []byte, passphrase string) (thor.Address, error) {
+	key, err := DecryptKey(keyjson, passphrase)
+	if err != nil {
+		return thor.Address{}, err
+	}
+	addr := thor.Address(crypto.PubkeyToAddress(key.PublicKey))
+	if has, err := ks.Has(addr); err != nil {
+		return thor.Address{}, err
+	} else if has {
+		return thor.Address{}, errors.Errorf("key %v already exists", addr)
+	}
+	return addr, ks.write(addr, keyjson)
+}
+
+// Load decrypts the key of the address with the passphrase.
+func (ks *KeyStore) Load(addr thor.Address, passphrase string) (*ecdsa.PrivateKey, error) {
+	files, err := ks.files()
+	if err != nil {
+		return nil, err
+	}
+	path, ok := files[addr]
+	if !ok {
+		return nil, errors.Errorf("key %v not found", addr)
+	}
+	keyjson, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return DecryptKey(keyjson, passphrase)
+}
+
+func (ks *KeyStore) write(addr thor.Address, keyjson []byte) error {
+	ts := time.Now().UTC()
+	name := fmt.Sprintf("UTC--%s.%09dZ--%x",
+		ts.Format("2006-01-02T15-04-05"), ts.Nanosecond(), addr.Bytes())
+	return os.WriteFile(filepath.Join(ks.dir, name), keyjson, 0o600)
+}
+
+// files maps the addresses to the files of the store, skipping files which are not keystore v3 JSON.
+func (ks *KeyStore) files() (map[thor.Address]string, error) {
+	entries, err := os.ReadDir(ks.dir)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[thor.Address]string)
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(ks.dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		addr, err := KeyAddress(data)
+		if err != nil {
+			continue
+		}
+		files[addr] = path
+	}
+	return files, nil
+}
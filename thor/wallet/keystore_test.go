@@ -0,0 +1,102 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package wallet
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/thor"
+)
+
+func init() {
+	scryptN = keystore.LightScryptN
+	scryptP = keystore.LightScryptP
+}
+
+func TestEncryptDecryptKey(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	keyjson, err := EncryptKey(key, "pass")
+	require.NoError(t, err)
+
+	addr, err := KeyAddress(keyjson)
+	require.NoError(t, err)
+	assert.Equal(t, thor.Address(crypto.PubkeyToAddress(key.PublicKey)), addr)
+	_, err = KeyAddress([]byte(`{"version":3}`))
+	assert.Error(t, err)
+
+	decrypted, err := DecryptKey(keyjson, "pass")
+	require.NoError(t, err)
+	assert.Equal(t, crypto.FromECDSA(key), crypto.FromECDSA(decrypted))
+
+	_, err = DecryptKey(keyjson, "wrong")
+	assert.Error(t, err)
+
+	_, err = DecryptKey([]byte("not json"), "pass")
+	assert.Error(t, err)
+}
+
+func TestKeyStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "keys")
+	ks, err := NewKeyStore(dir)
+	require.NoError(t, err)
+
+	addrs, err := ks.List()
+	require.NoError(t, err)
+	assert.Empty(t, addrs)
+
+	key1, _ := crypto.GenerateKey()
+	key2, _ := crypto.GenerateKey()
+
+	addr1, err := ks.Import(key1, "pass1")
+	require.NoError(t, err)
+	assert.Equal(t, thor.Address(crypto.PubkeyToAddress(key1.PublicKey)), addr1)
+
+	_, err = ks.Import(key1, "pass1")
+	assert.Error(t, err, "duplicated key")
+
+	keyjson, err := EncryptKey(key2, "pass2")
+	require.NoError(t, err)
+	_, err = ks.ImportJSON(keyjson, "wrong")
+	assert.Error(t, err)
+	addr2, err := ks.ImportJSON(keyjson, "pass2")
+	require.NoError(t, err)
+
+	// unrelated files are skipped
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README"), []byte("hello"), 0o600))
+
+	addrs, err = ks.List()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []thor.Address{addr1, addr2}, addrs)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	for _, entry := range entries {
+		if entry.Name() == "README" {
+			continue
+		}
+		info, err := entry.Info()
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	}
+
+	loaded, err := ks.Load(addr2, "pass2")
+	require.NoError(t, err)
+	assert.Equal(t, crypto.FromECDSA(key2), crypto.FromECDSA(loaded))
+
+	_, err = ks.Load(addr2, "pass1")
+	assert.Error(t, err)
+
+	_, err = ks.Load(thor.Address{}, "pass1")
+	assert.Error(t, err)
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package wallet derives keys from BIP-39 mnemonics along the BIP-32 path of VeChain, and stores keys in encrypted
+// keystore v3 files.
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/tyler-smith/go-bip39"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// NewMnemonic generates a mnemonic of 12, 15, 18, 21 or 24 words.
+func NewMnemonic(words int) (string, error) {
+	if words < 12 || words > 24 || words%3 != 0 {
+		return "", errors.Errorf("invalid number of words %v, want 12, 15, 18, 21 or 24", words)
+	}
+	entropy, err := bip39.NewEntropy(words / 3 * 32)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// Wallet is the keys derived from a mnemonic, along the VeChain path m/44'/818'/0'/0.
+type Wallet struct {
+	root    *ExtendedKey
+	account *ExtendedKey
+}
+
+// New returns the wallet of the mnemonic and the optional BIP-39 passphrase.
+func New(mnemonic, passphrase string) (*Wallet, error) {
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid mnemonic")
+	}
+	root, err := NewMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	path := VeChainPath(0)
+	account, err := root.Derive(path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	return &Wallet{root: root, account: account}, nil
+}
+
+// Derive returns the key at the path, from the master key.
+func (w *Wallet) Derive(path Path) (*ExtendedKey, error) {
+	return w.root.Derive(path)
+}
+
+// Key returns the key at the index of the VeChain path.
+func (w *Wallet) Key(index uint32) (*ecdsa.PrivateKey, error) {
+	key, err := w.account.Child(index)
+	if err != nil {
+		return nil, err
+	}
+	return key.PrivateKey(), nil
+}
+
+// Address returns the address of the key at the index of the VeChain path.
+func (w *Wallet) Address(index uint32) (thor.Address, error) {
+	key, err := w.account.Child(index)
+	if err != nil {
+		return thor.Address{}, err
+	}
+	return key.Address(), nil
+}
+
+// Find returns the index of the address along the VeChain path, searching the indexes below the limit.
+func (w *Wallet) Find(addr thor.Address, limit uint32) (uint32, bool, error) {
+	for i := uint32(0); i < limit; i++ {
+		derived, err := w.Address(i)
+		if err != nil {
+			return 0, false, err
+		}
+		if derived == addr {
+			return i, true, nil
+		}
+	}
+	return 0, false, nil
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package wallet
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyler-smith/go-bip39"
+	"github.com/vechain/thor/v2/thor"
+)
+
+const testMnemonic = "ignore empty bird silly journey junior ripple have guard waste between tenant"
+
+func TestNewMnemonic(t *testing.T) {
+	for _, words := range []int{12, 15, 18, 21, 24} {
+		mnemonic, err := NewMnemonic(words)
+		require.NoError(t, err)
+		assert.Len(t, strings.Fields(mnemonic), words)
+		assert.True(t, bip39.IsMnemonicValid(mnemonic))
+	}
+	for _, words := range []int{0, 11, 13, 27} {
+		_, err := NewMnemonic(words)
+		assert.Error(t, err)
+	}
+}
+
+func TestWallet(t *testing.T) {
+	w, err := New(testMnemonic, "")
+	require.NoError(t, err)
+
+	addr, err := w.Address(0)
+	require.NoError(t, err)
+	assert.Equal(t, thor.MustParseAddress("0x339fb3c438606519e2c75bbf531fb43a0f449a70"), addr)
+
+	key, err := w.Key(0)
+	require.NoError(t, err)
+	assert.Equal(t, addr, thor.Address(crypto.PubkeyToAddress(key.PublicKey)))
+
+	derived, err := w.Derive(VeChainPath(5))
+	require.NoError(t, err)
+	addr5, err := w.Address(5)
+	require.NoError(t, err)
+	assert.Equal(t, derived.Address(), addr5)
+
+	index, found, err := w.Find(addr5, 10)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, uint32(5), index)
+
+	_, found, err = w.Find(addr5, 5)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	// the passphrase changes the seed
+	other, err := New(testMnemonic, "secret")
+	require.NoError(t, err)
+	otherAddr, err := other.Address(0)
+	require.NoError(t, err)
+	assert.NotEqual(t, addr, otherAddr)
+
+	// extra whitespace is tolerated
+	spaced, err := New("  "+strings.ReplaceAll(testMnemonic, " ", "  \n"), "")
+	require.NoError(t, err)
+	spacedAddr, err := spaced.Address(0)
+	require.NoError(t, err)
+	assert.Equal(t, addr, spacedAddr)
+
+	_, err = New("ignore empty bird silly journey junior ripple have guard waste between between", "")
+	assert.Error(t, err)
+}